@@ -17,6 +17,7 @@ limitations under the License.
 package v1beta1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -53,12 +54,152 @@ type ScaleStatus struct {
 	// +kubebuilder:validation:Minimum=0
 	// where the value must be a non-negative integer.
 	ScaledReplicas int32 `json:"scaledReplicas,omitempty"`
+	// TargetReplicas is the desired number of replicas, computed from
+	// OriginReplicas and the scale mode when the operation enters Pending.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	TargetReplicas *int32 `json:"targetReplicas,omitempty"`
+	// ScalingBeginTime is the time when the replicas started to be changed,
+	// the scale timeout applies from this time.
+	// +kubebuilder:validation:Optional
+	ScalingBeginTime metav1.Time `json:"scalingBeginTime,omitempty"`
+	// Attempts is the number of scaling attempts started, including retries.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	Attempts int32 `json:"attempts,omitempty"`
+	// NextRetryTime is when the next scaling attempt starts after a timeout.
+	// +kubebuilder:validation:Optional
+	NextRetryTime *metav1.Time `json:"nextRetryTime,omitempty"`
+	// ScaleUpProgress tracks the progress of a stepwise scale-up.
+	// +kubebuilder:validation:Optional
+	ScaleUpProgress *StepProgress `json:"scaleUpProgress,omitempty"`
+	// ScaleDownProgress tracks the progress of a stepwise restore.
+	// +kubebuilder:validation:Optional
+	ScaleDownProgress *StepProgress `json:"scaleDownProgress,omitempty"`
+	// Overlaps lists other active AlertScales (namespace/name) targeting the
+	// same workload. The largest target replica count among them wins.
+	// +kubebuilder:validation:Optional
+	Overlaps []string `json:"overlaps,omitempty"`
+	// DriftEvents records the most recent replica drifts detected while Scaled.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=10
+	DriftEvents []DriftEvent `json:"driftEvents,omitempty"`
+	// LastAction records the last extend/cancel action applied to the AlertScale.
+	// +kubebuilder:validation:Optional
+	LastAction *ScaleAction `json:"lastAction,omitempty"`
+	// Message provides additional information about the current status,
+	// e.g. why the scaling operation failed.
+	// +kubebuilder:validation:Optional
+	Message string `json:"message,omitempty"`
+}
+
+// ScaleAction records an extend or cancel action on a running AlertScale.
+type ScaleAction struct {
+	// Action is the type of the action (extend or cancel).
+	Action string `json:"action"`
+	// Operator is who requested the action.
+	// +kubebuilder:validation:Optional
+	Operator string `json:"operator,omitempty"`
+	// Reason is why the action was requested.
+	// +kubebuilder:validation:Optional
+	Reason string `json:"reason,omitempty"`
+	// Duration is how long the scaling was extended by.
+	// +kubebuilder:validation:Optional
+	Duration string `json:"duration,omitempty"`
+	// Time is when the action was applied.
+	Time metav1.Time `json:"time"`
+}
+
+// DriftEvent records a replica drift of the scale target.
+type DriftEvent struct {
+	// Time is when the drift was detected.
+	Time metav1.Time `json:"time"`
+	// ExpectedReplicas is the replica count the AlertScale expected.
+	ExpectedReplicas int32 `json:"expectedReplicas"`
+	// ObservedReplicas is the replica count found on the target.
+	ObservedReplicas int32 `json:"observedReplicas"`
+	// Action is the drift policy applied to the event.
+	Action string `json:"action"`
+}
+
+// VerticalScale defines the container resources raised by a vertical AlertScale.
+type VerticalScale struct {
+	// Containers lists the containers of the pod template to change. The given
+	// requests and limits override the current values, other resources are kept.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	Containers []ContainerResources `json:"containers"`
+}
+
+// ContainerResources records the resource requirements of a container in a pod template.
+type ContainerResources struct {
+	// Name is the name of the container.
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+	// Resources are the requests and limits of the container.
+	// +kubebuilder:validation:Optional
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+}
+
+// StepPolicy defines how replicas are changed in steps instead of all at once.
+type StepPolicy struct {
+	// ReplicasPerStep is the maximum number of replicas changed in a single step.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum=1
+	ReplicasPerStep int32 `json:"replicasPerStep"`
+	// StepInterval is the minimum time to wait between two steps.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Pattern=`^(\d+)([smhdw])$`
+	// where s=seconds, m=minutes, h=hours, d=days, w=weeks
+	StepInterval string `json:"stepInterval,omitempty"`
+	// WaitForReady indicates whether all replicas of the previous step must be
+	// ready before the next step starts.
+	// +kubebuilder:validation:Optional
+	WaitForReady bool `json:"waitForReady,omitempty"`
+}
+
+// ScaleDownPolicy defines how the original replicas are restored.
+type ScaleDownPolicy struct {
+	StepPolicy `json:",inline"`
+	// Paused holds the restore at the current step until it is cleared.
+	// +kubebuilder:validation:Optional
+	Paused bool `json:"paused,omitempty"`
+}
+
+// RetryPolicy defines how a timed out scaling operation is retried before
+// the AlertScale fails.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of scaling attempts, including the first one.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=10
+	MaxAttempts int32 `json:"maxAttempts"`
+	// Backoff is the wait before the first retry, doubled for every further retry.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Pattern=`^(\d+)([smhdw])$`
+	// +kubebuilder:default="30s"
+	// where s=seconds, m=minutes, h=hours, d=days, w=weeks
+	Backoff string `json:"backoff,omitempty"`
+}
+
+// StepProgress records the progress of a stepwise scaling operation.
+type StepProgress struct {
+	// CurrentStep is the number of steps applied so far.
+	CurrentStep int32 `json:"currentStep,omitempty"`
+	// TotalSteps is the number of steps planned to reach the target replicas.
+	TotalSteps int32 `json:"totalSteps,omitempty"`
+	// LastStepTime is the time when the last step was applied.
+	// +kubebuilder:validation:Optional
+	LastStepTime *metav1.Time `json:"lastStepTime,omitempty"`
 }
 
 // ScaleTarget defines the target resource for scaling operations.
 type ScaleTarget struct {
-	// Name is the name of the target resource.
-	// +kubebuilder:validation:Required
+	// Name is the name of the target resource. Required unless the AlertScale
+	// selects its targets with ScaleTargets or ScaleTargetSelector.
+	// +kubebuilder:validation:Optional
 	// +kubebuilder:validation:Type=string
 	// +kubebuilder:validation:Pattern=`^[a-zA-Z0-9]([-a-zA-Z0-9]*[a-zA-Z0-9])?$`
 	// where a-z, A-Z, 0-9, and '-' are allowed,
@@ -72,7 +213,8 @@ type ScaleTarget struct {
 	// and must start and end with a lowercase alphanumeric character.
 	Namespace string `json:"namespace,omitempty"`
 	// Kind is the kind of the target resource (e.g., Deployment, StatefulSet).
-	// +kubebuilder:validation:Required
+	// Any kind exposing the /scale subresource is supported when APIVersion is set.
+	// +kubebuilder:validation:Optional
 	// +kubebuilder:validation:Type=string
 	// +kubebuilder:validation:Pattern=`^[A-Z][a-zA-Z0-9]*$`
 	// where the first character is uppercase and the rest are alphanumeric
@@ -87,6 +229,37 @@ type ScaleTarget struct {
 	// Labels is a map of labels for the target resource.
 }
 
+// GroupTarget is a member of a multi-target AlertScale.
+type GroupTarget struct {
+	ScaleTarget `json:",inline"`
+	// Replicas is the desired replica count of the target. When unset,
+	// ScaleThreshold, ScaleMode and the replica bounds are applied to the
+	// original replica count of the target.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	Replicas *int32 `json:"replicas,omitempty"`
+}
+
+// ScaleTargetSelector selects the workloads of a multi-target AlertScale by label.
+type ScaleTargetSelector struct {
+	// Kind is the kind of the selected workloads (e.g., Deployment, StatefulSet).
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^[A-Z][a-zA-Z0-9]*$`
+	Kind string `json:"kind"`
+	// APIVersion is the API version of the selected workloads.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z0-9._-]+/[a-zA-Z0-9._-]+$`
+	APIVersion string `json:"apiVersion,omitempty"`
+	// Namespace is the namespace to select workloads in, defaults to the
+	// namespace of the AlertScale.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Namespace string `json:"namespace,omitempty"`
+	// Selector is the label selector of the workloads. It must not be empty.
+	// +kubebuilder:validation:Required
+	Selector metav1.LabelSelector `json:"selector"`
+}
+
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
 // NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
 
@@ -109,12 +282,67 @@ type AlertScaleSpec struct {
 	// +kubebuilder:validation:Type=object
 	// +kubebuilder:validation:Required
 	ScaleTarget ScaleTarget `json:"scaleTarget,omitempty"`
-	// ScaleThreshold is the threshold for scaling alerts.
+	// ScaleTargets lists workloads scaled together as a group instead of the
+	// single ScaleTarget. The group is approved once, and scaling up and
+	// restoring either succeed for every member or are rolled back.
+	// +kubebuilder:validation:Optional
+	ScaleTargets []GroupTarget `json:"scaleTargets,omitempty"`
+	// ScaleTargetSelector adds the workloads matching a label selector to the
+	// group. The selector is evaluated once when the AlertScale enters Pending.
+	// +kubebuilder:validation:Optional
+	ScaleTargetSelector *ScaleTargetSelector `json:"scaleTargetSelector,omitempty"`
+	// ScaleType selects what the AlertScale changes: Horizontal changes the
+	// replica count, Vertical temporarily raises the container resources of
+	// the pod template as defined in VerticalScale. The replica settings are
+	// ignored for Vertical.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Enum=Horizontal;Vertical
+	// +kubebuilder:default=Horizontal
+	ScaleType string `json:"scaleType,omitempty"`
+	// VerticalScale defines the container resources applied for the scale
+	// duration when ScaleType is Vertical. Only Deployment and StatefulSet
+	// targets are supported.
+	// +kubebuilder:validation:Optional
+	VerticalScale *VerticalScale `json:"verticalScale,omitempty"`
+	// ScaleThreshold is interpreted according to ScaleMode: the desired replica
+	// count (Absolute), the replicas to add (Delta), or the percentage of the
+	// original replica count (Percentage).
 	// +kubebuilder:validation:Optional
 	// +kubebuilder:validation:Type=number
 	// +kubebuilder:validation:Minimum=0
-	// +kubebuilder:validation:Maximum=100
 	ScaleThreshold int32 `json:"scaleThreshold,omitempty"`
+	// ScaleMode defines how ScaleThreshold is applied to the original replica count.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Enum=Absolute;Delta;Percentage
+	// +kubebuilder:default=Absolute
+	ScaleMode string `json:"scaleMode,omitempty"`
+	// MinReplicas is the lower bound of the computed target replica count.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+	// MaxReplicas is the upper bound of the computed target replica count.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	MaxReplicas *int32 `json:"maxReplicas,omitempty"`
+	// ScaleUpPolicy enables stepwise scale-up. When unset, the target replicas
+	// are applied at once.
+	// +kubebuilder:validation:Optional
+	ScaleUpPolicy *StepPolicy `json:"scaleUpPolicy,omitempty"`
+	// ScaleDownPolicy enables stepwise restore of the original replicas when
+	// the scaling operation completes. When unset, the original replicas are
+	// restored at once.
+	// +kubebuilder:validation:Optional
+	ScaleDownPolicy *ScaleDownPolicy `json:"scaleDownPolicy,omitempty"`
+	// DriftPolicy defines how to react when the replicas of the target are
+	// changed by someone else while the AlertScale is Scaled: Reassert the
+	// scaled replicas, Adopt the new value, or Abort to Failed.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Enum=Reassert;Adopt;Abort
+	// +kubebuilder:default=Reassert
+	DriftPolicy string `json:"driftPolicy,omitempty"`
 	// ScaleNotification defines the notification settings for scaling alerts.
 	// +kubebuilder:validation:Optional
 	// +kubebuilder:validation:Type=string
@@ -133,6 +361,26 @@ type AlertScaleSpec struct {
 	// +kubebuilder:validation:Pattern=`^(\d+)([smhdw])$`
 	// where s=seconds, m=minutes, h=hours, d=days, w=weeks
 	ScaleTimeout string `json:"scaleTimeout,omitempty"`
+	// RetryPolicy retries a scaling operation that timed out instead of
+	// failing at once. When unset, the first timeout fails the AlertScale.
+	// +kubebuilder:validation:Optional
+	RetryPolicy *RetryPolicy `json:"retryPolicy,omitempty"`
+	// DryRun runs the full state machine, including approval and notifications,
+	// without modifying the workload or its HorizontalPodAutoscaler. The changes
+	// that would have been applied are recorded in status.plan instead.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	DryRun bool `json:"dryRun,omitempty"`
+	// CapacityCheckPolicy defines what happens when the capacity pre-check before
+	// scaling up finds that the schedulable nodes or the ResourceQuotas of the
+	// target namespace cannot hold the additional replicas: Block fails the
+	// AlertScale, Warn records a warning and scales anyway, Proceed only records
+	// the result in status.capacityCheck.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Enum=Block;Warn;Proceed
+	// +kubebuilder:default=Warn
+	CapacityCheckPolicy string `json:"capacityCheckPolicy,omitempty"`
 
 	// ScaleAutoApproval indicates whether the scaling operation requires auto-approval.
 	// +kubebuilder:validation:Optional
@@ -140,6 +388,32 @@ type AlertScaleSpec struct {
 	// +kubebuilder:default=false
 	// Example: true
 	ScaleAutoApproval bool `json:"scaleAutoApproval,omitempty"`
+
+	// AutoApprovalRules are CEL expressions evaluated while the AlertScale waits for approval.
+	// The AlertScale is approved by the first rule that evaluates to true, and the rule is
+	// recorded as the approver. Rules that do not match leave the AlertScale to manual approval.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=16
+	// +listType=map
+	// +listMapKey=name
+	AutoApprovalRules []AutoApprovalRule `json:"autoApprovalRules,omitempty"`
+}
+
+// AutoApprovalRule is a named CEL expression that approves an AlertScale when it evaluates to true.
+type AutoApprovalRule struct {
+	// Name identifies the rule. It is recorded as the approver in the form "rule:<name>".
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name"`
+
+	// Expression is a CEL expression returning a bool. The available variables are
+	// alertScale (the AlertScale object), target (the scale target object, empty for
+	// multi-target AlertScales), namespaceName, originReplicas, targetReplicas, delta
+	// (targetReplicas - originReplicas) and now (the current timestamp).
+	// Example: delta <= 2 && namespaceName != "prod"
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Expression string `json:"expression"`
 }
 
 // AlertScaleStatus defines the observed state of AlertScale.
@@ -148,6 +422,185 @@ type AlertScaleStatus struct {
 	// +kubebuilder:validation:Optional
 	// +kubebuilder:validation:Type=object
 	ScaleStatus ScaleStatus `json:"scaleStatus,omitempty"`
+	// OriginHPA records the original bounds of the HorizontalPodAutoscaler
+	// targeting the workload, so they can be restored after scaling.
+	// +kubebuilder:validation:Optional
+	OriginHPA *HPASnapshot `json:"originHPA,omitempty"`
+	// Targets records the state of every member of a multi-target AlertScale.
+	// The replica counts in ScaleStatus are the sums over the members.
+	// +kubebuilder:validation:Optional
+	Targets []TargetStatus `json:"targets,omitempty"`
+	// OriginResources records the original container resources of the pod
+	// template before a vertical scale, so they can be restored after scaling.
+	// +kubebuilder:validation:Optional
+	OriginResources []ContainerResources `json:"originResources,omitempty"`
+	// ObservedGeneration is the most recent generation observed by the controller.
+	// +kubebuilder:validation:Optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Conditions represent the latest available observations of the AlertScale:
+	// Approved, Progressing, Available and Failed.
+	// +listType=map
+	// +listMapKey=type
+	// +kubebuilder:validation:Optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// History records the most recent status transitions of the AlertScale.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=20
+	History []TransitionRecord `json:"history,omitempty"`
+	// Plan records the workload changes computed in dry-run mode instead of being applied.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=50
+	Plan []PlannedAction `json:"plan,omitempty"`
+	// CapacityCheck records the result of the capacity pre-check run before scaling up.
+	// +kubebuilder:validation:Optional
+	CapacityCheck *CapacityCheck `json:"capacityCheck,omitempty"`
+	// GuardrailViolations lists the ScaleGuardrail limits the AlertScale exceeded
+	// when it entered Pending. The AlertScale fails without scaling when it is not empty.
+	// +kubebuilder:validation:Optional
+	GuardrailViolations []GuardrailViolation `json:"guardrailViolations,omitempty"`
+	// Approvals records the approvals collected while waiting for the quorum of
+	// the ApprovalPolicies selecting the AlertScale.
+	// +kubebuilder:validation:Optional
+	Approvals []ApprovalRecord `json:"approvals,omitempty"`
+}
+
+// ApprovalRecord records an approval counted towards the quorum of ApprovalPolicies.
+type ApprovalRecord struct {
+	// Approver is the user who approved.
+	Approver string `json:"approver"`
+	// Groups are the approver groups the user belongs to, in the form "policy/group".
+	// +kubebuilder:validation:Optional
+	Groups []string `json:"groups,omitempty"`
+	// Time is when the approval was recorded.
+	Time metav1.Time `json:"time"`
+	// Reason is the reason given by the approver.
+	// +kubebuilder:validation:Optional
+	Reason string `json:"reason,omitempty"`
+}
+
+// GuardrailViolation records a ScaleGuardrail limit exceeded by an AlertScale.
+type GuardrailViolation struct {
+	// Guardrail is the name of the ScaleGuardrail.
+	Guardrail string `json:"guardrail"`
+	// Rule is the violated limit: MaxReplicas, MaxMultiplier, MaxDuration, AllowedKinds or AutoApproval.
+	Rule string `json:"rule"`
+	// Target is the scale target exceeding the limit, in the form "Kind namespace/name".
+	// Empty for limits on the AlertScale itself.
+	// +kubebuilder:validation:Optional
+	Target string `json:"target,omitempty"`
+	// Message describes the violation.
+	Message string `json:"message"`
+}
+
+// TargetStatus records the state of a member of a multi-target AlertScale.
+type TargetStatus struct {
+	// Target is the member workload.
+	Target ScaleTarget `json:"target"`
+	// Phase is the state of the member.
+	// +kubebuilder:validation:Enum=Pending;Scaling;Scaled;Restoring;Restored;Failed
+	Phase string `json:"phase"`
+	// OriginReplicas is the replica count of the member before scaling.
+	OriginReplicas int32 `json:"originReplicas"`
+	// TargetReplicas is the desired replica count of the member.
+	TargetReplicas int32 `json:"targetReplicas"`
+	// AvailableReplicas is the last observed available replica count of the member.
+	// +kubebuilder:validation:Optional
+	AvailableReplicas int32 `json:"availableReplicas,omitempty"`
+	// OriginHPA records the original bounds of the HorizontalPodAutoscaler
+	// targeting the member.
+	// +kubebuilder:validation:Optional
+	OriginHPA *HPASnapshot `json:"originHPA,omitempty"`
+	// Message describes the last failure or rollback of the member.
+	// +kubebuilder:validation:Optional
+	Message string `json:"message,omitempty"`
+}
+
+// CapacityCheck records whether the cluster can schedule the additional replicas
+// of a scale-up.
+type CapacityCheck struct {
+	// Time is when the check was run.
+	Time metav1.Time `json:"time"`
+	// Result is Sufficient, Insufficient, or Unknown when the check could not be
+	// completed, e.g. the pod template of the target is not readable.
+	// +kubebuilder:validation:Enum=Sufficient;Insufficient;Unknown
+	Result string `json:"result"`
+	// Policy is the capacity check policy applied to the result.
+	// +kubebuilder:validation:Optional
+	Policy string `json:"policy,omitempty"`
+	// AdditionalReplicas is the number of replicas added by the scale-up.
+	AdditionalReplicas int32 `json:"additionalReplicas"`
+	// Required is the resource requests of the pod template multiplied by AdditionalReplicas.
+	// +kubebuilder:validation:Optional
+	Required corev1.ResourceList `json:"required,omitempty"`
+	// Available is the allocatable minus requested resources summed over the
+	// schedulable nodes matching the nodeSelector and tolerations of the pod template.
+	// +kubebuilder:validation:Optional
+	Available corev1.ResourceList `json:"available,omitempty"`
+	// QuotaRemaining is the smallest remaining amount of each resource across the
+	// ResourceQuotas of the target namespace, keyed by the quota resource name.
+	// +kubebuilder:validation:Optional
+	QuotaRemaining corev1.ResourceList `json:"quotaRemaining,omitempty"`
+	// Message describes the shortfalls found, or why the check could not be completed.
+	// +kubebuilder:validation:Optional
+	Message string `json:"message,omitempty"`
+}
+
+// PlannedAction records a workload change that a dry-run AlertScale would have applied.
+type PlannedAction struct {
+	// Time is when the action was planned.
+	Time metav1.Time `json:"time"`
+	// Status is the AlertScale status in which the action was planned.
+	// +kubebuilder:validation:Optional
+	Status string `json:"status,omitempty"`
+	// Action is the planned change: Scale sets the replicas of the workload,
+	// RaiseHPA raises the replica bounds of its HorizontalPodAutoscaler,
+	// Resize sets the container resources of its pod template.
+	// +kubebuilder:validation:Enum=Scale;RaiseHPA;Resize
+	Action string `json:"action"`
+	// Target is the workload of the action as "Kind namespace/name".
+	// +kubebuilder:validation:Optional
+	Target string `json:"target,omitempty"`
+	// Replicas is the replica count the action would have applied, zero for Resize.
+	Replicas int32 `json:"replicas"`
+	// Resources are the container resources a Resize would have applied.
+	// +kubebuilder:validation:Optional
+	Resources []ContainerResources `json:"resources,omitempty"`
+	// Message is a human readable description of the action.
+	// +kubebuilder:validation:Optional
+	Message string `json:"message,omitempty"`
+}
+
+// TransitionRecord records a status transition of the AlertScale.
+type TransitionRecord struct {
+	// From is the status before the transition, empty for the initial transition.
+	// +kubebuilder:validation:Optional
+	From string `json:"from,omitempty"`
+	// To is the status after the transition.
+	To string `json:"to"`
+	// Time is when the transition happened.
+	Time metav1.Time `json:"time"`
+	// Actor is who triggered the transition, e.g. the approver or "system".
+	// +kubebuilder:validation:Optional
+	Actor string `json:"actor,omitempty"`
+	// Reason is a CamelCase reason for the transition, same as the condition reason.
+	// +kubebuilder:validation:Optional
+	Reason string `json:"reason,omitempty"`
+	// Message is a human readable description of the transition.
+	// +kubebuilder:validation:Optional
+	Message string `json:"message,omitempty"`
+}
+
+// HPASnapshot records the replica bounds of a HorizontalPodAutoscaler.
+type HPASnapshot struct {
+	// Name is the name of the HorizontalPodAutoscaler.
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+	// MinReplicas is the original minReplicas of the HorizontalPodAutoscaler.
+	// +kubebuilder:validation:Optional
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+	// MaxReplicas is the original maxReplicas of the HorizontalPodAutoscaler.
+	// +kubebuilder:validation:Required
+	MaxReplicas int32 `json:"maxReplicas"`
 }
 
 // +kubebuilder:object:root=true
@@ -155,11 +608,14 @@ type AlertScaleStatus struct {
 // +kubebuilder:resource:shortName=as;ascale
 // +kubebuilder:printcolumn:name="Target",type=string,JSONPath=`.spec.scaleTarget.name`
 // +kubebuilder:printcolumn:name="AutoApproval",type=boolean,JSONPath=`.spec.scaleAutoApproval`
+// +kubebuilder:printcolumn:name="DryRun",type=boolean,JSONPath=`.spec.dryRun`,priority=1
 // +kubebuilder:printcolumn:name="Status",type=string,JSONPath=`.status.scaleStatus.status`
 // +kubebuilder:printcolumn:name="Origin-Replicas",type=integer,JSONPath=`.status.scaleStatus.originReplicas`
+// +kubebuilder:printcolumn:name="Target-Replicas",type=integer,JSONPath=`.status.scaleStatus.targetReplicas`
 // +kubebuilder:printcolumn:name="Scaled-Replicas",type=integer,JSONPath=`.status.scaleStatus.scaledReplicas`
 // +kubebuilder:printcolumn:name="Scaled-Duration",type=string,JSONPath=`.spec.scaleDuration`
 // +kubebuilder:printcolumn:name="Threshold",type=integer,JSONPath=`.spec.scaleThreshold`
+// +kubebuilder:printcolumn:name="Mode",type=string,JSONPath=`.spec.scaleMode`
 // +kubebuilder:printcolumn:name="NotificationType",type=string,JSONPath=`.spec.scaleNotificationType`
 // +kubebuilder:printcolumn:name="MsgTemplate",type=string,JSONPath=`.spec.scaleNotifyMsgTemplate`
 // +kubebuilder:printcolumn:name="Reason",type=string,JSONPath=`.spec.scaleReason`
@@ -0,0 +1,93 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ApprovalPolicySpec defines which AlertScales need multi-party approval and who must approve them.
+type ApprovalPolicySpec struct {
+	// Namespaces lists the namespaces of the selected AlertScales.
+	// AlertScales in all namespaces are selected when empty.
+	// +kubebuilder:validation:Optional
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// Selector selects AlertScales by their labels. All AlertScales in the selected
+	// namespaces are selected when unset.
+	// Since the requester sets the labels, creating an AlertScale in a selected namespace
+	// that the selector does not match, or changing its labels so that the policy no longer
+	// selects it, requires the approve verb on alertscales.
+	// +kubebuilder:validation:Optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+
+	// ApproverGroups are the groups that must each approve a selected AlertScale.
+	// The AlertScale is approved once every group has its required number of approvals,
+	// and rejected by a single rejection from any member.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	// +listType=map
+	// +listMapKey=name
+	ApproverGroups []ApproverGroup `json:"approverGroups"`
+}
+
+// ApproverGroup is a set of users whose approvals count towards the same quorum.
+type ApproverGroup struct {
+	// Name identifies the group, e.g. owner or sre.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name"`
+
+	// Users are the usernames of the members, as recorded in the approval-operator annotation.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	Users []string `json:"users"`
+
+	// Required is the number of distinct members that must approve.
+	// A user in several groups of the same policy counts towards only one of them.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default:=1
+	Required int32 `json:"required,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster,shortName=ap
+// +kubebuilder:printcolumn:name="Namespaces",type="string",JSONPath=".spec.namespaces"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// ApprovalPolicy is the Schema for the approvalpolicies API.
+// It requires approvals from several approver groups before a selected AlertScale is approved.
+type ApprovalPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ApprovalPolicySpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ApprovalPolicyList contains a list of ApprovalPolicy.
+type ApprovalPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ApprovalPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ApprovalPolicy{}, &ApprovalPolicyList{})
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ScaleGuardrailSpec defines the limits applied to every AlertScale in the namespace.
+// Unset fields impose no limit. When several guardrails exist in a namespace,
+// an AlertScale must satisfy all of them.
+type ScaleGuardrailSpec struct {
+	// MaxReplicas is the maximum number of target replicas of each scale target.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	MaxReplicas *int32 `json:"maxReplicas,omitempty"`
+
+	// MaxMultiplier is the maximum ratio of the target replicas to the origin replicas
+	// of each scale target, e.g. "3" or "1.5". Targets with 0 origin replicas are not limited by it.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Pattern=`^\d+(\.\d+)?$`
+	MaxMultiplier string `json:"maxMultiplier,omitempty"`
+
+	// MaxDuration is the maximum scaleDuration of an AlertScale,
+	// and the maximum total duration after extending it through the extend action.
+	// Format: number followed by unit (s/m/h/d/w), e.g. "1d".
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Pattern=`^(\d+)([smhdw])$`
+	MaxDuration string `json:"maxDuration,omitempty"`
+
+	// AllowedKinds lists the kinds of scale targets AlertScales may scale, e.g. Deployment.
+	// All kinds are allowed when empty.
+	// +kubebuilder:validation:Optional
+	AllowedKinds []string `json:"allowedKinds,omitempty"`
+
+	// AllowAutoApproval indicates whether AlertScales may set scaleAutoApproval or autoApprovalRules.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:=true
+	AllowAutoApproval *bool `json:"allowAutoApproval,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Namespaced,shortName=sgr
+// +kubebuilder:printcolumn:name="Max-Replicas",type="integer",JSONPath=".spec.maxReplicas"
+// +kubebuilder:printcolumn:name="Max-Multiplier",type="string",JSONPath=".spec.maxMultiplier"
+// +kubebuilder:printcolumn:name="Max-Duration",type="string",JSONPath=".spec.maxDuration"
+// +kubebuilder:printcolumn:name="Auto-Approval",type="boolean",JSONPath=".spec.allowAutoApproval"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// ScaleGuardrail is the Schema for the scaleguardrails API.
+// It limits what AlertScales in its namespace may request. The limits are checked
+// when an AlertScale is created and again when it enters Pending.
+type ScaleGuardrail struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ScaleGuardrailSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ScaleGuardrailList contains a list of ScaleGuardrail.
+type ScaleGuardrailList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ScaleGuardrail `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ScaleGuardrail{}, &ScaleGuardrailList{})
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ScaleScheduleSpec defines the desired state of ScaleSchedule.
+type ScaleScheduleSpec struct {
+	// Schedule is a standard 5-field cron expression (minute hour day-of-month month day-of-week),
+	// or one of the macros @yearly, @monthly, @weekly, @daily and @hourly.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Schedule string `json:"schedule"`
+
+	// TimeZone is the IANA time zone the schedule is evaluated in, e.g. "Asia/Shanghai".
+	// Defaults to the time zone of the operator.
+	// +kubebuilder:validation:Optional
+	TimeZone *string `json:"timeZone,omitempty"`
+
+	// CatchUpPolicy controls what happens to runs missed while the operator was down
+	// or the schedule was suspended. Skip ignores missed runs. RunLatest starts the most
+	// recent missed run if its scale window is still open, for the remaining duration.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=Skip;RunLatest
+	// +kubebuilder:default:=Skip
+	CatchUpPolicy string `json:"catchUpPolicy,omitempty"`
+
+	// Suspend stops creating new AlertScales. Runs already started are not affected.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:=false
+	Suspend bool `json:"suspend,omitempty"`
+
+	// HistoryLimit is the number of finished AlertScales to keep.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:default:=3
+	HistoryLimit *int32 `json:"historyLimit,omitempty"`
+
+	// AlertScaleTemplate is the spec of the AlertScale created for each run,
+	// including the scale target, replicas and duration.
+	// +kubebuilder:validation:Required
+	AlertScaleTemplate AlertScaleSpec `json:"alertScaleTemplate"`
+}
+
+// ScaleScheduleStatus defines the observed state of ScaleSchedule.
+type ScaleScheduleStatus struct {
+	// LastScheduleTime is the scheduled time of the last run that was handled,
+	// either started or skipped.
+	// +kubebuilder:validation:Optional
+	LastScheduleTime *metav1.Time `json:"lastScheduleTime,omitempty"`
+
+	// NextScheduleTime is the scheduled time of the next run.
+	// +kubebuilder:validation:Optional
+	NextScheduleTime *metav1.Time `json:"nextScheduleTime,omitempty"`
+
+	// LastMissedTime is the scheduled time of the last run that was missed.
+	// +kubebuilder:validation:Optional
+	LastMissedTime *metav1.Time `json:"lastMissedTime,omitempty"`
+
+	// Active lists the AlertScales created by this schedule that have not finished yet.
+	// +kubebuilder:validation:Optional
+	Active []string `json:"active,omitempty"`
+
+	// Message provides additional information about the schedule,
+	// e.g. why the schedule could not be evaluated.
+	// +kubebuilder:validation:Optional
+	Message string `json:"message,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Namespaced,shortName=sched
+// +kubebuilder:printcolumn:name="Schedule",type="string",JSONPath=".spec.schedule"
+// +kubebuilder:printcolumn:name="Suspend",type="boolean",JSONPath=".spec.suspend"
+// +kubebuilder:printcolumn:name="Last-Schedule",type="date",JSONPath=".status.lastScheduleTime"
+// +kubebuilder:printcolumn:name="Next-Schedule",type="string",JSONPath=".status.nextScheduleTime"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// ScaleSchedule is the Schema for the scaleschedules API.
+type ScaleSchedule struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ScaleScheduleSpec   `json:"spec,omitempty"`
+	Status ScaleScheduleStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ScaleScheduleList contains a list of ScaleSchedule.
+type ScaleScheduleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ScaleSchedule `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ScaleSchedule{}, &ScaleScheduleList{})
+}
@@ -21,6 +21,7 @@ limitations under the License.
 package v1beta1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
@@ -30,7 +31,7 @@ func (in *AlertScale) DeepCopyInto(out *AlertScale) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
@@ -88,6 +89,53 @@ func (in *AlertScaleList) DeepCopyObject() runtime.Object {
 func (in *AlertScaleSpec) DeepCopyInto(out *AlertScaleSpec) {
 	*out = *in
 	out.ScaleTarget = in.ScaleTarget
+	if in.ScaleTargets != nil {
+		in, out := &in.ScaleTargets, &out.ScaleTargets
+		*out = make([]GroupTarget, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ScaleTargetSelector != nil {
+		in, out := &in.ScaleTargetSelector, &out.ScaleTargetSelector
+		*out = new(ScaleTargetSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VerticalScale != nil {
+		in, out := &in.VerticalScale, &out.VerticalScale
+		*out = new(VerticalScale)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MinReplicas != nil {
+		in, out := &in.MinReplicas, &out.MinReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxReplicas != nil {
+		in, out := &in.MaxReplicas, &out.MaxReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ScaleUpPolicy != nil {
+		in, out := &in.ScaleUpPolicy, &out.ScaleUpPolicy
+		*out = new(StepPolicy)
+		**out = **in
+	}
+	if in.ScaleDownPolicy != nil {
+		in, out := &in.ScaleDownPolicy, &out.ScaleDownPolicy
+		*out = new(ScaleDownPolicy)
+		**out = **in
+	}
+	if in.RetryPolicy != nil {
+		in, out := &in.RetryPolicy, &out.RetryPolicy
+		*out = new(RetryPolicy)
+		**out = **in
+	}
+	if in.AutoApprovalRules != nil {
+		in, out := &in.AutoApprovalRules, &out.AutoApprovalRules
+		*out = make([]AutoApprovalRule, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertScaleSpec.
@@ -104,6 +152,63 @@ func (in *AlertScaleSpec) DeepCopy() *AlertScaleSpec {
 func (in *AlertScaleStatus) DeepCopyInto(out *AlertScaleStatus) {
 	*out = *in
 	in.ScaleStatus.DeepCopyInto(&out.ScaleStatus)
+	if in.OriginHPA != nil {
+		in, out := &in.OriginHPA, &out.OriginHPA
+		*out = new(HPASnapshot)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Targets != nil {
+		in, out := &in.Targets, &out.Targets
+		*out = make([]TargetStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.OriginResources != nil {
+		in, out := &in.OriginResources, &out.OriginResources
+		*out = make([]ContainerResources, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = make([]TransitionRecord, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Plan != nil {
+		in, out := &in.Plan, &out.Plan
+		*out = make([]PlannedAction, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.CapacityCheck != nil {
+		in, out := &in.CapacityCheck, &out.CapacityCheck
+		*out = new(CapacityCheck)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GuardrailViolations != nil {
+		in, out := &in.GuardrailViolations, &out.GuardrailViolations
+		*out = make([]GuardrailViolation, len(*in))
+		copy(*out, *in)
+	}
+	if in.Approvals != nil {
+		in, out := &in.Approvals, &out.Approvals
+		*out = make([]ApprovalRecord, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertScaleStatus.
@@ -117,26 +222,25 @@ func (in *AlertScaleStatus) DeepCopy() *AlertScaleStatus {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PodRebalance) DeepCopyInto(out *PodRebalance) {
+func (in *ApprovalPolicy) DeepCopyInto(out *ApprovalPolicy) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodRebalance.
-func (in *PodRebalance) DeepCopy() *PodRebalance {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApprovalPolicy.
+func (in *ApprovalPolicy) DeepCopy() *ApprovalPolicy {
 	if in == nil {
 		return nil
 	}
-	out := new(PodRebalance)
+	out := new(ApprovalPolicy)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *PodRebalance) DeepCopyObject() runtime.Object {
+func (in *ApprovalPolicy) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -144,31 +248,31 @@ func (in *PodRebalance) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PodRebalanceList) DeepCopyInto(out *PodRebalanceList) {
+func (in *ApprovalPolicyList) DeepCopyInto(out *ApprovalPolicyList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]PodRebalance, len(*in))
+		*out = make([]ApprovalPolicy, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodRebalanceList.
-func (in *PodRebalanceList) DeepCopy() *PodRebalanceList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApprovalPolicyList.
+func (in *ApprovalPolicyList) DeepCopy() *ApprovalPolicyList {
 	if in == nil {
 		return nil
 	}
-	out := new(PodRebalanceList)
+	out := new(ApprovalPolicyList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *PodRebalanceList) DeepCopyObject() runtime.Object {
+func (in *ApprovalPolicyList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -176,237 +280,262 @@ func (in *PodRebalanceList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PodRebalanceSpec) DeepCopyInto(out *PodRebalanceSpec) {
+func (in *ApprovalPolicySpec) DeepCopyInto(out *ApprovalPolicySpec) {
 	*out = *in
-	in.Selector.DeepCopyInto(&out.Selector)
-	in.Strategy.DeepCopyInto(&out.Strategy)
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ApproverGroups != nil {
+		in, out := &in.ApproverGroups, &out.ApproverGroups
+		*out = make([]ApproverGroup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodRebalanceSpec.
-func (in *PodRebalanceSpec) DeepCopy() *PodRebalanceSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApprovalPolicySpec.
+func (in *ApprovalPolicySpec) DeepCopy() *ApprovalPolicySpec {
 	if in == nil {
 		return nil
 	}
-	out := new(PodRebalanceSpec)
+	out := new(ApprovalPolicySpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PodRebalanceStatus) DeepCopyInto(out *PodRebalanceStatus) {
+func (in *ApprovalRecord) DeepCopyInto(out *ApprovalRecord) {
 	*out = *in
-	in.RebalanceBeginTime.DeepCopyInto(&out.RebalanceBeginTime)
-	in.RebalanceEndTime.DeepCopyInto(&out.RebalanceEndTime)
-	if in.RebalancedPods != nil {
-		in, out := &in.RebalancedPods, &out.RebalancedPods
-		*out = make([]RebalancedPodInfo, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]v1.Condition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
+	in.Time.DeepCopyInto(&out.Time)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodRebalanceStatus.
-func (in *PodRebalanceStatus) DeepCopy() *PodRebalanceStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApprovalRecord.
+func (in *ApprovalRecord) DeepCopy() *ApprovalRecord {
 	if in == nil {
 		return nil
 	}
-	out := new(PodRebalanceStatus)
+	out := new(ApprovalRecord)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PodRebalanceStrategy) DeepCopyInto(out *PodRebalanceStrategy) {
+func (in *ApproverGroup) DeepCopyInto(out *ApproverGroup) {
 	*out = *in
-	if in.Parameters != nil {
-		in, out := &in.Parameters, &out.Parameters
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+	if in.Users != nil {
+		in, out := &in.Users, &out.Users
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	if in.Threshold != nil {
-		in, out := &in.Threshold, &out.Threshold
-		*out = new(RebalanceThreshold)
-		(*in).DeepCopyInto(*out)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApproverGroup.
+func (in *ApproverGroup) DeepCopy() *ApproverGroup {
+	if in == nil {
+		return nil
 	}
+	out := new(ApproverGroup)
+	in.DeepCopyInto(out)
+	return out
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodRebalanceStrategy.
-func (in *PodRebalanceStrategy) DeepCopy() *PodRebalanceStrategy {
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoApprovalRule) DeepCopyInto(out *AutoApprovalRule) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoApprovalRule.
+func (in *AutoApprovalRule) DeepCopy() *AutoApprovalRule {
 	if in == nil {
 		return nil
 	}
-	out := new(PodRebalanceStrategy)
+	out := new(AutoApprovalRule)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RebalanceThreshold) DeepCopyInto(out *RebalanceThreshold) {
+func (in *CapacityCheck) DeepCopyInto(out *CapacityCheck) {
 	*out = *in
-	if in.CPU != nil {
-		in, out := &in.CPU, &out.CPU
-		*out = new(int32)
-		**out = **in
+	in.Time.DeepCopyInto(&out.Time)
+	if in.Required != nil {
+		in, out := &in.Required, &out.Required
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
 	}
-	if in.Memory != nil {
-		in, out := &in.Memory, &out.Memory
-		*out = new(int32)
-		**out = **in
+	if in.Available != nil {
+		in, out := &in.Available, &out.Available
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
 	}
-	if in.PodCountImbalance != nil {
-		in, out := &in.PodCountImbalance, &out.PodCountImbalance
-		*out = new(int32)
-		**out = **in
+	if in.QuotaRemaining != nil {
+		in, out := &in.QuotaRemaining, &out.QuotaRemaining
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RebalanceThreshold.
-func (in *RebalanceThreshold) DeepCopy() *RebalanceThreshold {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CapacityCheck.
+func (in *CapacityCheck) DeepCopy() *CapacityCheck {
 	if in == nil {
 		return nil
 	}
-	out := new(RebalanceThreshold)
+	out := new(CapacityCheck)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RebalancedPodInfo) DeepCopyInto(out *RebalancedPodInfo) {
+func (in *ContainerResources) DeepCopyInto(out *ContainerResources) {
 	*out = *in
-	in.Timestamp.DeepCopyInto(&out.Timestamp)
+	in.Resources.DeepCopyInto(&out.Resources)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RebalancedPodInfo.
-func (in *RebalancedPodInfo) DeepCopy() *RebalancedPodInfo {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerResources.
+func (in *ContainerResources) DeepCopy() *ContainerResources {
 	if in == nil {
 		return nil
 	}
-	out := new(RebalancedPodInfo)
+	out := new(ContainerResources)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ScaleNotifyConfig) DeepCopyInto(out *ScaleNotifyConfig) {
+func (in *DriftEvent) DeepCopyInto(out *DriftEvent) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Time.DeepCopyInto(&out.Time)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaleNotifyConfig.
-func (in *ScaleNotifyConfig) DeepCopy() *ScaleNotifyConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriftEvent.
+func (in *DriftEvent) DeepCopy() *DriftEvent {
 	if in == nil {
 		return nil
 	}
-	out := new(ScaleNotifyConfig)
+	out := new(DriftEvent)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ScaleNotifyConfig) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ScaleNotifyConfigList) DeepCopyInto(out *ScaleNotifyConfigList) {
+func (in *GroupTarget) DeepCopyInto(out *GroupTarget) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]ScaleNotifyConfig, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	out.ScaleTarget = in.ScaleTarget
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaleNotifyConfigList.
-func (in *ScaleNotifyConfigList) DeepCopy() *ScaleNotifyConfigList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GroupTarget.
+func (in *GroupTarget) DeepCopy() *GroupTarget {
 	if in == nil {
 		return nil
 	}
-	out := new(ScaleNotifyConfigList)
+	out := new(GroupTarget)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ScaleNotifyConfigList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GuardrailViolation) DeepCopyInto(out *GuardrailViolation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GuardrailViolation.
+func (in *GuardrailViolation) DeepCopy() *GuardrailViolation {
+	if in == nil {
+		return nil
 	}
-	return nil
+	out := new(GuardrailViolation)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ScaleNotifyConfigSpec) DeepCopyInto(out *ScaleNotifyConfigSpec) {
+func (in *HPASnapshot) DeepCopyInto(out *HPASnapshot) {
 	*out = *in
-	in.Config.DeepCopyInto(&out.Config)
+	if in.MinReplicas != nil {
+		in, out := &in.MinReplicas, &out.MinReplicas
+		*out = new(int32)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaleNotifyConfigSpec.
-func (in *ScaleNotifyConfigSpec) DeepCopy() *ScaleNotifyConfigSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HPASnapshot.
+func (in *HPASnapshot) DeepCopy() *HPASnapshot {
 	if in == nil {
 		return nil
 	}
-	out := new(ScaleNotifyConfigSpec)
+	out := new(HPASnapshot)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ScaleNotifyConfigStatus) DeepCopyInto(out *ScaleNotifyConfigStatus) {
+func (in *PlannedAction) DeepCopyInto(out *PlannedAction) {
 	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]ContainerResources, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaleNotifyConfigStatus.
-func (in *ScaleNotifyConfigStatus) DeepCopy() *ScaleNotifyConfigStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlannedAction.
+func (in *PlannedAction) DeepCopy() *PlannedAction {
 	if in == nil {
 		return nil
 	}
-	out := new(ScaleNotifyConfigStatus)
+	out := new(PlannedAction)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ScaleNotifyMsgTemplate) DeepCopyInto(out *ScaleNotifyMsgTemplate) {
+func (in *PodRebalance) DeepCopyInto(out *PodRebalance) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
-	out.Status = in.Status
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaleNotifyMsgTemplate.
-func (in *ScaleNotifyMsgTemplate) DeepCopy() *ScaleNotifyMsgTemplate {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodRebalance.
+func (in *PodRebalance) DeepCopy() *PodRebalance {
 	if in == nil {
 		return nil
 	}
-	out := new(ScaleNotifyMsgTemplate)
+	out := new(PodRebalance)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ScaleNotifyMsgTemplate) DeepCopyObject() runtime.Object {
+func (in *PodRebalance) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -414,31 +543,31 @@ func (in *ScaleNotifyMsgTemplate) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ScaleNotifyMsgTemplateList) DeepCopyInto(out *ScaleNotifyMsgTemplateList) {
+func (in *PodRebalanceList) DeepCopyInto(out *PodRebalanceList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]ScaleNotifyMsgTemplate, len(*in))
+		*out = make([]PodRebalance, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaleNotifyMsgTemplateList.
-func (in *ScaleNotifyMsgTemplateList) DeepCopy() *ScaleNotifyMsgTemplateList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodRebalanceList.
+func (in *PodRebalanceList) DeepCopy() *PodRebalanceList {
 	if in == nil {
 		return nil
 	}
-	out := new(ScaleNotifyMsgTemplateList)
+	out := new(PodRebalanceList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ScaleNotifyMsgTemplateList) DeepCopyObject() runtime.Object {
+func (in *PodRebalanceList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -446,31 +575,553 @@ func (in *ScaleNotifyMsgTemplateList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ScaleNotifyMsgTemplateSpec) DeepCopyInto(out *ScaleNotifyMsgTemplateSpec) {
+func (in *PodRebalanceSpec) DeepCopyInto(out *PodRebalanceSpec) {
 	*out = *in
+	in.Selector.DeepCopyInto(&out.Selector)
+	in.Strategy.DeepCopyInto(&out.Strategy)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaleNotifyMsgTemplateSpec.
-func (in *ScaleNotifyMsgTemplateSpec) DeepCopy() *ScaleNotifyMsgTemplateSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodRebalanceSpec.
+func (in *PodRebalanceSpec) DeepCopy() *PodRebalanceSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ScaleNotifyMsgTemplateSpec)
+	out := new(PodRebalanceSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ScaleNotifyMsgTemplateStatus) DeepCopyInto(out *ScaleNotifyMsgTemplateStatus) {
+func (in *PodRebalanceStatus) DeepCopyInto(out *PodRebalanceStatus) {
 	*out = *in
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaleNotifyMsgTemplateStatus.
-func (in *ScaleNotifyMsgTemplateStatus) DeepCopy() *ScaleNotifyMsgTemplateStatus {
-	if in == nil {
-		return nil
-	}
-	out := new(ScaleNotifyMsgTemplateStatus)
+	in.RebalanceBeginTime.DeepCopyInto(&out.RebalanceBeginTime)
+	in.RebalanceEndTime.DeepCopyInto(&out.RebalanceEndTime)
+	if in.RebalancedPods != nil {
+		in, out := &in.RebalancedPods, &out.RebalancedPods
+		*out = make([]RebalancedPodInfo, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodRebalanceStatus.
+func (in *PodRebalanceStatus) DeepCopy() *PodRebalanceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PodRebalanceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodRebalanceStrategy) DeepCopyInto(out *PodRebalanceStrategy) {
+	*out = *in
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Threshold != nil {
+		in, out := &in.Threshold, &out.Threshold
+		*out = new(RebalanceThreshold)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodRebalanceStrategy.
+func (in *PodRebalanceStrategy) DeepCopy() *PodRebalanceStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(PodRebalanceStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RebalanceThreshold) DeepCopyInto(out *RebalanceThreshold) {
+	*out = *in
+	if in.CPU != nil {
+		in, out := &in.CPU, &out.CPU
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Memory != nil {
+		in, out := &in.Memory, &out.Memory
+		*out = new(int32)
+		**out = **in
+	}
+	if in.PodCountImbalance != nil {
+		in, out := &in.PodCountImbalance, &out.PodCountImbalance
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RebalanceThreshold.
+func (in *RebalanceThreshold) DeepCopy() *RebalanceThreshold {
+	if in == nil {
+		return nil
+	}
+	out := new(RebalanceThreshold)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RebalancedPodInfo) DeepCopyInto(out *RebalancedPodInfo) {
+	*out = *in
+	in.Timestamp.DeepCopyInto(&out.Timestamp)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RebalancedPodInfo.
+func (in *RebalancedPodInfo) DeepCopy() *RebalancedPodInfo {
+	if in == nil {
+		return nil
+	}
+	out := new(RebalancedPodInfo)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RetryPolicy) DeepCopyInto(out *RetryPolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetryPolicy.
+func (in *RetryPolicy) DeepCopy() *RetryPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(RetryPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScaleAction) DeepCopyInto(out *ScaleAction) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaleAction.
+func (in *ScaleAction) DeepCopy() *ScaleAction {
+	if in == nil {
+		return nil
+	}
+	out := new(ScaleAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScaleDownPolicy) DeepCopyInto(out *ScaleDownPolicy) {
+	*out = *in
+	out.StepPolicy = in.StepPolicy
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaleDownPolicy.
+func (in *ScaleDownPolicy) DeepCopy() *ScaleDownPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ScaleDownPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScaleGuardrail) DeepCopyInto(out *ScaleGuardrail) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaleGuardrail.
+func (in *ScaleGuardrail) DeepCopy() *ScaleGuardrail {
+	if in == nil {
+		return nil
+	}
+	out := new(ScaleGuardrail)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ScaleGuardrail) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScaleGuardrailList) DeepCopyInto(out *ScaleGuardrailList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ScaleGuardrail, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaleGuardrailList.
+func (in *ScaleGuardrailList) DeepCopy() *ScaleGuardrailList {
+	if in == nil {
+		return nil
+	}
+	out := new(ScaleGuardrailList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ScaleGuardrailList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScaleGuardrailSpec) DeepCopyInto(out *ScaleGuardrailSpec) {
+	*out = *in
+	if in.MaxReplicas != nil {
+		in, out := &in.MaxReplicas, &out.MaxReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.AllowedKinds != nil {
+		in, out := &in.AllowedKinds, &out.AllowedKinds
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowAutoApproval != nil {
+		in, out := &in.AllowAutoApproval, &out.AllowAutoApproval
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaleGuardrailSpec.
+func (in *ScaleGuardrailSpec) DeepCopy() *ScaleGuardrailSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ScaleGuardrailSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScaleNotifyConfig) DeepCopyInto(out *ScaleNotifyConfig) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaleNotifyConfig.
+func (in *ScaleNotifyConfig) DeepCopy() *ScaleNotifyConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ScaleNotifyConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ScaleNotifyConfig) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScaleNotifyConfigList) DeepCopyInto(out *ScaleNotifyConfigList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ScaleNotifyConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaleNotifyConfigList.
+func (in *ScaleNotifyConfigList) DeepCopy() *ScaleNotifyConfigList {
+	if in == nil {
+		return nil
+	}
+	out := new(ScaleNotifyConfigList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ScaleNotifyConfigList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScaleNotifyConfigSpec) DeepCopyInto(out *ScaleNotifyConfigSpec) {
+	*out = *in
+	in.Config.DeepCopyInto(&out.Config)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaleNotifyConfigSpec.
+func (in *ScaleNotifyConfigSpec) DeepCopy() *ScaleNotifyConfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ScaleNotifyConfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScaleNotifyConfigStatus) DeepCopyInto(out *ScaleNotifyConfigStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaleNotifyConfigStatus.
+func (in *ScaleNotifyConfigStatus) DeepCopy() *ScaleNotifyConfigStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ScaleNotifyConfigStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScaleNotifyMsgTemplate) DeepCopyInto(out *ScaleNotifyMsgTemplate) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaleNotifyMsgTemplate.
+func (in *ScaleNotifyMsgTemplate) DeepCopy() *ScaleNotifyMsgTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(ScaleNotifyMsgTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ScaleNotifyMsgTemplate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScaleNotifyMsgTemplateList) DeepCopyInto(out *ScaleNotifyMsgTemplateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ScaleNotifyMsgTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaleNotifyMsgTemplateList.
+func (in *ScaleNotifyMsgTemplateList) DeepCopy() *ScaleNotifyMsgTemplateList {
+	if in == nil {
+		return nil
+	}
+	out := new(ScaleNotifyMsgTemplateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ScaleNotifyMsgTemplateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScaleNotifyMsgTemplateSpec) DeepCopyInto(out *ScaleNotifyMsgTemplateSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaleNotifyMsgTemplateSpec.
+func (in *ScaleNotifyMsgTemplateSpec) DeepCopy() *ScaleNotifyMsgTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ScaleNotifyMsgTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScaleNotifyMsgTemplateStatus) DeepCopyInto(out *ScaleNotifyMsgTemplateStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaleNotifyMsgTemplateStatus.
+func (in *ScaleNotifyMsgTemplateStatus) DeepCopy() *ScaleNotifyMsgTemplateStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ScaleNotifyMsgTemplateStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScaleSchedule) DeepCopyInto(out *ScaleSchedule) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaleSchedule.
+func (in *ScaleSchedule) DeepCopy() *ScaleSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(ScaleSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ScaleSchedule) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScaleScheduleList) DeepCopyInto(out *ScaleScheduleList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ScaleSchedule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaleScheduleList.
+func (in *ScaleScheduleList) DeepCopy() *ScaleScheduleList {
+	if in == nil {
+		return nil
+	}
+	out := new(ScaleScheduleList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ScaleScheduleList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScaleScheduleSpec) DeepCopyInto(out *ScaleScheduleSpec) {
+	*out = *in
+	if in.TimeZone != nil {
+		in, out := &in.TimeZone, &out.TimeZone
+		*out = new(string)
+		**out = **in
+	}
+	if in.HistoryLimit != nil {
+		in, out := &in.HistoryLimit, &out.HistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
+	in.AlertScaleTemplate.DeepCopyInto(&out.AlertScaleTemplate)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaleScheduleSpec.
+func (in *ScaleScheduleSpec) DeepCopy() *ScaleScheduleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ScaleScheduleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScaleScheduleStatus) DeepCopyInto(out *ScaleScheduleStatus) {
+	*out = *in
+	if in.LastScheduleTime != nil {
+		in, out := &in.LastScheduleTime, &out.LastScheduleTime
+		*out = (*in).DeepCopy()
+	}
+	if in.NextScheduleTime != nil {
+		in, out := &in.NextScheduleTime, &out.NextScheduleTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastMissedTime != nil {
+		in, out := &in.LastMissedTime, &out.LastMissedTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Active != nil {
+		in, out := &in.Active, &out.Active
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaleScheduleStatus.
+func (in *ScaleScheduleStatus) DeepCopy() *ScaleScheduleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ScaleScheduleStatus)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -480,6 +1131,43 @@ func (in *ScaleStatus) DeepCopyInto(out *ScaleStatus) {
 	*out = *in
 	in.ScaleBeginTime.DeepCopyInto(&out.ScaleBeginTime)
 	in.ScaleEndTime.DeepCopyInto(&out.ScaleEndTime)
+	if in.TargetReplicas != nil {
+		in, out := &in.TargetReplicas, &out.TargetReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	in.ScalingBeginTime.DeepCopyInto(&out.ScalingBeginTime)
+	if in.NextRetryTime != nil {
+		in, out := &in.NextRetryTime, &out.NextRetryTime
+		*out = (*in).DeepCopy()
+	}
+	if in.ScaleUpProgress != nil {
+		in, out := &in.ScaleUpProgress, &out.ScaleUpProgress
+		*out = new(StepProgress)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ScaleDownProgress != nil {
+		in, out := &in.ScaleDownProgress, &out.ScaleDownProgress
+		*out = new(StepProgress)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Overlaps != nil {
+		in, out := &in.Overlaps, &out.Overlaps
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DriftEvents != nil {
+		in, out := &in.DriftEvents, &out.DriftEvents
+		*out = make([]DriftEvent, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastAction != nil {
+		in, out := &in.LastAction, &out.LastAction
+		*out = new(ScaleAction)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaleStatus.
@@ -506,3 +1194,112 @@ func (in *ScaleTarget) DeepCopy() *ScaleTarget {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScaleTargetSelector) DeepCopyInto(out *ScaleTargetSelector) {
+	*out = *in
+	in.Selector.DeepCopyInto(&out.Selector)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaleTargetSelector.
+func (in *ScaleTargetSelector) DeepCopy() *ScaleTargetSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(ScaleTargetSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StepPolicy) DeepCopyInto(out *StepPolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StepPolicy.
+func (in *StepPolicy) DeepCopy() *StepPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(StepPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StepProgress) DeepCopyInto(out *StepProgress) {
+	*out = *in
+	if in.LastStepTime != nil {
+		in, out := &in.LastStepTime, &out.LastStepTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StepProgress.
+func (in *StepProgress) DeepCopy() *StepProgress {
+	if in == nil {
+		return nil
+	}
+	out := new(StepProgress)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TargetStatus) DeepCopyInto(out *TargetStatus) {
+	*out = *in
+	out.Target = in.Target
+	if in.OriginHPA != nil {
+		in, out := &in.OriginHPA, &out.OriginHPA
+		*out = new(HPASnapshot)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TargetStatus.
+func (in *TargetStatus) DeepCopy() *TargetStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TargetStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TransitionRecord) DeepCopyInto(out *TransitionRecord) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TransitionRecord.
+func (in *TransitionRecord) DeepCopy() *TransitionRecord {
+	if in == nil {
+		return nil
+	}
+	out := new(TransitionRecord)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VerticalScale) DeepCopyInto(out *VerticalScale) {
+	*out = *in
+	if in.Containers != nil {
+		in, out := &in.Containers, &out.Containers
+		*out = make([]ContainerResources, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VerticalScale.
+func (in *VerticalScale) DeepCopy() *VerticalScale {
+	if in == nil {
+		return nil
+	}
+	out := new(VerticalScale)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -21,6 +21,7 @@ import (
 	"flag"
 	"os"
 	"path/filepath"
+	"strings"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
@@ -28,6 +29,7 @@ import (
 
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/discovery"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/certwatcher"
@@ -39,7 +41,9 @@ import (
 
 	opsv1beta1 "udesk.cn/ops/api/v1beta1"
 	"udesk.cn/ops/internal/controller"
+	opsmetrics "udesk.cn/ops/internal/metrics"
 	server "udesk.cn/ops/internal/server"
+	"udesk.cn/ops/internal/strategy"
 	webhookv1beta1 "udesk.cn/ops/internal/webhook/v1beta1"
 	// +kubebuilder:scaffold:imports
 )
@@ -67,6 +71,8 @@ func main() {
 	var enableHTTP2 bool
 	var enableAPIServer bool
 	var apiAddr string
+	var approvalDelegates string
+	var forbidSelfApproval bool
 	var tlsOpts []func(*tls.Config)
 	flag.StringVar(&metricsAddr, "metrics-bind-address", "0", "The address the metrics endpoint binds to. "+
 		"Use :8443 for HTTPS or :8080 for HTTP, or leave as 0 to disable the metrics service.")
@@ -89,6 +95,11 @@ func main() {
 		"If set, the API server will be enabled for external access")
 	flag.StringVar(&apiAddr, "api-addr", ":8088",
 		"The address the API server binds to.")
+	flag.StringVar(&approvalDelegates, "approval-delegates", "",
+		"Comma-separated users allowed to create, approve, extend or cancel AlertScales on behalf of the user named in "+
+			"the requested-by, approval-operator or scale-action-operator annotation, e.g. the service account used by the API server.")
+	flag.BoolVar(&forbidSelfApproval, "forbid-self-approval", false,
+		"If set, users cannot approve AlertScales they requested.")
 	opts := zap.Options{
 		Development: true,
 	}
@@ -210,13 +221,27 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Targets other than the built-in workloads must expose the scale subresource
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(mgr.GetConfig())
+	if err != nil {
+		setupLog.Error(err, "unable to create discovery client")
+		os.Exit(1)
+	}
+	strategy.SetDiscoveryClient(discoveryClient)
+
 	if err := (&controller.AlertScaleReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
+		Client:    mgr.GetClient(),
+		APIReader: mgr.GetAPIReader(),
+		Scheme:    mgr.GetScheme(),
+		Recorder:  mgr.GetEventRecorderFor("alertscale-controller"),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "AlertScale")
 		os.Exit(1)
 	}
+	if err := opsmetrics.RegisterActiveCollector(mgr.GetClient()); err != nil {
+		setupLog.Error(err, "unable to register metrics collector", "collector", "AlertScale")
+		os.Exit(1)
+	}
 	if err := (&controller.PodRebalanceReconciler{
 		Client: mgr.GetClient(),
 		Scheme: mgr.GetScheme(),
@@ -258,6 +283,22 @@ func main() {
 			os.Exit(1)
 		}
 		setupLog.Info("Webhook registered successfully", "webhook", "ScaleNotifyConfig")
+		approvalOptions := webhookv1beta1.ApprovalOptions{ForbidSelfApproval: forbidSelfApproval}
+		for _, delegate := range strings.Split(approvalDelegates, ",") {
+			if delegate = strings.TrimSpace(delegate); delegate != "" {
+				approvalOptions.Delegates = append(approvalOptions.Delegates, delegate)
+			}
+		}
+		if err := webhookv1beta1.SetupAlertScaleWebhookWithManager(mgr, approvalOptions); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "AlertScale")
+			os.Exit(1)
+		}
+		setupLog.Info("Webhook registered successfully", "webhook", "AlertScale")
+		if err := webhookv1beta1.SetupPodRebalanceWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "PodRebalance")
+			os.Exit(1)
+		}
+		setupLog.Info("Webhook registered successfully", "webhook", "PodRebalance")
 	} else {
 		setupLog.Info("Webhooks disabled by ENABLE_WEBHOOKS environment variable")
 	}
@@ -275,6 +316,13 @@ func main() {
 		setupLog.Error(err, "unable to create controller", "controller", "PodRebalance")
 		os.Exit(1)
 	}
+	if err := (&controller.ScaleScheduleReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ScaleSchedule")
+		os.Exit(1)
+	}
 	// +kubebuilder:scaffold:builder
 
 	if metricsCertWatcher != nil {
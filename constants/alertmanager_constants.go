@@ -0,0 +1,37 @@
+package constants
+
+// Alertmanager 集成相关的标签与注解常量
+const (
+	// AlertFingerprintLabel 记录触发 AlertScale 的告警指纹，用于去重
+	AlertFingerprintLabel = "ops.udesk.cn/alert-fingerprint"
+
+	// AlertNameLabel 记录触发 AlertScale 的告警名称
+	AlertNameLabel = "ops.udesk.cn/alert-name"
+
+	// AlertSourceAnnotation 记录 AlertScale 的来源
+	AlertSourceAnnotation = "ops.udesk.cn/source"
+
+	// AlertResolvedAnnotation 记录告警恢复时间，控制器据此提前结束扩容
+	AlertResolvedAnnotation = "ops.udesk.cn/alert-resolved-at"
+)
+
+// AlertSourceAlertmanager 表示 AlertScale 由 Alertmanager webhook 创建
+const AlertSourceAlertmanager = "alertmanager"
+
+// Alertmanager 告警中用于描述扩容参数的标签/注解键
+// 优先读取 annotations，其次读取 labels
+const (
+	AlertKeyTargetKind       = "scale_target_kind"
+	AlertKeyTargetName       = "scale_target_name"
+	AlertKeyTargetNamespace  = "scale_target_namespace"
+	AlertKeyTargetAPIVersion = "scale_target_api_version"
+	AlertKeyReplicas         = "scale_replicas"
+	AlertKeyMode             = "scale_mode"
+	AlertKeyMinReplicas      = "scale_min_replicas"
+	AlertKeyMaxReplicas      = "scale_max_replicas"
+	AlertKeyDuration         = "scale_duration"
+	AlertKeyTimeout          = "scale_timeout"
+	AlertKeyAutoApproval     = "scale_auto_approval"
+	AlertKeyNotificationType = "scale_notification_type"
+	AlertKeyNotifyTemplate   = "scale_notify_msg_template"
+)
@@ -30,6 +30,10 @@ const (
 	ApprovalDecisionReject = "reject"
 )
 
+// ApprovalVerb 审批使用的虚拟动词，webhook 通过 SubjectAccessReview 检查用户是否拥有
+// alertscales 资源的该动词权限
+const ApprovalVerb = "approve"
+
 // 审批处理状态常量
 const (
 	// ApprovalProcessingPending 等待处理
@@ -38,3 +42,10 @@ const (
 	// ApprovalProcessingCompleted 处理完成
 	ApprovalProcessingCompleted = "completed"
 )
+
+// 请求者身份注解常量
+const (
+	// RequestedByAnnotation 存储创建资源的用户，由 mutating webhook 从 AdmissionRequest 的 userInfo 写入，
+	// 创建时覆盖用户填写的值，更新时保持原值，不可伪造
+	RequestedByAnnotation = "ops.udesk.cn/requested-by"
+)
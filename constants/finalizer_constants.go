@@ -0,0 +1,4 @@
+package constants
+
+// AlertScaleFinalizer 删除 AlertScale 前恢复工作负载副本数与 HPA 边界
+const AlertScaleFinalizer = "ops.udesk.cn/alertscale-finalizer"
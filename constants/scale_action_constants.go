@@ -0,0 +1,31 @@
+package constants
+
+// 运行中 AlertScale 的延长/取消操作注解常量
+const (
+	// ScaleActionAnnotation 存储操作类型 (extend/cancel)
+	ScaleActionAnnotation = "ops.udesk.cn/scale-action"
+
+	// ScaleActionDurationAnnotation 存储延长时长
+	ScaleActionDurationAnnotation = "ops.udesk.cn/scale-action-duration"
+
+	// ScaleActionOperatorAnnotation 存储操作员
+	ScaleActionOperatorAnnotation = "ops.udesk.cn/scale-action-operator"
+
+	// ScaleActionReasonAnnotation 存储操作原因
+	ScaleActionReasonAnnotation = "ops.udesk.cn/scale-action-reason"
+
+	// ScaleActionTimestampAnnotation 存储操作时间戳
+	ScaleActionTimestampAnnotation = "ops.udesk.cn/scale-action-timestamp"
+
+	// ScaleActionProcessingAnnotation 存储操作处理状态，取值同审批处理状态
+	ScaleActionProcessingAnnotation = "ops.udesk.cn/scale-action-processing"
+)
+
+// 操作类型常量
+const (
+	// ScaleActionExtend 延长扩容持续时间
+	ScaleActionExtend = "extend"
+
+	// ScaleActionCancel 提前结束扩容
+	ScaleActionCancel = "cancel"
+)
@@ -0,0 +1,13 @@
+package constants
+
+// ScaleSchedule 相关的标签与注解常量
+const (
+	// ScaleScheduleLabel 记录创建 AlertScale 的 ScaleSchedule 名称
+	ScaleScheduleLabel = "ops.udesk.cn/scale-schedule"
+
+	// ScheduledTimeAnnotation 记录 AlertScale 对应的计划触发时间
+	ScheduledTimeAnnotation = "ops.udesk.cn/scheduled-at"
+)
+
+// AlertSourceSchedule 表示 AlertScale 由 ScaleSchedule 创建
+const AlertSourceSchedule = "schedule"
@@ -2,15 +2,23 @@ package controller
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	appv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	opsv1beta1 "udesk.cn/ops/api/v1beta1"
 
+	"udesk.cn/ops/constants"
 	"udesk.cn/ops/internal/handler"
+	"udesk.cn/ops/internal/metrics"
 	"udesk.cn/ops/internal/strategy"
 	"udesk.cn/ops/internal/types"
 )
@@ -18,21 +26,32 @@ import (
 // +kubebuilder:rbac:groups=ops.udesk.cn,resources=alertscales,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=ops.udesk.cn,resources=alertscales/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=ops.udesk.cn,resources=alertscales/finalizers,verbs=update
+// +kubebuilder:rbac:groups=ops.udesk.cn,resources=scaleguardrails,verbs=get;list;watch
+// +kubebuilder:rbac:groups=ops.udesk.cn,resources=approvalpolicies,verbs=get;list;watch
 // +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;update;patch
 // +kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=autoscaling,resources=horizontalpodautoscalers,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=apps,resources=deployments/scale;statefulsets/scale;replicasets/scale,verbs=get;update;patch
+// +kubebuilder:rbac:groups="",resources=replicationcontrollers/scale,verbs=get;update;patch
 // +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups=apps,resources=replicasets,verbs=get;list
+// +kubebuilder:rbac:groups="",resources=replicationcontrollers,verbs=get;list
+// +kubebuilder:rbac:groups="",resources=nodes;pods;resourcequotas,verbs=get;list;watch
 
 const (
 	// ResourceKindDeployment represents Deployment resource kind
-	ResourceKindDeployment = "Deployment"
+	ResourceKindDeployment = strategy.KindDeployment
 	// ResourceKindStatefulSet represents StatefulSet resource kind
-	ResourceKindStatefulSet = "StatefulSet"
+	ResourceKindStatefulSet = strategy.KindStatefulSet
 )
 
 // AlertScaleReconciler reconciles a AlertScale object
 type AlertScaleReconciler struct {
 	client.Client
+	// APIReader 绕过缓存读取节点和 Pod 等只在容量预检时需要的对象
+	APIReader     client.Reader
 	Scheme        *runtime.Scheme
+	Recorder      record.EventRecorder
 	StateHandlers map[string]types.StateHandler
 }
 
@@ -51,27 +70,53 @@ func (r *AlertScaleReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
-	// 根据目标类型选择策略
-	var scaleStrategy types.ScaleStrategy
-	switch alertScale.Spec.ScaleTarget.Kind {
-	case ResourceKindDeployment:
-		scaleStrategy = &strategy.DeploymentStrategy{}
-	case ResourceKindStatefulSet:
-		scaleStrategy = &strategy.StatefulSetStrategy{}
-	default:
-		log.Info("Unsupported scale target kind", "kind", alertScale.Spec.ScaleTarget.Kind)
-		return ctrl.Result{}, nil
-	}
-
 	// 创建上下文
 	scaleContext := &types.ScaleContext{
-		AlertScale:    alertScale,
-		Client:        r.Client,
-		Request:       req,
-		Context:       ctx,
-		ScaleStrategy: scaleStrategy,
+		AlertScale: alertScale,
+		Client:     r.Client,
+		APIReader:  r.APIReader,
+		Request:    req,
+		Context:    ctx,
+		Recorder:   r.Recorder,
+	}
+
+	// 删除中的 AlertScale 先恢复工作负载再释放
+	if !alertScale.DeletionTimestamp.IsZero() {
+		return r.handleDeletion(scaleContext)
 	}
 
+	// 确保 finalizer 存在，保证删除时能恢复工作负载
+	if !controllerutil.ContainsFinalizer(alertScale, constants.AlertScaleFinalizer) {
+		controllerutil.AddFinalizer(alertScale, constants.AlertScaleFinalizer)
+		if err := r.Update(ctx, alertScale); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	// 根据目标类型选择策略
+	scaleStrategy, err := newScaleStrategy(r.Client, alertScale)
+	if err != nil {
+		if errors.Is(err, strategy.ErrUnsupportedScaleTarget) {
+			log.Info("Unsupported scale target", "kind", alertScale.Spec.ScaleTarget.Kind, "reason", err.Error())
+			return r.failUnsupportedTarget(scaleContext, err)
+		}
+		return ctrl.Result{}, err
+	}
+	if alertScale.Spec.DryRun {
+		scaleStrategy = strategy.NewDryRunStrategy(scaleStrategy, alertScale)
+	}
+	scaleContext.ScaleStrategy = scaleStrategy
+
+	resourceStrategy, err := newResourceStrategy(r.Client, alertScale)
+	if err != nil {
+		if errors.Is(err, strategy.ErrUnsupportedScaleTarget) {
+			log.Info("Unsupported vertical scale target", "kind", alertScale.Spec.ScaleTarget.Kind, "reason", err.Error())
+			return r.failUnsupportedTarget(scaleContext, err)
+		}
+		return ctrl.Result{}, err
+	}
+	scaleContext.ResourceStrategy = resourceStrategy
+
 	// 获取当前状态处理器
 	currentStatus := alertScale.Status.ScaleStatus.Status
 	stateHandler, exists := r.StateHandlers[currentStatus]
@@ -79,7 +124,104 @@ func (r *AlertScaleReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		stateHandler = r.StateHandlers["default"]
 	}
 
-	return stateHandler.Handle(scaleContext)
+	result, err := stateHandler.Handle(scaleContext)
+	if err != nil {
+		return result, err
+	}
+
+	// 记录已处理的 spec 版本，供 kubectl wait 和 GitOps 健康检查判断状态是否最新
+	if alertScale.Status.ObservedGeneration != alertScale.Generation {
+		alertScale.Status.ObservedGeneration = alertScale.Generation
+		if err := r.Status().Update(ctx, alertScale); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	return result, nil
+}
+
+// handleDeletion 处理删除逻辑，目标不支持扩缩容时不恢复工作负载
+func (r *AlertScaleReconciler) handleDeletion(scaleContext *types.ScaleContext) (ctrl.Result, error) {
+	scaleStrategy, err := newScaleStrategy(r.Client, scaleContext.AlertScale)
+	if err != nil && !errors.Is(err, strategy.ErrUnsupportedScaleTarget) {
+		return ctrl.Result{}, err
+	}
+	if scaleStrategy != nil && scaleContext.AlertScale.Spec.DryRun {
+		scaleStrategy = strategy.NewDryRunStrategy(scaleStrategy, scaleContext.AlertScale)
+	}
+	scaleContext.ScaleStrategy = scaleStrategy
+
+	resourceStrategy, err := newResourceStrategy(r.Client, scaleContext.AlertScale)
+	if err != nil && !errors.Is(err, strategy.ErrUnsupportedScaleTarget) {
+		return ctrl.Result{}, err
+	}
+	scaleContext.ResourceStrategy = resourceStrategy
+
+	return (&handler.DeletionHandler{}).Handle(scaleContext)
+}
+
+// newScaleStrategy 根据目标类型选择扩缩容策略，多目标 AlertScale 按每个成员的类型选择
+func newScaleStrategy(c client.Client, alertScale *opsv1beta1.AlertScale) (types.ScaleStrategy, error) {
+	if types.IsGroup(alertScale) {
+		return strategy.NewGroupStrategy(), nil
+	}
+	return strategy.NewScaleStrategy(c, &alertScale.Spec.ScaleTarget)
+}
+
+// newResourceStrategy 纵向扩缩容时创建调整容器资源的策略，横向扩缩容时返回 nil
+func newResourceStrategy(c client.Client, alertScale *opsv1beta1.AlertScale) (types.ResourceStrategy, error) {
+	if !types.IsVertical(alertScale) {
+		return nil, nil
+	}
+	resourceStrategy, err := strategy.NewVerticalStrategy(c, &alertScale.Spec.ScaleTarget)
+	if err != nil {
+		return nil, err
+	}
+	if alertScale.Spec.DryRun {
+		resourceStrategy = strategy.NewDryRunResourceStrategy(resourceStrategy, alertScale)
+	}
+	return resourceStrategy, nil
+}
+
+// failUnsupportedTarget 目标无法扩缩容时直接将 AlertScale 置为 Failed 并记录原因，
+// 由于未改动过工作负载，下一次调谐直接归档
+func (r *AlertScaleReconciler) failUnsupportedTarget(scaleContext *types.ScaleContext, reason error) (ctrl.Result, error) {
+	status := &scaleContext.AlertScale.Status.ScaleStatus
+	switch status.Status {
+	case types.ScaleStatusArchived:
+		return ctrl.Result{}, nil
+	case types.ScaleStatusFailed:
+		r.transition(scaleContext, types.ScaleStatusArchived, types.ReasonUnsupportedTarget, "Nothing to restore for unsupported scale target")
+		return ctrl.Result{}, r.Status().Update(scaleContext.Context, scaleContext.AlertScale)
+	}
+
+	status.Message = reason.Error()
+	status.ScaleEndTime = metav1.Now()
+	r.transition(scaleContext, types.ScaleStatusFailed, types.ReasonUnsupportedTarget, status.Message)
+	if err := r.Status().Update(scaleContext.Context, scaleContext.AlertScale); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if r.Recorder != nil {
+		r.Recorder.Event(scaleContext.AlertScale, corev1.EventTypeWarning, types.ReasonUnsupportedTarget, status.Message)
+	}
+
+	if err := handler.NewNotificationService(r.Client).SendNotification(scaleContext.Context, scaleContext, "failed"); err != nil {
+		logf.FromContext(scaleContext.Context).Error(err, "Failed to send notification", "status", "failed")
+	}
+
+	return ctrl.Result{Requeue: true}, nil
+}
+
+// transition 切换状态并同步 Conditions、指标和状态切换记录，由调用方提交状态更新
+func (r *AlertScaleReconciler) transition(scaleContext *types.ScaleContext, toStatus, reason, message string) {
+	alertScale := scaleContext.AlertScale
+	fromStatus := alertScale.Status.ScaleStatus.Status
+	alertScale.Status.ScaleStatus.Status = toStatus
+	alertScale.Status.ObservedGeneration = alertScale.Generation
+	types.SetTransitionConditions(alertScale, toStatus, reason, message)
+	metrics.ObserveTransition(alertScale, fromStatus, toStatus, time.Now())
+	types.RecordTransition(alertScale, fromStatus, toStatus, types.ActorSystem, reason, message)
 }
 
 // SetupWithManager sets up the controller with the Manager.
@@ -94,6 +236,7 @@ func (r *AlertScaleReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		types.ScaleStatusArchived:    &handler.ArchivedHandler{},
 		types.ScaleStatusApprovaling: &handler.ApprovalingHandler{},
 		types.ScaleStatusApproved:    &handler.ApprovedHandler{},
+		types.ScaleStatusRejected:    &handler.RejectedHandler{},
 
 		"default": &handler.DefaultHandler{},
 	}
@@ -27,6 +27,10 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	appv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -35,8 +39,10 @@ import (
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
 
 	opsv1beta1 "udesk.cn/ops/api/v1beta1"
+	"udesk.cn/ops/constants"
 	"udesk.cn/ops/internal/handler"
 	"udesk.cn/ops/internal/strategy"
 	internalTypes "udesk.cn/ops/internal/types"
@@ -81,7 +87,10 @@ var _ = Describe("AlertScale Controller", func() {
 		Expect(opsv1beta1.AddToScheme(scheme)).To(Succeed())
 
 		// 创建fake client
-		fakeClient = fake.NewClientBuilder().WithScheme(scheme).Build()
+		fakeClient = fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithStatusSubresource(&opsv1beta1.AlertScale{}).
+			Build()
 
 		// 初始化reconciler
 		reconciler = &AlertScaleReconciler{
@@ -238,6 +247,61 @@ var _ = Describe("AlertScale Controller", func() {
 				NamespacedName: namespacedName,
 			})
 			Expect(err).NotTo(HaveOccurred())
+
+			// 不支持的类型应进入 Failed 状态并记录原因
+			updated := &opsv1beta1.AlertScale{}
+			Expect(fakeClient.Get(ctx, namespacedName, updated)).To(Succeed())
+			Expect(updated.Status.ScaleStatus.Status).To(Equal(internalTypes.ScaleStatusFailed))
+			Expect(updated.Status.ScaleStatus.Message).To(ContainSubstring("UnsupportedKind"))
+			Expect(meta.IsStatusConditionTrue(updated.Status.Conditions, internalTypes.ConditionFailed)).To(BeTrue())
+
+			// 没有需要恢复的副本数，下一次调谐直接归档
+			_, err = reconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: namespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeClient.Get(ctx, namespacedName, updated)).To(Succeed())
+			Expect(updated.Status.ScaleStatus.Status).To(Equal(internalTypes.ScaleStatusArchived))
+			Expect(meta.IsStatusConditionTrue(updated.Status.Conditions, internalTypes.ConditionFailed)).To(BeTrue())
+		})
+
+		It("should record conditions, events and the observed generation on transitions", func() {
+			recorder := record.NewFakeRecorder(10)
+			reconciler.Recorder = recorder
+
+			alertScale := &opsv1beta1.AlertScale{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       resourceName,
+					Namespace:  "default",
+					Generation: 2,
+				},
+				Spec: opsv1beta1.AlertScaleSpec{
+					ScaleReason: "Test Reason",
+					ScaleTarget: opsv1beta1.ScaleTarget{
+						Kind:      ResourceKindDeployment,
+						Name:      "test-deployment",
+						Namespace: "default",
+					},
+				},
+			}
+			Expect(fakeClient.Create(ctx, alertScale)).To(Succeed())
+
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: namespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			updated := &opsv1beta1.AlertScale{}
+			Expect(fakeClient.Get(ctx, namespacedName, updated)).To(Succeed())
+			Expect(updated.Status.ScaleStatus.Status).To(Equal(internalTypes.ScaleStatusPending))
+			Expect(updated.Status.ObservedGeneration).To(Equal(updated.Generation))
+			for _, conditionType := range []string{
+				internalTypes.ConditionApproved,
+				internalTypes.ConditionProgressing,
+				internalTypes.ConditionAvailable,
+				internalTypes.ConditionFailed,
+			} {
+				Expect(meta.IsStatusConditionFalse(updated.Status.Conditions, conditionType)).To(BeTrue(), conditionType)
+			}
+			Expect(recorder.Events).To(Receive(ContainSubstring(internalTypes.ReasonCreated)))
 		})
 
 		It("should use correct state handler based on status", func() {
@@ -398,45 +462,168 @@ var _ = Describe("AlertScale Controller", func() {
 		})
 	})
 
-	Context("When testing strategy selection", func() {
-		It("should select DeploymentStrategy for Deployment target", func() {
+	Context("When the scale target has an HPA", func() {
+		It("should raise HPA bounds while scaling and restore them on completion", func() {
+			deployment := &appv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Name: "web-app", Namespace: "default"},
+				Spec: appv1.DeploymentSpec{
+					Replicas: int32Ptr(2),
+					Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web-app"}},
+				},
+			}
+			hpa := &autoscalingv2.HorizontalPodAutoscaler{
+				ObjectMeta: metav1.ObjectMeta{Name: "web-app-hpa", Namespace: "default"},
+				Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+					ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+						APIVersion: "apps/v1",
+						Kind:       ResourceKindDeployment,
+						Name:       "web-app",
+					},
+					MinReplicas: int32Ptr(2),
+					MaxReplicas: 4,
+				},
+			}
 			alertScale := &opsv1beta1.AlertScale{
+				ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default"},
 				Spec: opsv1beta1.AlertScaleSpec{
-					ScaleTarget: opsv1beta1.ScaleTarget{Kind: ResourceKindDeployment},
+					ScaleReason:    "Traffic spike",
+					ScaleThreshold: 6,
+					ScaleTimeout:   "5m",
+					ScaleTarget: opsv1beta1.ScaleTarget{
+						Kind:      ResourceKindDeployment,
+						Name:      "web-app",
+						Namespace: "default",
+					},
+				},
+				Status: opsv1beta1.AlertScaleStatus{
+					ScaleStatus: opsv1beta1.ScaleStatus{
+						Status:         internalTypes.ScaleStatusScaling,
+						ScaleBeginTime: metav1.Now(),
+						OriginReplicas: 2,
+					},
 				},
 			}
+			fakeClient = fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(deployment, hpa, alertScale).
+				WithStatusSubresource(alertScale).
+				Build()
+			reconciler.Client = fakeClient
+
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: namespacedName})
+			Expect(err).NotTo(HaveOccurred())
 
-			// 模拟reconciler中的策略选择逻辑
-			var scaleStrategy internalTypes.ScaleStrategy
-			switch alertScale.Spec.ScaleTarget.Kind {
-			case ResourceKindDeployment:
-				scaleStrategy = &strategy.DeploymentStrategy{}
-			case ResourceKindStatefulSet:
-				scaleStrategy = &strategy.StatefulSetStrategy{}
-			}
+			hpaKey := types.NamespacedName{Name: "web-app-hpa", Namespace: "default"}
+			raised := &autoscalingv2.HorizontalPodAutoscaler{}
+			Expect(fakeClient.Get(ctx, hpaKey, raised)).To(Succeed())
+			Expect(*raised.Spec.MinReplicas).To(Equal(int32(6)))
+			Expect(raised.Spec.MaxReplicas).To(Equal(int32(6)))
 
-			_, isDeploymentStrategy := scaleStrategy.(*strategy.DeploymentStrategy)
-			Expect(isDeploymentStrategy).To(BeTrue())
+			updated := &opsv1beta1.AlertScale{}
+			Expect(fakeClient.Get(ctx, namespacedName, updated)).To(Succeed())
+			Expect(updated.Status.OriginHPA).NotTo(BeNil())
+			Expect(*updated.Status.OriginHPA.MinReplicas).To(Equal(int32(2)))
+			Expect(updated.Status.OriginHPA.MaxReplicas).To(Equal(int32(4)))
+
+			updated.Status.ScaleStatus.Status = internalTypes.ScaleStatusCompleted
+			Expect(fakeClient.Status().Update(ctx, updated)).To(Succeed())
+
+			_, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: namespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			restored := &autoscalingv2.HorizontalPodAutoscaler{}
+			Expect(fakeClient.Get(ctx, hpaKey, restored)).To(Succeed())
+			Expect(*restored.Spec.MinReplicas).To(Equal(int32(2)))
+			Expect(restored.Spec.MaxReplicas).To(Equal(int32(4)))
+
+			Expect(fakeClient.Get(ctx, namespacedName, updated)).To(Succeed())
+			Expect(updated.Status.ScaleStatus.Status).To(Equal(internalTypes.ScaleStatusArchived))
+			Expect(updated.Status.OriginHPA).To(BeNil())
 		})
+	})
 
-		It("should select StatefulSetStrategy for StatefulSet target", func() {
+	Context("When an AlertScale is deleted", func() {
+		It("should restore the origin replicas before releasing the finalizer", func() {
+			deployment := &appv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Name: "web-app", Namespace: "default"},
+				Spec: appv1.DeploymentSpec{
+					Replicas: int32Ptr(6),
+					Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web-app"}},
+				},
+			}
+			targetReplicas := int32(6)
 			alertScale := &opsv1beta1.AlertScale{
+				ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default"},
 				Spec: opsv1beta1.AlertScaleSpec{
-					ScaleTarget: opsv1beta1.ScaleTarget{Kind: ResourceKindStatefulSet},
+					ScaleReason:    "Traffic spike",
+					ScaleThreshold: 6,
+					ScaleTarget: opsv1beta1.ScaleTarget{
+						Kind:      ResourceKindDeployment,
+						Name:      "web-app",
+						Namespace: "default",
+					},
+				},
+				Status: opsv1beta1.AlertScaleStatus{
+					ScaleStatus: opsv1beta1.ScaleStatus{
+						Status:         internalTypes.ScaleStatusScaled,
+						OriginReplicas: 2,
+						TargetReplicas: &targetReplicas,
+					},
 				},
 			}
+			fakeClient = fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(deployment, alertScale).
+				WithStatusSubresource(alertScale).
+				Build()
+			reconciler.Client = fakeClient
+			reconciler.StateHandlers[internalTypes.ScaleStatusScaled] = &MockStateHandler{}
+
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: namespacedName})
+			Expect(err).NotTo(HaveOccurred())
 
-			// 模拟reconciler中的策略选择逻辑
-			var scaleStrategy internalTypes.ScaleStrategy
-			switch alertScale.Spec.ScaleTarget.Kind {
-			case ResourceKindDeployment:
-				scaleStrategy = &strategy.DeploymentStrategy{}
-			case ResourceKindStatefulSet:
-				scaleStrategy = &strategy.StatefulSetStrategy{}
-			}
+			current := &opsv1beta1.AlertScale{}
+			Expect(fakeClient.Get(ctx, namespacedName, current)).To(Succeed())
+			Expect(current.Finalizers).To(ContainElement(constants.AlertScaleFinalizer))
+
+			Expect(fakeClient.Delete(ctx, current)).To(Succeed())
+			_, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: namespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			restored := &appv1.Deployment{}
+			Expect(fakeClient.Get(ctx, types.NamespacedName{Name: "web-app", Namespace: "default"}, restored)).To(Succeed())
+			Expect(*restored.Spec.Replicas).To(Equal(int32(2)))
+			Expect(apierrors.IsNotFound(fakeClient.Get(ctx, namespacedName, current))).To(BeTrue())
+		})
+	})
+
+	Context("When testing strategy selection", func() {
+		It("should select DeploymentStrategy for Deployment target", func() {
+			scaleStrategy, err := strategy.NewScaleStrategy(fakeClient, &opsv1beta1.ScaleTarget{Kind: ResourceKindDeployment})
+			Expect(err).NotTo(HaveOccurred())
+
+			_, isDeploymentStrategy := scaleStrategy.(*strategy.DeploymentStrategy)
+			Expect(isDeploymentStrategy).To(BeTrue())
+		})
+
+		It("should select StatefulSetStrategy for StatefulSet target", func() {
+			scaleStrategy, err := strategy.NewScaleStrategy(fakeClient, &opsv1beta1.ScaleTarget{Kind: ResourceKindStatefulSet})
+			Expect(err).NotTo(HaveOccurred())
 
 			_, isStatefulSetStrategy := scaleStrategy.(*strategy.StatefulSetStrategy)
 			Expect(isStatefulSetStrategy).To(BeTrue())
 		})
+
+		It("should select ScaleSubresourceStrategy for other scalable kinds", func() {
+			scaleStrategy, err := strategy.NewScaleStrategy(fakeClient, &opsv1beta1.ScaleTarget{Kind: "ReplicaSet"})
+			Expect(err).NotTo(HaveOccurred())
+
+			_, isSubresourceStrategy := scaleStrategy.(*strategy.ScaleSubresourceStrategy)
+			Expect(isSubresourceStrategy).To(BeTrue())
+		})
 	})
 })
+
+func int32Ptr(i int32) *int32 {
+	return &i
+}
@@ -0,0 +1,318 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	opsv1beta1 "udesk.cn/ops/api/v1beta1"
+	"udesk.cn/ops/constants"
+	"udesk.cn/ops/internal/cron"
+	"udesk.cn/ops/internal/types"
+)
+
+const (
+	// scheduleTolerance 计划时间之后在该时间内触发视为按时执行，超过则视为错过
+	scheduleTolerance = time.Minute
+
+	// maxRunsPerReconcile 单次调谐最多遍历的计划时间数，错过次数过多时分批处理
+	maxRunsPerReconcile = 10000
+
+	// defaultScheduleScaleDuration 模板未指定扩容持续时间时的默认值，与 AlertScale 保持一致
+	defaultScheduleScaleDuration = "5m"
+
+	// defaultScheduleHistoryLimit 默认保留的已结束 AlertScale 数量
+	defaultScheduleHistoryLimit = 3
+)
+
+// finishedScaleStatuses 已结束的 AlertScale 状态
+var finishedScaleStatuses = map[string]bool{
+	types.ScaleStatusArchived: true,
+	types.ScaleStatusRejected: true,
+	types.ScaleStatusFailed:   true,
+}
+
+// ScaleScheduleReconciler reconciles a ScaleSchedule object
+type ScaleScheduleReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+
+	// Now 返回当前时间，为空时使用 time.Now，便于测试
+	Now func() time.Time
+}
+
+// +kubebuilder:rbac:groups=ops.udesk.cn,resources=scaleschedules,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=ops.udesk.cn,resources=scaleschedules/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=ops.udesk.cn,resources=scaleschedules/finalizers,verbs=update
+// +kubebuilder:rbac:groups=ops.udesk.cn,resources=alertscales,verbs=get;list;watch;create;update;patch;delete
+
+// Reconcile 按 cron 表达式创建子 AlertScale，由 AlertScale 状态机完成审批、通知和恢复
+func (r *ScaleScheduleReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+
+	schedule := &opsv1beta1.ScaleSchedule{}
+	if err := r.Get(ctx, req.NamespacedName, schedule); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	// 统计仍在执行的子 AlertScale，并清理超出保留数量的历史记录
+	children, err := r.listChildren(ctx, schedule)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	active, finished := splitChildren(children)
+	schedule.Status.Active = childNames(active)
+	if err := r.cleanupHistory(ctx, schedule, finished); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	cronSchedule, location, err := parseSchedule(&schedule.Spec)
+	if err != nil {
+		log.Info("Invalid schedule", "scaleSchedule", schedule.Name, "reason", err.Error())
+		schedule.Status.NextScheduleTime = nil
+		schedule.Status.Message = err.Error()
+		return ctrl.Result{}, r.Status().Update(ctx, schedule)
+	}
+
+	window, err := types.ParseDuration(scheduleScaleDuration(&schedule.Spec))
+	if err != nil {
+		schedule.Status.NextScheduleTime = nil
+		schedule.Status.Message = fmt.Sprintf("invalid scale duration: %v", err)
+		return ctrl.Result{}, r.Status().Update(ctx, schedule)
+	}
+
+	now := r.now().In(location)
+
+	// 暂停期间不创建新的 AlertScale，恢复后按补偿策略处理期间错过的计划时间
+	if schedule.Spec.Suspend {
+		schedule.Status.NextScheduleTime = nil
+		schedule.Status.Message = "schedule is suspended"
+		return ctrl.Result{}, r.Status().Update(ctx, schedule)
+	}
+
+	last := schedule.CreationTimestamp.Time
+	if schedule.Status.LastScheduleTime != nil {
+		last = schedule.Status.LastScheduleTime.Time
+	}
+
+	scheduledTime, more := mostRecentRun(cronSchedule, last.In(location), now)
+	if !scheduledTime.IsZero() {
+		lateness := now.Sub(scheduledTime)
+		switch {
+		case lateness <= scheduleTolerance:
+			if err := r.createRun(ctx, schedule, scheduledTime, ""); err != nil {
+				return ctrl.Result{}, err
+			}
+			schedule.Status.Message = ""
+		case schedule.Spec.CatchUpPolicy == types.CatchUpPolicyRunLatest && lateness < window:
+			// 补偿执行时只扩容剩余的时间窗口
+			remaining := fmt.Sprintf("%ds", int64((window - lateness).Seconds()))
+			if err := r.createRun(ctx, schedule, scheduledTime, remaining); err != nil {
+				return ctrl.Result{}, err
+			}
+			schedule.Status.Message = fmt.Sprintf("caught up missed run at %s for the remaining %s", scheduledTime.Format(time.RFC3339), remaining)
+		default:
+			log.Info("Skipping missed run", "scaleSchedule", schedule.Name, "scheduledTime", scheduledTime)
+			schedule.Status.LastMissedTime = &metav1.Time{Time: scheduledTime}
+			schedule.Status.Message = fmt.Sprintf("missed run at %s was skipped", scheduledTime.Format(time.RFC3339))
+		}
+		schedule.Status.LastScheduleTime = &metav1.Time{Time: scheduledTime}
+	}
+
+	next := cronSchedule.Next(now)
+	if next.IsZero() {
+		schedule.Status.NextScheduleTime = nil
+		schedule.Status.Message = "schedule has no upcoming runs"
+		return ctrl.Result{}, r.Status().Update(ctx, schedule)
+	}
+	schedule.Status.NextScheduleTime = &metav1.Time{Time: next}
+
+	if err := r.Status().Update(ctx, schedule); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if more {
+		return ctrl.Result{Requeue: true}, nil
+	}
+	return ctrl.Result{RequeueAfter: next.Sub(now)}, nil
+}
+
+func (r *ScaleScheduleReconciler) now() time.Time {
+	if r.Now != nil {
+		return r.Now()
+	}
+	return time.Now()
+}
+
+// parseSchedule 解析 cron 表达式和时区
+func parseSchedule(spec *opsv1beta1.ScaleScheduleSpec) (*cron.Schedule, *time.Location, error) {
+	location := time.Local
+	if spec.TimeZone != nil && *spec.TimeZone != "" {
+		loc, err := time.LoadLocation(*spec.TimeZone)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid time zone %q: %w", *spec.TimeZone, err)
+		}
+		location = loc
+	}
+
+	cronSchedule, err := cron.Parse(spec.Schedule)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid schedule %q: %w", spec.Schedule, err)
+	}
+	return cronSchedule, location, nil
+}
+
+func scheduleScaleDuration(spec *opsv1beta1.ScaleScheduleSpec) string {
+	if spec.AlertScaleTemplate.ScaleDuration == "" {
+		return defaultScheduleScaleDuration
+	}
+	return spec.AlertScaleTemplate.ScaleDuration
+}
+
+// mostRecentRun 返回 (last, now] 之间最近的计划时间，遍历次数超过上限时返回 more=true 以便分批处理
+func mostRecentRun(cronSchedule *cron.Schedule, last, now time.Time) (latest time.Time, more bool) {
+	runs := 0
+	for t := cronSchedule.Next(last); !t.IsZero() && !t.After(now); t = cronSchedule.Next(t) {
+		latest = t
+		runs++
+		if runs >= maxRunsPerReconcile {
+			return latest, true
+		}
+	}
+	return latest, false
+}
+
+// createRun 为计划时间创建子 AlertScale 并加入 Active 列表，名称由计划时间决定以保证重复调谐时幂等
+func (r *ScaleScheduleReconciler) createRun(ctx context.Context, schedule *opsv1beta1.ScaleSchedule, scheduledTime time.Time, duration string) error {
+	log := logf.FromContext(ctx)
+
+	name := fmt.Sprintf("%s-%d", schedule.Name, scheduledTime.Unix()/60)
+	alertScale := &opsv1beta1.AlertScale{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: schedule.Namespace,
+			Labels: map[string]string{
+				constants.ScaleScheduleLabel: schedule.Name,
+			},
+			Annotations: map[string]string{
+				constants.AlertSourceAnnotation:   constants.AlertSourceSchedule,
+				constants.ScheduledTimeAnnotation: scheduledTime.UTC().Format(time.RFC3339),
+			},
+		},
+		Spec: *schedule.Spec.AlertScaleTemplate.DeepCopy(),
+	}
+	if alertScale.Spec.ScaleTarget.Namespace == "" {
+		alertScale.Spec.ScaleTarget.Namespace = schedule.Namespace
+	}
+	if duration != "" {
+		alertScale.Spec.ScaleDuration = duration
+	}
+
+	if err := controllerutil.SetControllerReference(schedule, alertScale, r.Scheme); err != nil {
+		return err
+	}
+
+	if err := r.Create(ctx, alertScale); err != nil {
+		if apierrors.IsAlreadyExists(err) {
+			return nil
+		}
+		log.Error(err, "Failed to create scheduled AlertScale", "scaleSchedule", schedule.Name, "scheduledTime", scheduledTime)
+		return err
+	}
+
+	schedule.Status.Active = append(schedule.Status.Active, name)
+	log.Info("Created scheduled AlertScale", "scaleSchedule", schedule.Name, "alertScale", alertScale.Name, "scheduledTime", scheduledTime)
+	return nil
+}
+
+// listChildren 列出由 ScaleSchedule 创建的 AlertScale，按创建时间排序
+func (r *ScaleScheduleReconciler) listChildren(ctx context.Context, schedule *opsv1beta1.ScaleSchedule) ([]opsv1beta1.AlertScale, error) {
+	alertScaleList := &opsv1beta1.AlertScaleList{}
+	if err := r.List(ctx, alertScaleList,
+		client.InNamespace(schedule.Namespace),
+		client.MatchingLabels{constants.ScaleScheduleLabel: schedule.Name},
+	); err != nil {
+		return nil, err
+	}
+
+	var children []opsv1beta1.AlertScale
+	for _, alertScale := range alertScaleList.Items {
+		if metav1.IsControlledBy(&alertScale, schedule) {
+			children = append(children, alertScale)
+		}
+	}
+
+	sort.Slice(children, func(i, j int) bool {
+		return children[i].CreationTimestamp.Before(&children[j].CreationTimestamp)
+	})
+	return children, nil
+}
+
+// cleanupHistory 删除超出保留数量的已结束 AlertScale，优先删除最早创建的
+func (r *ScaleScheduleReconciler) cleanupHistory(ctx context.Context, schedule *opsv1beta1.ScaleSchedule, finished []opsv1beta1.AlertScale) error {
+	limit := int32(defaultScheduleHistoryLimit)
+	if schedule.Spec.HistoryLimit != nil {
+		limit = *schedule.Spec.HistoryLimit
+	}
+
+	for i := 0; i < len(finished)-int(limit); i++ {
+		if err := r.Delete(ctx, &finished[i]); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// splitChildren 将子 AlertScale 分为执行中和已结束两组
+func splitChildren(children []opsv1beta1.AlertScale) (active, finished []opsv1beta1.AlertScale) {
+	for _, child := range children {
+		if finishedScaleStatuses[child.Status.ScaleStatus.Status] {
+			finished = append(finished, child)
+		} else {
+			active = append(active, child)
+		}
+	}
+	return active, finished
+}
+
+func childNames(alertScales []opsv1beta1.AlertScale) []string {
+	names := make([]string, 0, len(alertScales))
+	for _, alertScale := range alertScales {
+		names = append(names, alertScale.Name)
+	}
+	return names
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ScaleScheduleReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&opsv1beta1.ScaleSchedule{}).
+		Owns(&opsv1beta1.AlertScale{}).
+		Named("scaleschedule").
+		Complete(r)
+}
@@ -0,0 +1,239 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	opsv1beta1 "udesk.cn/ops/api/v1beta1"
+	"udesk.cn/ops/constants"
+	internalTypes "udesk.cn/ops/internal/types"
+)
+
+var _ = Describe("ScaleSchedule Controller", func() {
+	var (
+		ctx            context.Context
+		reconciler     *ScaleScheduleReconciler
+		fakeClient     client.Client
+		schedule       *opsv1beta1.ScaleSchedule
+		namespacedName types.NamespacedName
+		now            time.Time
+	)
+
+	// 2025-06-02 是周一，计划时间为每周一 8:30 UTC
+	scheduledTime := time.Date(2025, 6, 2, 8, 30, 0, 0, time.UTC)
+
+	reconcileAt := func(at time.Time) reconcile.Result {
+		now = at
+		result, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: namespacedName})
+		Expect(err).NotTo(HaveOccurred())
+		return result
+	}
+
+	listRuns := func() []opsv1beta1.AlertScale {
+		alertScaleList := &opsv1beta1.AlertScaleList{}
+		Expect(fakeClient.List(ctx, alertScaleList, client.InNamespace("default"))).To(Succeed())
+		return alertScaleList.Items
+	}
+
+	getSchedule := func() *opsv1beta1.ScaleSchedule {
+		current := &opsv1beta1.ScaleSchedule{}
+		Expect(fakeClient.Get(ctx, namespacedName, current)).To(Succeed())
+		return current
+	}
+
+	buildReconciler := func(objects ...client.Object) {
+		scheme := runtime.NewScheme()
+		Expect(opsv1beta1.AddToScheme(scheme)).To(Succeed())
+
+		fakeClient = fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(append([]client.Object{schedule}, objects...)...).
+			WithStatusSubresource(&opsv1beta1.ScaleSchedule{}, &opsv1beta1.AlertScale{}).
+			Build()
+
+		reconciler = &ScaleScheduleReconciler{
+			Client: fakeClient,
+			Scheme: scheme,
+			Now:    func() time.Time { return now },
+		}
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		utc := "UTC"
+		schedule = &opsv1beta1.ScaleSchedule{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "monday-peak",
+				Namespace:         "default",
+				UID:               "schedule-uid",
+				CreationTimestamp: metav1.NewTime(scheduledTime.Add(-24 * time.Hour)),
+			},
+			Spec: opsv1beta1.ScaleScheduleSpec{
+				Schedule: "30 8 * * MON",
+				TimeZone: &utc,
+				AlertScaleTemplate: opsv1beta1.AlertScaleSpec{
+					ScaleReason:    "Monday morning peak",
+					ScaleDuration:  "2h",
+					ScaleThreshold: 10,
+					ScaleTarget: opsv1beta1.ScaleTarget{
+						Kind: "Deployment",
+						Name: "web-app",
+					},
+				},
+			},
+		}
+		namespacedName = types.NamespacedName{Name: schedule.Name, Namespace: schedule.Namespace}
+	})
+
+	It("should wait for the next scheduled time", func() {
+		buildReconciler()
+
+		result := reconcileAt(scheduledTime.Add(-time.Hour))
+		Expect(result.RequeueAfter).To(Equal(time.Hour))
+		Expect(listRuns()).To(BeEmpty())
+		Expect(getSchedule().Status.NextScheduleTime.Time).To(BeTemporally("==", scheduledTime))
+	})
+
+	It("should create an owned AlertScale when the schedule fires", func() {
+		buildReconciler()
+
+		result := reconcileAt(scheduledTime.Add(5 * time.Second))
+		Expect(result.RequeueAfter).To(Equal(7*24*time.Hour - 5*time.Second))
+
+		runs := listRuns()
+		Expect(runs).To(HaveLen(1))
+		run := runs[0]
+		Expect(run.Labels).To(HaveKeyWithValue(constants.ScaleScheduleLabel, schedule.Name))
+		Expect(run.Annotations).To(HaveKeyWithValue(constants.AlertSourceAnnotation, constants.AlertSourceSchedule))
+		Expect(run.OwnerReferences).To(HaveLen(1))
+		Expect(run.OwnerReferences[0].Name).To(Equal(schedule.Name))
+		Expect(run.Spec.ScaleDuration).To(Equal("2h"))
+		Expect(run.Spec.ScaleThreshold).To(Equal(int32(10)))
+		Expect(run.Spec.ScaleTarget.Namespace).To(Equal("default"))
+
+		status := getSchedule().Status
+		Expect(status.LastScheduleTime.Time).To(BeTemporally("==", scheduledTime))
+		Expect(status.Active).To(ConsistOf(run.Name))
+
+		// 重复调谐不会重复创建
+		reconcileAt(scheduledTime.Add(10 * time.Second))
+		Expect(listRuns()).To(HaveLen(1))
+	})
+
+	It("should evaluate the schedule in the configured time zone", func() {
+		shanghai := "Asia/Shanghai"
+		schedule.Spec.TimeZone = &shanghai
+		buildReconciler()
+
+		// 上海时间周一 8:30 为 UTC 0:30
+		reconcileAt(scheduledTime.Add(-8*time.Hour + time.Second))
+		Expect(listRuns()).To(HaveLen(1))
+	})
+
+	It("should skip runs missed while the operator was down", func() {
+		buildReconciler()
+
+		reconcileAt(scheduledTime.Add(30 * time.Minute))
+		Expect(listRuns()).To(BeEmpty())
+
+		status := getSchedule().Status
+		Expect(status.LastMissedTime.Time).To(BeTemporally("==", scheduledTime))
+		Expect(status.LastScheduleTime.Time).To(BeTemporally("==", scheduledTime))
+	})
+
+	It("should catch up the latest missed run for the remaining window", func() {
+		schedule.Spec.CatchUpPolicy = internalTypes.CatchUpPolicyRunLatest
+		buildReconciler()
+
+		reconcileAt(scheduledTime.Add(30 * time.Minute))
+		runs := listRuns()
+		Expect(runs).To(HaveLen(1))
+		Expect(runs[0].Spec.ScaleDuration).To(Equal("5400s"))
+	})
+
+	It("should not catch up runs whose window has closed", func() {
+		schedule.Spec.CatchUpPolicy = internalTypes.CatchUpPolicyRunLatest
+		buildReconciler()
+
+		reconcileAt(scheduledTime.Add(3 * time.Hour))
+		Expect(listRuns()).To(BeEmpty())
+		Expect(getSchedule().Status.LastMissedTime).NotTo(BeNil())
+	})
+
+	It("should not create runs while suspended", func() {
+		schedule.Spec.Suspend = true
+		buildReconciler()
+
+		reconcileAt(scheduledTime.Add(5 * time.Second))
+		Expect(listRuns()).To(BeEmpty())
+		Expect(getSchedule().Status.NextScheduleTime).To(BeNil())
+	})
+
+	It("should report an invalid schedule", func() {
+		schedule.Spec.Schedule = "every monday"
+		buildReconciler()
+
+		reconcileAt(scheduledTime)
+		Expect(getSchedule().Status.Message).To(ContainSubstring("invalid schedule"))
+	})
+
+	It("should delete finished runs beyond the history limit", func() {
+		historyLimit := int32(1)
+		schedule.Spec.HistoryLimit = &historyLimit
+
+		var runs []client.Object
+		for i, status := range []string{internalTypes.ScaleStatusArchived, internalTypes.ScaleStatusArchived, internalTypes.ScaleStatusScaled} {
+			run := &opsv1beta1.AlertScale{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              schedule.Name + "-" + string(rune('a'+i)),
+					Namespace:         "default",
+					Labels:            map[string]string{constants.ScaleScheduleLabel: schedule.Name},
+					CreationTimestamp: metav1.NewTime(scheduledTime.Add(time.Duration(i) * time.Minute)),
+					OwnerReferences: []metav1.OwnerReference{{
+						APIVersion: opsv1beta1.GroupVersion.String(),
+						Kind:       "ScaleSchedule",
+						Name:       schedule.Name,
+						UID:        schedule.UID,
+						Controller: func() *bool { b := true; return &b }(),
+					}},
+				},
+				Status: opsv1beta1.AlertScaleStatus{
+					ScaleStatus: opsv1beta1.ScaleStatus{Status: status},
+				},
+			}
+			runs = append(runs, run)
+		}
+		buildReconciler(runs...)
+
+		reconcileAt(scheduledTime.Add(-time.Hour))
+
+		remaining := listRuns()
+		Expect(remaining).To(HaveLen(2))
+		Expect(getSchedule().Status.Active).To(ConsistOf(schedule.Name + "-c"))
+	})
+})
@@ -0,0 +1,207 @@
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule 标准 5 段 cron 表达式：分 时 日 月 周
+type Schedule struct {
+	minute, hour, dom, month, dow uint64
+
+	// domAny/dowAny 日、周字段为 "*" 时，两者按“与”匹配，否则任一匹配即可
+	domAny, dowAny bool
+}
+
+// field 描述 cron 字段的取值范围
+type field struct {
+	name     string
+	min, max uint
+	names    map[string]uint
+}
+
+var (
+	minuteField = field{name: "minute", min: 0, max: 59}
+	hourField   = field{name: "hour", min: 0, max: 23}
+	domField    = field{name: "day of month", min: 1, max: 31}
+	monthField  = field{name: "month", min: 1, max: 12, names: map[string]uint{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	// 周字段允许 7 表示周日
+	dowField = field{name: "day of week", min: 0, max: 7, names: map[string]uint{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+// macros 预定义的 cron 表达式
+var macros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// maxSearchYears Next 向后查找的最大年数，避免不可能的表达式（如 2 月 30 日）死循环
+const maxSearchYears = 5
+
+// Parse 解析 cron 表达式，支持 *、列表、范围、步长、月份/星期英文缩写以及 @daily 等预定义表达式
+func Parse(spec string) (*Schedule, error) {
+	spec = strings.TrimSpace(spec)
+	if strings.HasPrefix(spec, "@") {
+		expanded, ok := macros[strings.ToLower(spec)]
+		if !ok {
+			return nil, fmt.Errorf("unknown cron macro %q", spec)
+		}
+		spec = expanded
+	}
+
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("expected 5 fields in cron expression %q, found %d", spec, len(fields))
+	}
+
+	s := &Schedule{}
+	var err error
+	if s.minute, _, err = parseField(fields[0], minuteField); err != nil {
+		return nil, err
+	}
+	if s.hour, _, err = parseField(fields[1], hourField); err != nil {
+		return nil, err
+	}
+	if s.dom, s.domAny, err = parseField(fields[2], domField); err != nil {
+		return nil, err
+	}
+	if s.month, _, err = parseField(fields[3], monthField); err != nil {
+		return nil, err
+	}
+	if s.dow, s.dowAny, err = parseField(fields[4], dowField); err != nil {
+		return nil, err
+	}
+	// 7 与 0 都表示周日
+	if s.dow&(1<<7) != 0 {
+		s.dow |= 1
+	}
+
+	return s, nil
+}
+
+// parseField 解析单个字段，返回取值位图以及字段是否为 "*"
+func parseField(expr string, f field) (uint64, bool, error) {
+	var bits uint64
+	for _, part := range strings.Split(expr, ",") {
+		partBits, err := parseRange(part, f)
+		if err != nil {
+			return 0, false, err
+		}
+		bits |= partBits
+	}
+	return bits, expr == "*" || expr == "?", nil
+}
+
+// parseRange 解析 "*"、"a"、"a-b"，以及带步长的 "*/n"、"a/n"、"a-b/n"
+func parseRange(expr string, f field) (uint64, error) {
+	rangeExpr, stepExpr, hasStep := strings.Cut(expr, "/")
+
+	step := uint(1)
+	if hasStep {
+		n, err := strconv.ParseUint(stepExpr, 10, 8)
+		if err != nil || n == 0 {
+			return 0, fmt.Errorf("invalid step %q in %s field", stepExpr, f.name)
+		}
+		step = uint(n)
+	}
+
+	var start, end uint
+	switch {
+	case rangeExpr == "*" || rangeExpr == "?":
+		start, end = f.min, f.max
+	case strings.Contains(rangeExpr, "-"):
+		low, high, _ := strings.Cut(rangeExpr, "-")
+		var err error
+		if start, err = parseValue(low, f); err != nil {
+			return 0, err
+		}
+		if end, err = parseValue(high, f); err != nil {
+			return 0, err
+		}
+	default:
+		value, err := parseValue(rangeExpr, f)
+		if err != nil {
+			return 0, err
+		}
+		start, end = value, value
+		// "a/n" 表示从 a 开始到最大值
+		if hasStep {
+			end = f.max
+		}
+	}
+
+	if start > end {
+		return 0, fmt.Errorf("invalid range %q in %s field", rangeExpr, f.name)
+	}
+
+	var bits uint64
+	for v := start; v <= end; v += step {
+		bits |= 1 << v
+	}
+	return bits, nil
+}
+
+func parseValue(expr string, f field) (uint, error) {
+	if value, ok := f.names[strings.ToLower(expr)]; ok {
+		return value, nil
+	}
+	n, err := strconv.ParseUint(expr, 10, 8)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q in %s field", expr, f.name)
+	}
+	value := uint(n)
+	if value < f.min || value > f.max {
+		return 0, fmt.Errorf("value %d out of range [%d, %d] in %s field", value, f.min, f.max, f.name)
+	}
+	return value, nil
+}
+
+// Next 返回严格晚于 t 的下一个触发时间，使用 t 所在的时区；找不到时返回零值
+func (s *Schedule) Next(t time.Time) time.Time {
+	loc := t.Location()
+	t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), 0, 0, loc).Add(time.Minute)
+	yearLimit := t.Year() + maxSearchYears
+
+	for t.Year() <= yearLimit {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !s.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			continue
+		}
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+
+	return time.Time{}
+}
+
+func (s *Schedule) dayMatches(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domAny || s.dowAny {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
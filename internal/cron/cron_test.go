@@ -0,0 +1,82 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cron
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Cron", func() {
+	// 2025-06-02 是周一
+	base := time.Date(2025, 6, 2, 8, 30, 0, 0, time.UTC)
+
+	next := func(spec string, from time.Time) time.Time {
+		schedule, err := Parse(spec)
+		Expect(err).NotTo(HaveOccurred())
+		return schedule.Next(from)
+	}
+
+	DescribeTable("Next",
+		func(spec string, expected time.Time) {
+			Expect(next(spec, base)).To(Equal(expected))
+		},
+		Entry("every minute", "* * * * *", time.Date(2025, 6, 2, 8, 31, 0, 0, time.UTC)),
+		Entry("fixed time later today", "0 9 * * *", time.Date(2025, 6, 2, 9, 0, 0, 0, time.UTC)),
+		Entry("fixed time tomorrow", "0 8 * * *", time.Date(2025, 6, 3, 8, 0, 0, 0, time.UTC)),
+		Entry("step", "*/20 * * * *", time.Date(2025, 6, 2, 8, 40, 0, 0, time.UTC)),
+		Entry("range with step", "0 9-17/4 * * *", time.Date(2025, 6, 2, 9, 0, 0, 0, time.UTC)),
+		Entry("list", "15,45 8 * * *", time.Date(2025, 6, 2, 8, 45, 0, 0, time.UTC)),
+		Entry("weekday names", "0 8 * * MON-FRI", time.Date(2025, 6, 3, 8, 0, 0, 0, time.UTC)),
+		Entry("sunday as 7", "0 0 * * 7", time.Date(2025, 6, 8, 0, 0, 0, 0, time.UTC)),
+		Entry("month names", "0 0 1 dec *", time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC)),
+		Entry("day of month or day of week", "0 0 15 * 3", time.Date(2025, 6, 4, 0, 0, 0, 0, time.UTC)),
+		Entry("macro", "@monthly", time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)),
+	)
+
+	It("should always return a time strictly after the given time", func() {
+		Expect(next("30 8 * * *", base)).To(Equal(time.Date(2025, 6, 3, 8, 30, 0, 0, time.UTC)))
+	})
+
+	It("should evaluate in the location of the given time", func() {
+		shanghai, err := time.LoadLocation("Asia/Shanghai")
+		Expect(err).NotTo(HaveOccurred())
+
+		result := next("0 9 * * *", base.In(shanghai))
+		Expect(result.Location()).To(Equal(shanghai))
+		Expect(result.UTC()).To(Equal(time.Date(2025, 6, 3, 1, 0, 0, 0, time.UTC)))
+	})
+
+	It("should return zero time for impossible dates", func() {
+		Expect(next("0 0 30 2 *", base).IsZero()).To(BeTrue())
+	})
+
+	DescribeTable("invalid expressions",
+		func(spec string) {
+			_, err := Parse(spec)
+			Expect(err).To(HaveOccurred())
+		},
+		Entry("too few fields", "0 9 * *"),
+		Entry("out of range", "60 * * * *"),
+		Entry("bad range", "0 17-9 * * *"),
+		Entry("zero step", "*/0 * * * *"),
+		Entry("unknown name", "0 0 * * funday"),
+		Entry("unknown macro", "@fortnightly"),
+	)
+})
@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"udesk.cn/ops/constants"
+	"udesk.cn/ops/internal/metrics"
+	"udesk.cn/ops/internal/types"
+)
+
+// restorableStatuses 已改动工作负载副本数或 HPA 边界，删除时需要恢复的状态
+var restorableStatuses = []string{
+	types.ScaleStatusScaling,
+	types.ScaleStatusScaled,
+	types.ScaleStatusCompleted,
+	types.ScaleStatusFailed,
+}
+
+// DeletionHandler 处理删除中的 AlertScale：恢复原始副本数与 HPA 边界，发送删除通知后移除 finalizer
+type DeletionHandler struct {
+	BaseStateHandler
+}
+
+func (h *DeletionHandler) Handle(ctx *types.ScaleContext) (ctrl.Result, error) {
+	log := logf.FromContext(ctx.Context)
+	log.Info("Handling AlertScale deletion", "alertScale", ctx.AlertScale.Name)
+
+	if !controllerutil.ContainsFinalizer(ctx.AlertScale, constants.AlertScaleFinalizer) {
+		return ctrl.Result{}, nil
+	}
+
+	// 目标不支持扩缩容时未改动过工作负载，无需恢复
+	restorable := ctx.ScaleStrategy != nil
+	if types.IsVertical(ctx.AlertScale) {
+		restorable = ctx.ResourceStrategy != nil
+	}
+	if restorable && containsStatus(restorableStatuses, ctx.AlertScale.Status.ScaleStatus.Status) {
+		// 工作负载或 HPA 已被删除时无需恢复，避免阻塞删除
+		if err := h.restore(ctx); err != nil && !apierrors.IsNotFound(err) {
+			metrics.ObserveRestoreFailure(ctx.AlertScale)
+			return ctrl.Result{}, err
+		} else if err != nil {
+			log.Info("Scale target not found, skip restore", "alertScale", ctx.AlertScale.Name)
+		}
+	}
+
+	h.sendNotification(ctx, "deleted")
+
+	controllerutil.RemoveFinalizer(ctx.AlertScale, constants.AlertScaleFinalizer)
+	if err := ctx.Client.Update(ctx.Context, ctx.AlertScale); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// restore 一次性恢复工作负载，不等待副本收敛；
+// 同一工作负载上仍有其他生效的 AlertScale 时只恢复到它们的最大目标副本数
+func (h *DeletionHandler) restore(ctx *types.ScaleContext) error {
+	if types.IsGroup(ctx.AlertScale) {
+		if _, err := h.restoreGroup(ctx, false); err != nil {
+			return err
+		}
+		h.recordEvent(ctx, corev1.EventTypeNormal, types.ReasonDeleted,
+			fmt.Sprintf("Restored %d targets before deletion", len(ctx.AlertScale.Status.Targets)))
+		return nil
+	}
+	if types.IsVertical(ctx.AlertScale) {
+		if _, _, err := h.restoreVertical(ctx); err != nil {
+			return err
+		}
+		h.recordEvent(ctx, corev1.EventTypeNormal, types.ReasonDeleted, "Restored container resources before deletion")
+		return nil
+	}
+
+	restoreReplicas, holders, err := h.restoreTarget(ctx)
+	if err != nil {
+		return err
+	}
+
+	// 存在 HPA 时恢复其原始边界，副本数交还 HPA 管理
+	if hasHPA, err := h.restoreHPA(ctx, holders); err != nil {
+		return err
+	} else if hasHPA {
+		h.recordEvent(ctx, corev1.EventTypeNormal, types.ReasonDeleted, "Restored HPA bounds before deletion")
+		return nil
+	}
+
+	currentReplicas, err := ctx.ScaleStrategy.GetCurrentReplicas(
+		ctx.Context,
+		ctx.Client,
+		&ctx.AlertScale.Spec.ScaleTarget,
+	)
+	if err != nil {
+		return err
+	}
+
+	if currentReplicas != restoreReplicas {
+		if err := ctx.ScaleStrategy.Scale(
+			ctx.Context,
+			ctx.Client,
+			&ctx.AlertScale.Spec.ScaleTarget,
+			restoreReplicas,
+		); err != nil {
+			return err
+		}
+	}
+
+	h.recordEvent(ctx, corev1.EventTypeNormal, types.ReasonDeleted,
+		fmt.Sprintf("Restored to %d replicas before deletion", restoreReplicas))
+	return nil
+}
+
+func (h *DeletionHandler) CanTransition(toState string) bool {
+	return false
+}
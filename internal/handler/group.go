@@ -0,0 +1,296 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	opsv1beta1 "udesk.cn/ops/api/v1beta1"
+	"udesk.cn/ops/internal/strategy"
+	"udesk.cn/ops/internal/types"
+)
+
+// 多目标 AlertScale 将 spec.scaleTargets 和 spec.scaleTargetSelector 选中的工作负载作为一组扩缩容：
+//   - 进入 Pending 时确定成员，记录每个成员的原始和目标副本数到 status.targets，之后成员不再变化
+//   - 审批只针对整组进行一次，ScaleStatus 中的副本数为所有成员之和
+//   - 扩容时任一成员失败则整组切换到 Failed，由 FailedHandler 将所有成员恢复到原始副本数
+//   - 恢复时任一成员失败则将其余成员重新扩容到目标副本数，保持 Completed 并重试恢复
+//   - 成员按 overlap.go 的规则与作用于同一工作负载的其他 AlertScale 合并：继承生效者记录的原始副本数与 HPA 快照，
+//     扩容到各方最大的目标副本数，恢复时只降到其余生效者的最大目标副本数
+//   - 不支持分步策略、漂移检测和容量预检
+
+// errNoGroupTargets 多目标 AlertScale 没有选中任何工作负载
+var errNoGroupTargets = errors.New("no scale targets matched")
+
+// resolveGroup 确定多目标 AlertScale 的成员，记录每个成员的原始和目标副本数
+func (h *BaseStateHandler) resolveGroup(ctx *types.ScaleContext) error {
+	members, err := strategy.ResolveGroupTargets(ctx.Context, ctx.Client, ctx.AlertScale)
+	if err != nil {
+		return err
+	}
+	if len(members) == 0 {
+		return errNoGroupTargets
+	}
+
+	targets := make([]opsv1beta1.TargetStatus, 0, len(members))
+	for _, member := range members {
+		originReplicas, err := ctx.ScaleStrategy.GetCurrentReplicas(ctx.Context, ctx.Client, &member.ScaleTarget)
+		if err != nil {
+			return fmt.Errorf("%s: %w", types.TargetRef(&member.ScaleTarget), err)
+		}
+		// 成员已被其他生效的 AlertScale 扩容时，继承其记录的原始副本数
+		overlapping, err := h.scalesOnTarget(ctx, &member.ScaleTarget, inheritableStatuses)
+		if err != nil {
+			return err
+		}
+		if len(overlapping) > 0 {
+			originReplicas = overlapping[0].Status.ScaleStatus.OriginReplicas
+		}
+		targetReplicas := types.CalculateTargetReplicas(&ctx.AlertScale.Spec, originReplicas)
+		if member.Replicas != nil {
+			targetReplicas = *member.Replicas
+		}
+		targets = append(targets, opsv1beta1.TargetStatus{
+			Target:            member.ScaleTarget,
+			Phase:             types.TargetPhasePending,
+			OriginReplicas:    originReplicas,
+			TargetReplicas:    targetReplicas,
+			AvailableReplicas: originReplicas,
+		})
+	}
+	ctx.AlertScale.Status.Targets = targets
+	return nil
+}
+
+// isUnresolvableGroup 判断成员解析错误是否无法通过重试恢复
+func isUnresolvableGroup(err error) bool {
+	return errors.Is(err, errNoGroupTargets) || errors.Is(err, strategy.ErrUnsupportedScaleTarget) || apierrors.IsNotFound(err)
+}
+
+// groupReplicas 返回所有成员的原始副本数之和与目标副本数之和
+func groupReplicas(targets []opsv1beta1.TargetStatus) (int32, int32) {
+	var originReplicas, targetReplicas int32
+	for _, member := range targets {
+		originReplicas += member.OriginReplicas
+		targetReplicas += member.TargetReplicas
+	}
+	return originReplicas, targetReplicas
+}
+
+// scaleGroup 将所有成员扩容到各自的目标副本数，返回是否所有成员都已就绪；
+// 任一成员扩容失败时将整组切换到 Failed，由调用方提交状态更新
+func (h *BaseStateHandler) scaleGroup(ctx *types.ScaleContext) (bool, error) {
+	targets := ctx.AlertScale.Status.Targets
+	completed := true
+	var availableReplicas int32
+	for i := range targets {
+		member := &targets[i]
+		desiredReplicas, err := h.scaleMember(ctx, member)
+		if err != nil {
+			h.failGroup(ctx, member, err)
+			return false, nil
+		}
+
+		available, err := ctx.ScaleStrategy.GetAvailableReplicas(ctx.Context, ctx.Client, &member.Target)
+		if err != nil {
+			return false, err
+		}
+		member.AvailableReplicas = available
+		availableReplicas += available
+		if available == desiredReplicas {
+			member.Phase = types.TargetPhaseScaled
+		} else {
+			member.Phase = types.TargetPhaseScaling
+			completed = false
+		}
+	}
+	ctx.AlertScale.Status.ScaleStatus.ScaledReplicas = availableReplicas
+	return completed, nil
+}
+
+// scaleMember 提升成员 HPA 的副本数边界并将成员扩容，返回成员应达到的副本数：
+// 成员的目标副本数与同一工作负载上其他生效 AlertScale 的目标副本数中的最大值
+func (h *BaseStateHandler) scaleMember(ctx *types.ScaleContext, member *opsv1beta1.TargetStatus) (int32, error) {
+	desiredReplicas := member.TargetReplicas
+	holders, err := h.scalesOnTarget(ctx, &member.Target, holdingStatuses)
+	if err != nil {
+		return 0, err
+	}
+	if len(holders) > 0 {
+		desiredReplicas = max(desiredReplicas, maxTargetReplicas(holders))
+	}
+
+	if err := h.raiseMemberHPA(ctx, member, desiredReplicas); err != nil {
+		return 0, err
+	}
+
+	currentReplicas, err := ctx.ScaleStrategy.GetCurrentReplicas(ctx.Context, ctx.Client, &member.Target)
+	if err != nil {
+		return 0, err
+	}
+	if currentReplicas == desiredReplicas {
+		return desiredReplicas, nil
+	}
+	return desiredReplicas, ctx.ScaleStrategy.Scale(ctx.Context, ctx.Client, &member.Target, desiredReplicas)
+}
+
+// raiseMemberHPA 成员存在 HPA 时先持久化其原始边界再提升，避免 HPA 回滚扩容结果；
+// 其他生效的 AlertScale 已提升 HPA 时继承其记录的原始边界
+func (h *BaseStateHandler) raiseMemberHPA(ctx *types.ScaleContext, member *opsv1beta1.TargetStatus, replicas int32) error {
+	hpa, err := strategy.FindHPA(ctx.Context, ctx.Client, &member.Target)
+	if err != nil || hpa == nil {
+		return err
+	}
+
+	if ctx.AlertScale.Spec.DryRun {
+		types.RecordPlannedTargetAction(ctx.AlertScale, types.TargetRef(&member.Target), types.PlannedActionRaiseHPA, replicas,
+			fmt.Sprintf("Raise the replica bounds of HPA %s to at least %d", hpa.Name, replicas))
+		return nil
+	}
+
+	if member.OriginHPA == nil {
+		member.OriginHPA = strategy.SnapshotHPA(hpa)
+		overlapping, err := h.scalesOnTarget(ctx, &member.Target, inheritableStatuses)
+		if err != nil {
+			return err
+		}
+		for _, other := range overlapping {
+			if other.Status.OriginHPA != nil {
+				member.OriginHPA = other.Status.OriginHPA.DeepCopy()
+				break
+			}
+		}
+		if err := ctx.Client.Status().Update(ctx.Context, ctx.AlertScale); err != nil {
+			return err
+		}
+	}
+	return strategy.RaiseHPABounds(ctx.Context, ctx.Client, hpa, replicas)
+}
+
+// failGroup 记录失败的成员并将整组切换到 Failed
+func (h *BaseStateHandler) failGroup(ctx *types.ScaleContext, member *opsv1beta1.TargetStatus, cause error) {
+	logf.FromContext(ctx.Context).Error(cause, "Failed to scale group member, rolling back the group",
+		"alertScale", ctx.AlertScale.Name, "target", types.TargetRef(&member.Target))
+
+	member.Phase = types.TargetPhaseFailed
+	member.Message = cause.Error()
+	status := &ctx.AlertScale.Status.ScaleStatus
+	status.ScaleEndTime = metav1.Now()
+	status.Message = fmt.Sprintf("Scaling %s failed: %v, rolling back %d targets",
+		types.TargetRef(&member.Target), cause, len(ctx.AlertScale.Status.Targets))
+	h.transitionTo(ctx, types.ScaleStatusFailed, types.ReasonGroupRollback, status.Message)
+}
+
+// restoreGroup 将所有成员恢复到原始副本数，返回是否所有成员都已恢复完成，并提交状态更新。
+// rollback 为 true 时任一成员恢复失败会将其余成员重新扩容到目标副本数，使整组保持扩容状态
+func (h *BaseStateHandler) restoreGroup(ctx *types.ScaleContext, rollback bool) (bool, error) {
+	targets := ctx.AlertScale.Status.Targets
+	converged := true
+	var availableReplicas int32
+	for i := range targets {
+		member := &targets[i]
+		restored, err := h.restoreMember(ctx, member)
+		if err != nil {
+			member.Phase = types.TargetPhaseFailed
+			member.Message = fmt.Sprintf("restore failed: %v", err)
+			if rollback {
+				h.rollbackRestore(ctx, member, err)
+			}
+			return false, errors.Join(fmt.Errorf("%s: %w", types.TargetRef(&member.Target), err),
+				ctx.Client.Status().Update(ctx.Context, ctx.AlertScale))
+		}
+		availableReplicas += member.AvailableReplicas
+		converged = converged && restored
+	}
+
+	ctx.AlertScale.Status.ScaleStatus.ScaledReplicas = availableReplicas
+	return converged, ctx.Client.Status().Update(ctx.Context, ctx.AlertScale)
+}
+
+// restoreMember 恢复成员的 HPA 边界或原始副本数，返回多余的副本是否已全部退出；
+// 尚未扩容的成员无需恢复，成员已被删除时视为恢复完成。
+// 同一工作负载上仍有其他生效的 AlertScale 时只恢复到它们的最大目标副本数
+func (h *BaseStateHandler) restoreMember(ctx *types.ScaleContext, member *opsv1beta1.TargetStatus) (bool, error) {
+	switch member.Phase {
+	case types.TargetPhaseRestored:
+		return true, nil
+	case types.TargetPhasePending:
+		member.Phase = types.TargetPhaseRestored
+		return true, nil
+	}
+
+	holders, err := h.scalesOnTarget(ctx, &member.Target, holdingStatuses)
+	if err != nil {
+		return false, err
+	}
+	restoreReplicas := member.OriginReplicas
+	if len(holders) > 0 {
+		restoreReplicas = maxTargetReplicas(holders)
+	}
+
+	// 存在 HPA 时恢复其原始边界，副本数交还 HPA 管理
+	if member.OriginHPA != nil {
+		bounds := hpaRestoreBounds(member.OriginHPA, holders)
+		if err := strategy.RestoreHPABounds(ctx.Context, ctx.Client, member.Target.Namespace, bounds); err != nil && !apierrors.IsNotFound(err) {
+			return false, err
+		}
+		member.OriginHPA = nil
+		member.Phase = types.TargetPhaseRestored
+		return true, nil
+	}
+
+	currentReplicas, err := ctx.ScaleStrategy.GetCurrentReplicas(ctx.Context, ctx.Client, &member.Target)
+	if apierrors.IsNotFound(err) {
+		member.Phase = types.TargetPhaseRestored
+		return true, nil
+	} else if err != nil {
+		return false, err
+	}
+	if currentReplicas != restoreReplicas {
+		if err := ctx.ScaleStrategy.Scale(ctx.Context, ctx.Client, &member.Target, restoreReplicas); err != nil {
+			return false, err
+		}
+	}
+
+	available, err := ctx.ScaleStrategy.GetAvailableReplicas(ctx.Context, ctx.Client, &member.Target)
+	if err != nil {
+		return false, err
+	}
+	member.AvailableReplicas = available
+	if available > restoreReplicas {
+		member.Phase = types.TargetPhaseRestoring
+		return false, nil
+	}
+	member.Phase = types.TargetPhaseRestored
+	return true, nil
+}
+
+// rollbackRestore 恢复失败时将其余成员重新扩容到目标副本数，保持整组处于扩容状态
+func (h *BaseStateHandler) rollbackRestore(ctx *types.ScaleContext, failed *opsv1beta1.TargetStatus, cause error) {
+	log := logf.FromContext(ctx.Context)
+	targets := ctx.AlertScale.Status.Targets
+	var rolledBack []string
+	for i := range targets {
+		member := &targets[i]
+		if member == failed || member.Phase == types.TargetPhasePending {
+			continue
+		}
+		if _, err := h.scaleMember(ctx, member); err != nil {
+			log.Error(err, "Failed to roll back group member", "alertScale", ctx.AlertScale.Name, "target", types.TargetRef(&member.Target))
+			member.Message = fmt.Sprintf("rollback failed: %v", err)
+			continue
+		}
+		member.Phase = types.TargetPhaseScaled
+		member.Message = fmt.Sprintf("restore rolled back because %s failed", types.TargetRef(&failed.Target))
+		rolledBack = append(rolledBack, types.TargetRef(&member.Target))
+	}
+
+	status := &ctx.AlertScale.Status.ScaleStatus
+	status.Message = fmt.Sprintf("Restoring %s failed: %v, rolled back %s to the target replicas",
+		types.TargetRef(&failed.Target), cause, strings.Join(rolledBack, ", "))
+	h.recordEvent(ctx, corev1.EventTypeWarning, types.ReasonGroupRollback, status.Message)
+}
@@ -0,0 +1,32 @@
+package handler
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"udesk.cn/ops/internal/policy"
+	"udesk.cn/ops/internal/types"
+)
+
+// enforceGuardrails 按命名空间中的 ScaleGuardrail 检查已确定的目标副本数，
+// 违规时记录到 status.guardrailViolations 并切换到 Failed，返回是否已被拦截。
+// webhook 只能按提交时的副本数估算，此处以进入 Pending 时的原始副本数为准
+func (h *BaseStateHandler) enforceGuardrails(ctx *types.ScaleContext, targets []policy.Target) (bool, error) {
+	violations, err := policy.Check(ctx.Context, ctx.Client, ctx.AlertScale, targets)
+	if err != nil {
+		return false, err
+	}
+	ctx.AlertScale.Status.GuardrailViolations = violations
+	if len(violations) == 0 {
+		return false, nil
+	}
+
+	status := &ctx.AlertScale.Status.ScaleStatus
+	status.ScaleEndTime = metav1.Now()
+	status.Message = "Blocked by " + policy.Summarize(violations)
+	logf.FromContext(ctx.Context).Info("AlertScale violates ScaleGuardrails", "alertScale", ctx.AlertScale.Name, "message", status.Message)
+	if err := h.updateStatus(ctx, types.ScaleStatusFailed, types.ReasonGuardrailViolated, status.Message); err != nil {
+		return true, err
+	}
+	h.sendNotification(ctx, "failed")
+	return true, nil
+}
@@ -4,9 +4,12 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 	"text/template"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -33,6 +36,7 @@ type TemplateData struct {
 	ScaleReason       string `json:"scaleReason"`
 	ScaleDuration     string `json:"scaleDuration"`
 	ScaleThreshold    int32  `json:"scaleThreshold"`
+	ScaleMode         string `json:"scaleMode"`
 	ScaleTimeout      string `json:"scaleTimeout"`
 	ScaleAutoApproval bool   `json:"scaleAutoApproval"`
 
@@ -48,12 +52,31 @@ type TemplateData struct {
 	Status         string    `json:"status"`
 	OriginReplicas int32     `json:"originReplicas"`
 	ScaledReplicas int32     `json:"scaledReplicas"`
+	TargetReplicas int32     `json:"targetReplicas"`
 	ScaleBeginTime time.Time `json:"scaleBeginTime"`
 	ScaleEndTime   time.Time `json:"scaleEndTime"`
 
 	// 额外字段
-	Timestamp time.Time `json:"timestamp"`
-	Operator  string    `json:"operator"`
+	Phase       string    `json:"phase"`
+	Message     string    `json:"message"`
+	Timestamp   time.Time `json:"timestamp"`
+	Operator    string    `json:"operator"`
+	RequestedBy string    `json:"requestedBy"`
+
+	// 试运行相关字段
+	DryRun bool                       `json:"dryRun"`
+	Plan   []opsv1beta1.PlannedAction `json:"plan,omitempty"`
+
+	// 纵向扩缩容相关字段
+	ScaleType       string                          `json:"scaleType"`
+	Resources       []opsv1beta1.ContainerResources `json:"resources,omitempty"`
+	OriginResources []opsv1beta1.ContainerResources `json:"originResources,omitempty"`
+
+	// 多目标 AlertScale 的成员状态，单目标时为空
+	Targets []opsv1beta1.TargetStatus `json:"targets,omitempty"`
+
+	// 扩容前容量预检结果，未检查时为空
+	CapacityCheck *opsv1beta1.CapacityCheck `json:"capacityCheck,omitempty"`
 }
 
 // SendNotification 发送通知
@@ -74,7 +97,7 @@ func (ns *NotificationService) SendNotification(ctx context.Context, scaleCtx *s
 	}
 
 	// 准备模板数据
-	templateData := ns.prepareTemplateData(scaleCtx)
+	templateData := ns.prepareTemplateData(scaleCtx, phase)
 
 	// 渲染消息内容
 	message, err := ns.renderMessage(ctx, scaleCtx, templateData)
@@ -94,18 +117,39 @@ func (ns *NotificationService) SendNotification(ctx context.Context, scaleCtx *s
 }
 
 // prepareTemplateData 准备模板数据
-func (ns *NotificationService) prepareTemplateData(scaleCtx *scaletypes.ScaleContext) *TemplateData {
+func (ns *NotificationService) prepareTemplateData(scaleCtx *scaletypes.ScaleContext, phase string) *TemplateData {
 	data := &TemplateData{
 		ScaleReason:       scaleCtx.AlertScale.Spec.ScaleReason,
 		ScaleDuration:     scaleCtx.AlertScale.Spec.ScaleDuration,
 		ScaleThreshold:    scaleCtx.AlertScale.Spec.ScaleThreshold,
+		ScaleMode:         scaleCtx.AlertScale.Spec.ScaleMode,
 		ScaleTimeout:      scaleCtx.AlertScale.Spec.ScaleTimeout,
 		ScaleAutoApproval: scaleCtx.AlertScale.Spec.ScaleAutoApproval,
 		Status:            scaleCtx.AlertScale.Status.ScaleStatus.Status,
 		OriginReplicas:    scaleCtx.AlertScale.Status.ScaleStatus.OriginReplicas,
 		ScaledReplicas:    scaleCtx.AlertScale.Status.ScaleStatus.ScaledReplicas,
+		Phase:             phase,
+		Message:           scaleCtx.AlertScale.Status.ScaleStatus.Message,
 		Timestamp:         time.Now(),
-		Operator:          "system", // 可以从 context 中获取
+		Operator:          scaletypes.ActorSystem,
+		RequestedBy:       requestedBy(scaleCtx.AlertScale),
+		DryRun:            scaleCtx.AlertScale.Spec.DryRun,
+		Plan:              scaleCtx.AlertScale.Status.Plan,
+		CapacityCheck:     scaleCtx.AlertScale.Status.CapacityCheck,
+		ScaleType:         scaleCtx.AlertScale.Spec.ScaleType,
+		OriginResources:   scaleCtx.AlertScale.Status.OriginResources,
+		Targets:           scaleCtx.AlertScale.Status.Targets,
+	}
+	if data.ScaleType == "" {
+		data.ScaleType = scaletypes.ScaleTypeHorizontal
+	}
+	if verticalScale := scaleCtx.AlertScale.Spec.VerticalScale; verticalScale != nil {
+		data.Resources = verticalScale.Containers
+	}
+
+	// 操作员为最近一次状态切换的触发者，如审批人或取消操作的用户
+	if history := scaleCtx.AlertScale.Status.History; len(history) > 0 {
+		data.Operator = history[len(history)-1].Actor
 	}
 
 	// 设置 ScaleTarget 信息
@@ -121,6 +165,9 @@ func (ns *NotificationService) prepareTemplateData(scaleCtx *scaletypes.ScaleCon
 	if !scaleCtx.AlertScale.Status.ScaleStatus.ScaleEndTime.IsZero() {
 		data.ScaleEndTime = scaleCtx.AlertScale.Status.ScaleStatus.ScaleEndTime.Time
 	}
+	if scaleCtx.AlertScale.Status.ScaleStatus.TargetReplicas != nil {
+		data.TargetReplicas = *scaleCtx.AlertScale.Status.ScaleStatus.TargetReplicas
+	}
 
 	return data
 }
@@ -185,7 +232,7 @@ func (ns *NotificationService) renderWithTemplate(ctx context.Context, scaleCtx
 
 // renderDefaultMessage 渲染默认消息
 func (ns *NotificationService) renderDefaultMessage(data *TemplateData) string {
-	return fmt.Sprintf(`**扩缩容操作通知**
+	message := fmt.Sprintf(`**扩缩容操作通知**
 
 **目标资源:** %s/%s
 **命名空间:** %s
@@ -215,4 +262,68 @@ func (ns *NotificationService) renderDefaultMessage(data *TemplateData) string {
 		data.ScaleBeginTime.Format("2006-01-02 15:04:05"),
 		data.Timestamp.Format("2006-01-02 15:04:05"),
 	)
+
+	if data.Message != "" {
+		message += fmt.Sprintf("\n\n**状态说明:** %s", data.Message)
+	}
+
+	if len(data.Targets) > 0 {
+		message += "\n\n**扩缩容目标:**"
+		for _, target := range data.Targets {
+			message += fmt.Sprintf("\n- %s: %d → %d (%s)", scaletypes.TargetRef(&target.Target), target.OriginReplicas, target.TargetReplicas, target.Phase)
+		}
+	}
+
+	if data.ScaleType == scaletypes.ScaleTypeVertical {
+		message += "\n\n**纵向扩容:** " + formatContainerResources(data.Resources)
+		if len(data.OriginResources) > 0 {
+			message += "\n**原始资源:** " + formatContainerResources(data.OriginResources)
+		}
+	}
+
+	if check := data.CapacityCheck; check != nil {
+		message += fmt.Sprintf("\n\n**容量预检:** %s（策略 %s）\n%s", check.Result, check.Policy, check.Message)
+	}
+
+	// 试运行时附上计划，说明工作负载未被修改
+	if data.DryRun {
+		message = "**[试运行]** 以下操作仅为计划，未修改工作负载\n\n" + message
+		if len(data.Plan) > 0 {
+			message += "\n\n**试运行计划:**"
+			for _, action := range data.Plan {
+				message += fmt.Sprintf("\n- %s [%s] %s", action.Time.Format("2006-01-02 15:04:05"), action.Status, action.Message)
+			}
+		}
+	}
+	return message
+}
+
+// formatContainerResources 格式化容器资源，如 app(requests: memory=2Gi; limits: memory=4Gi)
+func formatContainerResources(resources []opsv1beta1.ContainerResources) string {
+	formatList := func(list corev1.ResourceList) string {
+		names := make([]string, 0, len(list))
+		for name := range list {
+			names = append(names, string(name))
+		}
+		sort.Strings(names)
+		parts := make([]string, 0, len(names))
+		for _, name := range names {
+			quantity := list[corev1.ResourceName(name)]
+			parts = append(parts, name+"="+quantity.String())
+		}
+		return strings.Join(parts, ", ")
+	}
+
+	containers := make([]string, 0, len(resources))
+	for _, container := range resources {
+		var parts []string
+		if len(container.Resources.Requests) > 0 {
+			parts = append(parts, "requests: "+formatList(container.Resources.Requests))
+		}
+		if len(container.Resources.Limits) > 0 {
+			parts = append(parts, "limits: "+formatList(container.Resources.Limits))
+		}
+		containers = append(containers, fmt.Sprintf("%s(%s)", container.Name, strings.Join(parts, "; ")))
+	}
+	return strings.Join(containers, ", ")
 }
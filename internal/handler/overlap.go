@@ -0,0 +1,150 @@
+package handler
+
+import (
+	"sort"
+
+	opsv1beta1 "udesk.cn/ops/api/v1beta1"
+	"udesk.cn/ops/internal/strategy"
+	"udesk.cn/ops/internal/types"
+)
+
+// 多个 AlertScale 同时作用于同一工作负载时的合并规则：
+//   - 后创建的 AlertScale 继承仍在生效的 AlertScale 记录的原始副本数与 HPA 快照
+//   - 生效期间取所有生效 AlertScale 中最大的目标副本数
+//   - 先结束的 AlertScale 只恢复到剩余生效 AlertScale 的最大目标副本数，
+//     由最后一个结束的 AlertScale 恢复到真正的原始副本数
+//   - 试运行的 AlertScale 不实际持有工作负载，不参与其他 AlertScale 的合并
+//   - 纵向与横向 AlertScale 分别合并，纵向的合并规则见 vertical.go
+//   - 多目标 AlertScale 的每个未恢复成员按 status.targets 中的记录视为作用于该成员的 AlertScale 参与合并，见 group.go
+
+// holdingStatuses 正在持有扩容结果的状态
+var holdingStatuses = []string{
+	types.ScaleStatusApproved,
+	types.ScaleStatusScaling,
+	types.ScaleStatusScaled,
+}
+
+// inheritableStatuses 可继承原始副本数的状态，包括正在恢复中的 Completed
+var inheritableStatuses = []string{
+	types.ScaleStatusApproved,
+	types.ScaleStatusScaling,
+	types.ScaleStatusScaled,
+	types.ScaleStatusCompleted,
+}
+
+// overlappingScales 返回与当前 AlertScale 作用于同一工作负载且处于指定状态的其他 AlertScale，按创建时间排序
+func (h *BaseStateHandler) overlappingScales(ctx *types.ScaleContext, statuses []string) ([]opsv1beta1.AlertScale, error) {
+	return h.scalesOnTarget(ctx, &ctx.AlertScale.Spec.ScaleTarget, statuses)
+}
+
+// scalesOnTarget 返回当前 AlertScale 以外作用于 target 且处于指定状态的 AlertScale，按创建时间排序；
+// 多目标 AlertScale 以 memberView 的形式返回其作用于 target 的成员
+func (h *BaseStateHandler) scalesOnTarget(ctx *types.ScaleContext, target *opsv1beta1.ScaleTarget, statuses []string) ([]opsv1beta1.AlertScale, error) {
+	alertScaleList := &opsv1beta1.AlertScaleList{}
+	if err := ctx.Client.List(ctx.Context, alertScaleList); err != nil {
+		return nil, err
+	}
+
+	var overlapping []opsv1beta1.AlertScale
+	for i := range alertScaleList.Items {
+		other := &alertScaleList.Items[i]
+		if other.Namespace == ctx.AlertScale.Namespace && other.Name == ctx.AlertScale.Name {
+			continue
+		}
+		if other.Spec.DryRun || types.IsVertical(other) != types.IsVertical(ctx.AlertScale) {
+			continue
+		}
+		if !containsStatus(statuses, other.Status.ScaleStatus.Status) {
+			continue
+		}
+		if types.IsGroup(other) {
+			if member := groupMember(other, target); member != nil {
+				overlapping = append(overlapping, memberView(other, member))
+			}
+			continue
+		}
+		if strategy.SameScaleTarget(&other.Spec.ScaleTarget, target) {
+			overlapping = append(overlapping, *other)
+		}
+	}
+
+	sort.Slice(overlapping, func(i, j int) bool {
+		return overlapping[i].CreationTimestamp.Before(&overlapping[j].CreationTimestamp)
+	})
+	return overlapping, nil
+}
+
+// groupMember 返回多目标 AlertScale 中作用于 target 且尚未恢复的成员
+func groupMember(group *opsv1beta1.AlertScale, target *opsv1beta1.ScaleTarget) *opsv1beta1.TargetStatus {
+	for i := range group.Status.Targets {
+		member := &group.Status.Targets[i]
+		if member.Phase != types.TargetPhaseRestored && strategy.SameScaleTarget(&member.Target, target) {
+			return member
+		}
+	}
+	return nil
+}
+
+// memberView 将多目标 AlertScale 的成员表示为只作用于该成员的 AlertScale，
+// 原始副本数、目标副本数和 HPA 快照取自成员的记录，只用于合并计算，不能写回
+func memberView(group *opsv1beta1.AlertScale, member *opsv1beta1.TargetStatus) opsv1beta1.AlertScale {
+	view := *group
+	view.Spec.ScaleTarget = member.Target
+	view.Spec.ScaleTargets = nil
+	view.Spec.ScaleTargetSelector = nil
+	view.Status.Targets = nil
+	view.Status.OriginHPA = member.OriginHPA
+	view.Status.ScaleStatus.OriginReplicas = member.OriginReplicas
+	targetReplicas := member.TargetReplicas
+	view.Status.ScaleStatus.TargetReplicas = &targetReplicas
+	return view
+}
+
+// restoreTarget 返回结束扩容时应恢复到的副本数：
+// 仍有其他生效的 AlertScale 时恢复到它们的最大目标副本数，否则恢复到原始副本数
+func (h *BaseStateHandler) restoreTarget(ctx *types.ScaleContext) (int32, []opsv1beta1.AlertScale, error) {
+	holders, err := h.overlappingScales(ctx, holdingStatuses)
+	if err != nil {
+		return 0, nil, err
+	}
+	if len(holders) == 0 {
+		return ctx.AlertScale.Status.ScaleStatus.OriginReplicas, nil, nil
+	}
+	return maxTargetReplicas(holders), holders, nil
+}
+
+// maxTargetReplicas 返回一组 AlertScale 中最大的目标副本数
+func maxTargetReplicas(alertScales []opsv1beta1.AlertScale) int32 {
+	var maxReplicas int32
+	for i := range alertScales {
+		maxReplicas = max(maxReplicas, scaleTargetReplicas(&alertScales[i]))
+	}
+	return maxReplicas
+}
+
+// scaleTargetReplicas 返回 AlertScale 记录的目标副本数，未记录时按 spec 计算
+func scaleTargetReplicas(alertScale *opsv1beta1.AlertScale) int32 {
+	status := &alertScale.Status.ScaleStatus
+	if status.TargetReplicas != nil {
+		return *status.TargetReplicas
+	}
+	return types.CalculateTargetReplicas(&alertScale.Spec, status.OriginReplicas)
+}
+
+// scaleRefs 返回 AlertScale 的 namespace/name 列表
+func scaleRefs(alertScales []opsv1beta1.AlertScale) []string {
+	refs := make([]string, 0, len(alertScales))
+	for _, alertScale := range alertScales {
+		refs = append(refs, alertScale.Namespace+"/"+alertScale.Name)
+	}
+	return refs
+}
+
+func containsStatus(statuses []string, status string) bool {
+	for _, s := range statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
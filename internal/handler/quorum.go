@@ -0,0 +1,128 @@
+package handler
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	opsv1beta1 "udesk.cn/ops/api/v1beta1"
+	"udesk.cn/ops/constants"
+	"udesk.cn/ops/internal/policy"
+	"udesk.cn/ops/internal/types"
+)
+
+// 被 ApprovalPolicy 选中的 AlertScale 需要多方审批：
+//   - 每次批准记录到 status.approvals，所有策略的每个审批组都达到所需人数后才切换到 Approved
+//   - 任一审批组成员拒绝即切换到 Rejected
+//   - 不属于任何审批组的用户提交的决策被忽略，并记录 Warning Event
+//   - 每个决策处理后将 approval-processing 标记为 completed，下一位审批人才能提交
+//   - 不使用 scaleAutoApproval 和自动审批规则，告警恢复和审批超时仍然生效
+
+// handleQuorum 按 ApprovalPolicy 收集审批，法定人数满足时切换到 Approved
+func (h *ApprovalingHandler) handleQuorum(ctx *types.ScaleContext, policies []opsv1beta1.ApprovalPolicy) (ctrl.Result, error) {
+	if result, err := h.processQuorumDecision(ctx, policies); result != nil {
+		return *result, err
+	}
+
+	// 策略调整后已记录的审批可能已满足法定人数
+	if met, progress := policy.EvaluateQuorum(policies, ctx.AlertScale.Status.Approvals); met {
+		return h.approveByQuorum(ctx, lastApprover(ctx.AlertScale), progress)
+	}
+
+	if h.isAlertResolved(ctx) {
+		return h.processAlertResolved(ctx)
+	}
+	return h.processTimeout(ctx)
+}
+
+// processQuorumDecision 处理审批人提交的决策，没有待处理的决策时返回 nil
+func (h *ApprovalingHandler) processQuorumDecision(ctx *types.ScaleContext, policies []opsv1beta1.ApprovalPolicy) (*ctrl.Result, error) {
+	annotations := ctx.AlertScale.Annotations
+	decision, exists := annotations[constants.ApprovalDecisionAnnotation]
+	if !exists || annotations[constants.ApprovalProcessingAnnotation] != constants.ApprovalProcessingPending {
+		return nil, nil
+	}
+
+	log := logf.FromContext(ctx.Context)
+	operator := annotations[constants.ApprovalOperatorAnnotation]
+	log.Info("Processing approval decision against ApprovalPolicies", "decision", decision, "operator", operator, "alertScale", ctx.AlertScale.Name)
+
+	var result ctrl.Result
+	var err error
+	groups := policy.ApproverGroups(policies, operator)
+	switch {
+	case len(groups) == 0:
+		h.recordEvent(ctx, corev1.EventTypeWarning, types.ReasonApprovalIgnored,
+			fmt.Sprintf("Ignored %s from %s: not a member of any approver group", decision, operator))
+		result = ctrl.Result{RequeueAfter: time.Second * 10}
+	case decision == constants.ApprovalDecisionReject:
+		h.transitionBy(ctx, operator, types.ScaleStatusRejected, types.ReasonRejected, "Rejected by "+operator)
+		err = ctx.Client.Status().Update(ctx.Context, ctx.AlertScale)
+		result = ctrl.Result{Requeue: true}
+	case decision == constants.ApprovalDecisionApprove:
+		result, err = h.recordApproval(ctx, policies, operator, groups)
+	default:
+		log.Error(nil, "Unknown approval decision", "decision", decision)
+		result = ctrl.Result{RequeueAfter: time.Second * 10}
+	}
+	if err != nil {
+		log.Error(err, "Failed to update status after approval decision", "decision", decision)
+		return &ctrl.Result{}, err
+	}
+
+	// 标记处理完成，允许下一位审批人提交
+	if err := h.markApprovalCompleted(ctx); err != nil {
+		log.Error(err, "Failed to mark approval as completed")
+	}
+	return &result, nil
+}
+
+// recordApproval 记录审批人的批准，法定人数满足时切换到 Approved，否则保持 Approvaling 并更新进度
+func (h *ApprovalingHandler) recordApproval(ctx *types.ScaleContext, policies []opsv1beta1.ApprovalPolicy, operator string, groups []string) (ctrl.Result, error) {
+	status := &ctx.AlertScale.Status
+	if !slices.ContainsFunc(status.Approvals, func(approval opsv1beta1.ApprovalRecord) bool { return approval.Approver == operator }) {
+		status.Approvals = append(status.Approvals, opsv1beta1.ApprovalRecord{
+			Approver: operator,
+			Groups:   groups,
+			Time:     metav1.Now(),
+			Reason:   ctx.AlertScale.Annotations[constants.ApprovalReasonAnnotation],
+		})
+	}
+
+	met, progress := policy.EvaluateQuorum(policies, status.Approvals)
+	if met {
+		return h.approveByQuorum(ctx, operator, progress)
+	}
+
+	status.ScaleStatus.Message = "Waiting for approvals: " + progress
+	h.recordEvent(ctx, corev1.EventTypeNormal, types.ReasonApprovalRecorded,
+		fmt.Sprintf("Approval from %s recorded, waiting for approvals: %s", operator, progress))
+	return ctrl.Result{RequeueAfter: time.Second * 10}, ctx.Client.Status().Update(ctx.Context, ctx.AlertScale)
+}
+
+// approveByQuorum 法定人数满足后切换到 Approved，由最后一位审批人记为操作人
+func (h *ApprovalingHandler) approveByQuorum(ctx *types.ScaleContext, operator, progress string) (ctrl.Result, error) {
+	approvers := make([]string, 0, len(ctx.AlertScale.Status.Approvals))
+	for _, approval := range ctx.AlertScale.Status.Approvals {
+		approvers = append(approvers, approval.Approver)
+	}
+	message := fmt.Sprintf("Approved by %s (%s)", strings.Join(approvers, ", "), progress)
+	logf.FromContext(ctx.Context).Info("Approval quorum met, transitioning to Approved state", "alertScale", ctx.AlertScale.Name, "approvers", approvers)
+
+	h.transitionBy(ctx, operator, types.ScaleStatusApproved, types.ReasonApproved, message)
+	return ctrl.Result{Requeue: true}, ctx.Client.Status().Update(ctx.Context, ctx.AlertScale)
+}
+
+// lastApprover 返回最后一位记录的审批人
+func lastApprover(alertScale *opsv1beta1.AlertScale) string {
+	approvals := alertScale.Status.Approvals
+	if len(approvals) == 0 {
+		return ""
+	}
+	return approvals[len(approvals)-1].Approver
+}
@@ -1,20 +1,46 @@
 package handler
 
 import (
+	"fmt"
+	"strings"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	opsv1beta1 "udesk.cn/ops/api/v1beta1"
+	"udesk.cn/ops/constants"
+	"udesk.cn/ops/internal/metrics"
+	"udesk.cn/ops/internal/policy"
+	"udesk.cn/ops/internal/strategy"
 	"udesk.cn/ops/internal/types"
 )
 
+const (
+	// driftCheckInterval Scaled 状态下检查副本数漂移的间隔
+	driftCheckInterval = time.Second * 30
+	// maxDriftEvents 状态中保留的漂移事件数量
+	maxDriftEvents = 10
+	// defaultRetryBackoff 未配置或无法解析重试退避时间时的默认值
+	defaultRetryBackoff = time.Second * 30
+	// maxRetryBackoff 重试退避时间上限
+	maxRetryBackoff = time.Hour
+)
+
 func parseDuration(duration string) (time.Duration, error) {
 	if duration == "" {
 		duration = "5m"
 	}
-	return time.ParseDuration(duration)
+	return types.ParseDuration(duration)
+}
+
+// requestedBy 返回 webhook 写入的请求者，未经 webhook 创建的对象视为系统创建
+func requestedBy(alertScale *opsv1beta1.AlertScale) string {
+	if requester := alertScale.Annotations[constants.RequestedByAnnotation]; requester != "" {
+		return requester
+	}
+	return types.ActorSystem
 }
 
 // BaseStateHandler 提供通用的状态处理功能
@@ -25,11 +51,43 @@ func (h *BaseStateHandler) parseDuration(duration string) (time.Duration, error)
 	return parseDuration(duration)
 }
 
-func (h *BaseStateHandler) updateStatus(ctx *types.ScaleContext, status string) error {
-	ctx.AlertScale.Status.ScaleStatus.Status = status
+func (h *BaseStateHandler) updateStatus(ctx *types.ScaleContext, status, reason, message string) error {
+	h.transitionTo(ctx, status, reason, message)
 	return ctx.Client.Status().Update(ctx.Context, ctx.AlertScale)
 }
 
+// transitionTo 由控制器自动切换状态，见 transitionBy
+func (h *BaseStateHandler) transitionTo(ctx *types.ScaleContext, toStatus, reason, message string) {
+	h.transitionBy(ctx, types.ActorSystem, toStatus, reason, message)
+}
+
+// transitionBy 切换状态，同步更新 Conditions、追加状态切换记录并记录 Event，由调用方提交状态更新
+func (h *BaseStateHandler) transitionBy(ctx *types.ScaleContext, actor, toStatus, reason, message string) {
+	status := &ctx.AlertScale.Status.ScaleStatus
+	fromStatus := status.Status
+	if message == "" {
+		message = fmt.Sprintf("AlertScale transitioned from %s to %s", fromStatus, toStatus)
+	}
+	status.Status = toStatus
+	types.SetTransitionConditions(ctx.AlertScale, toStatus, reason, message)
+	metrics.ObserveTransition(ctx.AlertScale, fromStatus, toStatus, time.Now())
+	types.RecordTransition(ctx.AlertScale, fromStatus, toStatus, actor, reason, message)
+
+	eventType := corev1.EventTypeNormal
+	if toStatus == types.ScaleStatusFailed {
+		eventType = corev1.EventTypeWarning
+	}
+	h.recordEvent(ctx, eventType, reason, message)
+}
+
+// recordEvent 记录 Kubernetes Event，未配置 Recorder 时忽略
+func (h *BaseStateHandler) recordEvent(ctx *types.ScaleContext, eventType, reason, message string) {
+	if ctx.Recorder == nil {
+		return
+	}
+	ctx.Recorder.Event(ctx.AlertScale, eventType, reason, message)
+}
+
 func (h *BaseStateHandler) sendNotification(ctx *types.ScaleContext, status string) {
 	log := logf.FromContext(ctx.Context)
 	notificationService := NewNotificationService(ctx.Client)
@@ -42,6 +100,250 @@ func (h *BaseStateHandler) isTimeout(beginTime metav1.Time, timeoutDuration time
 	return beginTime.IsZero() || beginTime.Time.Add(timeoutDuration).Before(time.Now())
 }
 
+// isAlertResolved 检查触发扩容的告警是否已恢复
+func (h *BaseStateHandler) isAlertResolved(ctx *types.ScaleContext) bool {
+	_, resolved := ctx.AlertScale.Annotations[constants.AlertResolvedAnnotation]
+	return resolved
+}
+
+// targetReplicas 返回状态中记录的目标副本数，未记录时按当前 spec 计算
+func (h *BaseStateHandler) targetReplicas(ctx *types.ScaleContext) int32 {
+	return scaleTargetReplicas(ctx.AlertScale)
+}
+
+// nextStep 计算分步调整下一步的副本数，未满足进入下一步的条件时返回当前副本数和需要等待的时长
+func (h *BaseStateHandler) nextStep(ctx *types.ScaleContext, policy *opsv1beta1.StepPolicy, progress *opsv1beta1.StepProgress, currentReplicas, targetReplicas int32) (int32, time.Duration, error) {
+	// 等待步长间隔
+	if progress.LastStepTime != nil && policy.StepInterval != "" {
+		interval, err := h.parseDuration(policy.StepInterval)
+		if err != nil {
+			return currentReplicas, 0, err
+		}
+		if remaining := time.Until(progress.LastStepTime.Add(interval)); remaining > 0 {
+			return currentReplicas, remaining, nil
+		}
+	}
+
+	// 等待上一步收敛：扩容时新副本全部就绪，缩容时多余副本全部退出
+	if policy.WaitForReady && progress.CurrentStep > 0 {
+		availableReplicas, err := ctx.ScaleStrategy.GetAvailableReplicas(
+			ctx.Context,
+			ctx.Client,
+			&ctx.AlertScale.Spec.ScaleTarget,
+		)
+		if err != nil {
+			return currentReplicas, 0, err
+		}
+		if targetReplicas > currentReplicas && availableReplicas < currentReplicas ||
+			targetReplicas < currentReplicas && availableReplicas > currentReplicas {
+			return currentReplicas, 0, nil
+		}
+	}
+
+	replicasPerStep := max(policy.ReplicasPerStep, 1)
+	now := metav1.Now()
+	progress.CurrentStep++
+	progress.LastStepTime = &now
+	if targetReplicas > currentReplicas {
+		return min(currentReplicas+replicasPerStep, targetReplicas), 0, nil
+	}
+	return max(currentReplicas-replicasPerStep, targetReplicas), 0, nil
+}
+
+// newStepProgress 初始化分步调整进度
+func newStepProgress(policy *opsv1beta1.StepPolicy, from, to int32) *opsv1beta1.StepProgress {
+	diff := to - from
+	if diff < 0 {
+		diff = -diff
+	}
+	replicasPerStep := max(policy.ReplicasPerStep, 1)
+	return &opsv1beta1.StepProgress{
+		TotalSteps: (diff + replicasPerStep - 1) / replicasPerStep,
+	}
+}
+
+// restoreHPA 按状态中记录的快照恢复 HPA 的副本数边界，返回是否存在需要恢复的 HPA
+// holders 为同一工作负载上仍在生效的其他 AlertScale，HPA 下限保持为它们的最大目标副本数
+func (h *BaseStateHandler) restoreHPA(ctx *types.ScaleContext, holders []opsv1beta1.AlertScale) (bool, error) {
+	snapshot := ctx.AlertScale.Status.OriginHPA
+	if snapshot == nil {
+		return false, nil
+	}
+
+	bounds := hpaRestoreBounds(snapshot, holders)
+	if err := strategy.RestoreHPABounds(
+		ctx.Context,
+		ctx.Client,
+		ctx.AlertScale.Spec.ScaleTarget.Namespace,
+		bounds,
+	); err != nil {
+		return true, err
+	}
+
+	logf.FromContext(ctx.Context).Info("Restored HPA bounds", "hpa", snapshot.Name, "alertScale", ctx.AlertScale.Name)
+	ctx.AlertScale.Status.OriginHPA = nil
+	return true, nil
+}
+
+// hpaRestoreBounds 返回 HPA 应恢复到的副本数边界，下限不低于其他生效 AlertScale 的最大目标副本数
+func hpaRestoreBounds(snapshot *opsv1beta1.HPASnapshot, holders []opsv1beta1.AlertScale) *opsv1beta1.HPASnapshot {
+	bounds := snapshot.DeepCopy()
+	if len(holders) > 0 {
+		holdReplicas := maxTargetReplicas(holders)
+		if bounds.MinReplicas == nil || *bounds.MinReplicas < holdReplicas {
+			bounds.MinReplicas = &holdReplicas
+		}
+		bounds.MaxReplicas = max(bounds.MaxReplicas, holdReplicas)
+	}
+	return bounds
+}
+
+// stepDownHPA 配置了缩容策略时逐步降低 HPA 的 minReplicas，返回下一步前需要等待的时长；
+// 降到恢复后的下限时返回 0，由 restoreHPA 恢复原始边界并将副本数交还 HPA 管理
+func (h *BaseStateHandler) stepDownHPA(ctx *types.ScaleContext, holders []opsv1beta1.AlertScale) (time.Duration, error) {
+	policy := ctx.AlertScale.Spec.ScaleDownPolicy
+	snapshot := ctx.AlertScale.Status.OriginHPA
+	if policy == nil || snapshot == nil {
+		return 0, nil
+	}
+	if policy.Paused {
+		logf.FromContext(ctx.Context).Info("Restore paused by scale-down policy", "alertScale", ctx.AlertScale.Name)
+		return time.Second * 30, nil
+	}
+
+	hpa, err := strategy.GetHPA(ctx.Context, ctx.Client, ctx.AlertScale.Spec.ScaleTarget.Namespace, snapshot.Name)
+	if err != nil || hpa == nil {
+		return 0, err
+	}
+	floor := int32(1)
+	if bounds := hpaRestoreBounds(snapshot, holders); bounds.MinReplicas != nil {
+		floor = *bounds.MinReplicas
+	}
+	currentMin := int32(1)
+	if hpa.Spec.MinReplicas != nil {
+		currentMin = *hpa.Spec.MinReplicas
+	}
+
+	status := &ctx.AlertScale.Status.ScaleStatus
+	progress := status.ScaleDownProgress
+	// 实际副本数由 HPA 决定，等待多余副本退出到 HPA 期望的副本数后再进入下一步
+	if policy.WaitForReady && progress != nil && progress.CurrentStep > 0 {
+		availableReplicas, err := ctx.ScaleStrategy.GetAvailableReplicas(ctx.Context, ctx.Client, &ctx.AlertScale.Spec.ScaleTarget)
+		if err != nil {
+			return 0, err
+		}
+		status.ScaledReplicas = availableReplicas
+		if availableReplicas > max(currentMin, hpa.Status.DesiredReplicas) {
+			return time.Second * 5, ctx.Client.Status().Update(ctx.Context, ctx.AlertScale)
+		}
+	}
+	if currentMin <= floor {
+		return 0, nil
+	}
+
+	if progress == nil {
+		progress = newStepProgress(&policy.StepPolicy, currentMin, floor)
+		status.ScaleDownProgress = progress
+	}
+	stepPolicy := policy.StepPolicy
+	stepPolicy.WaitForReady = false
+	minReplicas, stepWait, err := h.nextStep(ctx, &stepPolicy, progress, currentMin, floor)
+	if err != nil {
+		return 0, err
+	}
+	if minReplicas != currentMin {
+		if err := strategy.SetHPAMinReplicas(ctx.Context, ctx.Client, hpa, minReplicas); err != nil {
+			return 0, err
+		}
+		logf.FromContext(ctx.Context).Info("Lowered HPA minReplicas", "hpa", hpa.Name, "minReplicas", minReplicas, "alertScale", ctx.AlertScale.Name)
+	}
+
+	requeueAfter := time.Second * 5
+	if stepWait > 0 && stepWait < requeueAfter {
+		requeueAfter = stepWait
+	}
+	return requeueAfter, ctx.Client.Status().Update(ctx.Context, ctx.AlertScale)
+}
+
+// processScaleAction 处理通过注解提交的延长/取消操作，allowExtend 为 false 时延长操作保留到扩容完成后处理
+func (h *BaseStateHandler) processScaleAction(ctx *types.ScaleContext, allowExtend bool) (*ctrl.Result, error) {
+	log := logf.FromContext(ctx.Context)
+	annotations := ctx.AlertScale.Annotations
+	if annotations[constants.ScaleActionProcessingAnnotation] != constants.ApprovalProcessingPending {
+		return nil, nil
+	}
+
+	action := annotations[constants.ScaleActionAnnotation]
+	if action == constants.ScaleActionExtend && !allowExtend {
+		return nil, nil
+	}
+
+	status := &ctx.AlertScale.Status.ScaleStatus
+	record := &opsv1beta1.ScaleAction{
+		Action:   action,
+		Operator: annotations[constants.ScaleActionOperatorAnnotation],
+		Reason:   annotations[constants.ScaleActionReasonAnnotation],
+		Time:     metav1.Now(),
+	}
+
+	var phase string
+	switch action {
+	case constants.ScaleActionExtend:
+		durationValue := annotations[constants.ScaleActionDurationAnnotation]
+		duration, err := h.parseDuration(durationValue)
+		if durationValue == "" || err != nil || duration <= 0 {
+			log.Error(err, "Invalid extend duration", "duration", durationValue, "alertScale", ctx.AlertScale.Name)
+			return &ctrl.Result{}, h.markScaleActionCompleted(ctx)
+		}
+		// 延长后的总持续时间同样受 ScaleGuardrail 的 maxDuration 限制
+		scaleEndTime := status.ScaleEndTime.Add(duration)
+		violations, err := policy.CheckExtension(ctx.Context, ctx.Client, ctx.AlertScale, scaleEndTime.Sub(status.ScaleBeginTime.Time))
+		if err != nil {
+			return &ctrl.Result{}, err
+		}
+		if len(violations) > 0 {
+			message := fmt.Sprintf("Extension by %s from %s rejected by %s", durationValue, record.Operator, policy.Summarize(violations))
+			h.recordEvent(ctx, corev1.EventTypeWarning, types.ReasonGuardrailViolated, message)
+			log.Info("Scale extension violates ScaleGuardrails", "alertScale", ctx.AlertScale.Name, "message", message)
+			return &ctrl.Result{}, h.markScaleActionCompleted(ctx)
+		}
+		record.Duration = durationValue
+		status.ScaleEndTime = metav1.NewTime(scaleEndTime)
+		phase = "extended"
+		h.recordEvent(ctx, corev1.EventTypeNormal, types.ReasonExtended,
+			fmt.Sprintf("Scaling extended by %s until %s by %s", durationValue, status.ScaleEndTime.Format(time.RFC3339), record.Operator))
+		log.Info("Scale extended", "alertScale", ctx.AlertScale.Name, "duration", durationValue, "operator", record.Operator)
+	case constants.ScaleActionCancel:
+		status.ScaleEndTime = metav1.Now()
+		h.transitionBy(ctx, record.Operator, types.ScaleStatusCompleted, types.ReasonCancelled,
+			fmt.Sprintf("Scaling cancelled by %s", record.Operator))
+		phase = "cancelled"
+		log.Info("Scale cancelled", "alertScale", ctx.AlertScale.Name, "operator", record.Operator)
+	default:
+		log.Error(nil, "Unknown scale action", "action", action, "alertScale", ctx.AlertScale.Name)
+		return &ctrl.Result{}, h.markScaleActionCompleted(ctx)
+	}
+
+	status.LastAction = record
+	if err := ctx.Client.Status().Update(ctx.Context, ctx.AlertScale); err != nil {
+		return &ctrl.Result{}, err
+	}
+	if err := h.markScaleActionCompleted(ctx); err != nil {
+		log.Error(err, "Failed to mark scale action as completed")
+	}
+
+	h.sendNotification(ctx, phase)
+	return &ctrl.Result{Requeue: true}, nil
+}
+
+func (h *BaseStateHandler) markScaleActionCompleted(ctx *types.ScaleContext) error {
+	if ctx.AlertScale.Annotations == nil {
+		ctx.AlertScale.Annotations = make(map[string]string)
+	}
+	ctx.AlertScale.Annotations[constants.ScaleActionProcessingAnnotation] = constants.ApprovalProcessingCompleted
+	return ctx.Client.Update(ctx.Context, ctx.AlertScale)
+}
+
 // ApprovalingHandler 处理 Approvaling 状态
 type ApprovalingHandler struct {
 	BaseStateHandler
@@ -51,16 +353,36 @@ func (h *ApprovalingHandler) Handle(ctx *types.ScaleContext) (ctrl.Result, error
 	log := logf.FromContext(ctx.Context)
 	log.Info("Handling Approvaling state", "alertScale", ctx.AlertScale.Name)
 
+	// 被 ApprovalPolicy 选中时需要各审批组达到法定人数，不再使用单人审批和自动审批
+	policies, err := policy.MatchApprovalPolicies(ctx.Context, ctx.Client, ctx.AlertScale)
+	if err != nil {
+		log.Error(err, "Failed to match ApprovalPolicies", "alertScale", ctx.AlertScale.Name)
+		return ctrl.Result{}, err
+	}
+	if len(policies) > 0 {
+		return h.handleQuorum(ctx, policies)
+	}
+
 	// 检查API审批决策
 	if result, err := h.processAPIApproval(ctx); result != nil {
 		return *result, err
 	}
 
+	// 告警已恢复，无需继续扩容
+	if h.isAlertResolved(ctx) {
+		return h.processAlertResolved(ctx)
+	}
+
 	// 检查自动批准
 	if ctx.AlertScale.Spec.ScaleAutoApproval {
 		return h.processAutoApproval(ctx)
 	}
 
+	// 检查自动审批规则，第一条匹配的规则作为审批人
+	if rule := h.matchAutoApprovalRule(ctx); rule != "" {
+		return h.processRuleApproval(ctx, rule)
+	}
+
 	// 检查超时
 	return h.processTimeout(ctx)
 }
@@ -80,13 +402,16 @@ func (h *ApprovalingHandler) processAPIApproval(ctx *types.ScaleContext) (*ctrl.
 
 	log.Info("Processing API approval decision", "decision", decision, "alertScale", ctx.AlertScale.Name)
 
-	var newStatus string
+	operator := ctx.AlertScale.Annotations["ops.udesk.cn/approval-operator"]
+	var newStatus, reason, message string
 	switch decision {
 	case "approve":
-		newStatus = types.ScaleStatusApproved
+		newStatus, reason = types.ScaleStatusApproved, types.ReasonApproved
+		message = "Approved by " + operator
 		log.Info("API approval processed: Approved", "alertScale", ctx.AlertScale.Name)
 	case "reject":
-		newStatus = types.ScaleStatusRejected
+		newStatus, reason = types.ScaleStatusRejected, types.ReasonRejected
+		message = "Rejected by " + operator
 		log.Info("API approval processed: Rejected", "alertScale", ctx.AlertScale.Name)
 	default:
 		log.Error(nil, "Unknown approval decision", "decision", decision)
@@ -95,7 +420,8 @@ func (h *ApprovalingHandler) processAPIApproval(ctx *types.ScaleContext) (*ctrl.
 	}
 
 	// 更新状态
-	if err := h.updateStatus(ctx, newStatus); err != nil {
+	h.transitionBy(ctx, operator, newStatus, reason, message)
+	if err := ctx.Client.Status().Update(ctx.Context, ctx.AlertScale); err != nil {
 		log.Error(err, "Failed to update status after API approval", "decision", decision)
 		return &ctrl.Result{}, err
 	}
@@ -113,7 +439,46 @@ func (h *ApprovalingHandler) processAutoApproval(ctx *types.ScaleContext) (ctrl.
 	log := logf.FromContext(ctx.Context)
 	log.Info("Auto approval enabled, transitioning to Approved state")
 
-	if err := h.updateStatus(ctx, types.ScaleStatusApproved); err != nil {
+	if err := h.updateStatus(ctx, types.ScaleStatusApproved, types.ReasonAutoApproved, "Auto-approved by system"); err != nil {
+		log.Error(err, "Failed to update status to Approved")
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{Requeue: true}, nil
+}
+
+// matchAutoApprovalRule 求值自动审批规则，返回匹配的规则名称；求值失败的规则视为不匹配
+func (h *ApprovalingHandler) matchAutoApprovalRule(ctx *types.ScaleContext) string {
+	rules := ctx.AlertScale.Spec.AutoApprovalRules
+	if len(rules) == 0 {
+		return ""
+	}
+	log := logf.FromContext(ctx.Context)
+
+	input := policy.ApprovalInput{AlertScale: ctx.AlertScale, Now: time.Now()}
+	if !types.IsGroup(ctx.AlertScale) {
+		target, err := strategy.GetTargetObject(ctx.Context, ctx.Client, &ctx.AlertScale.Spec.ScaleTarget)
+		if err != nil {
+			log.Error(err, "Failed to get scale target for auto-approval rules", "alertScale", ctx.AlertScale.Name)
+		} else {
+			input.Target = target.Object
+		}
+	}
+
+	rule, err := policy.MatchApprovalRules(rules, input)
+	if err != nil {
+		log.Error(err, "Failed to evaluate auto-approval rules", "alertScale", ctx.AlertScale.Name)
+	}
+	return rule
+}
+
+// processRuleApproval 由匹配的自动审批规则批准，规则以 rule:<name> 记录为审批人
+func (h *ApprovalingHandler) processRuleApproval(ctx *types.ScaleContext, rule string) (ctrl.Result, error) {
+	log := logf.FromContext(ctx.Context)
+	log.Info("Auto-approval rule matched, transitioning to Approved state", "alertScale", ctx.AlertScale.Name, "rule", rule)
+
+	h.transitionBy(ctx, types.RuleActor(rule), types.ScaleStatusApproved, types.ReasonAutoApproved, "Auto-approved by rule "+rule)
+	if err := ctx.Client.Status().Update(ctx.Context, ctx.AlertScale); err != nil {
 		log.Error(err, "Failed to update status to Approved")
 		return ctrl.Result{}, err
 	}
@@ -121,6 +486,18 @@ func (h *ApprovalingHandler) processAutoApproval(ctx *types.ScaleContext) (ctrl.
 	return ctrl.Result{Requeue: true}, nil
 }
 
+func (h *ApprovalingHandler) processAlertResolved(ctx *types.ScaleContext) (ctrl.Result, error) {
+	log := logf.FromContext(ctx.Context)
+	log.Info("Alert resolved before approval, transitioning to Rejected state", "alertScale", ctx.AlertScale.Name)
+
+	if err := h.updateStatus(ctx, types.ScaleStatusRejected, types.ReasonAlertResolved, "Alert resolved before approval"); err != nil {
+		log.Error(err, "Failed to update status to Rejected")
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{Requeue: true}, nil
+}
+
 func (h *ApprovalingHandler) processTimeout(ctx *types.ScaleContext) (ctrl.Result, error) {
 	timeout, err := h.parseDuration(ctx.AlertScale.Spec.ScaleTimeout)
 	if err != nil {
@@ -141,7 +518,7 @@ func (h *ApprovalingHandler) processTimeout(ctx *types.ScaleContext) (ctrl.Resul
 		log := logf.FromContext(ctx.Context)
 		log.Info("Approval timeout reached, transitioning to Rejected state")
 
-		if err := h.updateStatus(ctx, types.ScaleStatusRejected); err != nil {
+		if err := h.updateStatus(ctx, types.ScaleStatusRejected, types.ReasonApprovalTimeout, "Approval timed out after "+timeout.String()); err != nil {
 			log.Error(err, "Failed to update status to Rejected")
 			return ctrl.Result{}, err
 		}
@@ -176,7 +553,15 @@ func (h *ApprovedHandler) Handle(ctx *types.ScaleContext) (ctrl.Result, error) {
 	log := logf.FromContext(ctx.Context)
 	log.Info("Handling Approved state", "alertScale", ctx.AlertScale.Name)
 
-	if err := h.updateStatus(ctx, types.ScaleStatusScaling); err != nil {
+	// 扩容前检查集群和配额能否容纳新增的副本
+	if result, err := h.checkCapacity(ctx); result != nil {
+		return *result, err
+	}
+
+	// 记录开始调整副本数的时间，扩容超时从此刻开始计算
+	ctx.AlertScale.Status.ScaleStatus.ScalingBeginTime = metav1.Now()
+	ctx.AlertScale.Status.ScaleStatus.Attempts = 1
+	if err := h.updateStatus(ctx, types.ScaleStatusScaling, types.ReasonScalingStarted, ""); err != nil {
 		log.Error(err, "Failed to update status to Scaling")
 		return ctrl.Result{}, err
 	}
@@ -188,7 +573,69 @@ func (h *ApprovedHandler) Handle(ctx *types.ScaleContext) (ctrl.Result, error) {
 }
 
 func (h *ApprovedHandler) CanTransition(toState string) bool {
-	return toState == types.ScaleStatusScaling
+	return toState == types.ScaleStatusScaling || toState == types.ScaleStatusFailed
+}
+
+// checkCapacity 扩容前检查容量并按 CapacityCheckPolicy 处理结果，Block 策略下容量不足时切换到 Failed
+// 检查本身出错时不阻塞扩容，结果记为 Unknown
+func (h *ApprovedHandler) checkCapacity(ctx *types.ScaleContext) (*ctrl.Result, error) {
+	log := logf.FromContext(ctx.Context)
+	target := &ctx.AlertScale.Spec.ScaleTarget
+
+	// 纵向扩缩容不新增副本，多目标 AlertScale 的成员节点选择各不相同，不做预检
+	if types.IsVertical(ctx.AlertScale) || types.IsGroup(ctx.AlertScale) {
+		return nil, nil
+	}
+
+	currentReplicas, err := ctx.ScaleStrategy.GetCurrentReplicas(ctx.Context, ctx.Client, target)
+	if err != nil {
+		return &ctrl.Result{}, err
+	}
+	additionalReplicas := h.targetReplicas(ctx) - currentReplicas
+	if additionalReplicas <= 0 {
+		ctx.AlertScale.Status.CapacityCheck = nil
+		return nil, nil
+	}
+
+	policy := ctx.AlertScale.Spec.CapacityCheckPolicy
+	if policy == "" {
+		policy = types.CapacityCheckPolicyWarn
+	}
+
+	reader := ctx.APIReader
+	if reader == nil {
+		reader = ctx.Client
+	}
+	check, err := strategy.CheckCapacity(ctx.Context, ctx.Client, reader, target, additionalReplicas)
+	if err != nil {
+		log.Error(err, "Capacity check failed, scaling anyway", "alertScale", ctx.AlertScale.Name)
+		check = &opsv1beta1.CapacityCheck{
+			Time:               metav1.Now(),
+			Result:             types.CapacityResultUnknown,
+			AdditionalReplicas: additionalReplicas,
+			Message:            fmt.Sprintf("Capacity check failed: %v", err),
+		}
+	}
+	check.Policy = policy
+	ctx.AlertScale.Status.CapacityCheck = check
+	if check.Result != types.CapacityResultInsufficient {
+		return nil, nil
+	}
+
+	switch policy {
+	case types.CapacityCheckPolicyBlock:
+		ctx.AlertScale.Status.ScaleStatus.ScaleEndTime = metav1.Now()
+		if err := h.updateStatus(ctx, types.ScaleStatusFailed, types.ReasonCapacityShortfall, check.Message); err != nil {
+			log.Error(err, "Failed to update status to Failed")
+			return &ctrl.Result{}, err
+		}
+		h.sendNotification(ctx, "failed")
+		return &ctrl.Result{Requeue: true}, nil
+	case types.CapacityCheckPolicyWarn:
+		h.recordEvent(ctx, corev1.EventTypeWarning, types.ReasonCapacityShortfall, check.Message)
+	}
+	log.Info("Scaling despite insufficient capacity", "alertScale", ctx.AlertScale.Name, "policy", policy, "message", check.Message)
+	return nil, nil
 }
 
 // RejectedHandler 处理 Rejected 状态
@@ -200,7 +647,7 @@ func (h *RejectedHandler) Handle(ctx *types.ScaleContext) (ctrl.Result, error) {
 	log := logf.FromContext(ctx.Context)
 	log.Info("Handling Rejected state", "alertScale", ctx.AlertScale.Name)
 
-	if err := h.updateStatus(ctx, types.ScaleStatusCompleted); err != nil {
+	if err := h.updateStatus(ctx, types.ScaleStatusCompleted, types.ReasonRejected, "Closing rejected AlertScale"); err != nil {
 		log.Error(err, "Failed to update status to Completed")
 		return ctrl.Result{}, err
 	}
@@ -222,6 +669,10 @@ func (h *PendingHandler) Handle(ctx *types.ScaleContext) (ctrl.Result, error) {
 	log := logf.FromContext(ctx.Context)
 
 	log.Info("Handling Pending state", "alertScale", ctx.AlertScale.Name)
+	if types.IsGroup(ctx.AlertScale) {
+		return h.handleGroup(ctx)
+	}
+
 	// 获取当前副本数作为原始副本数
 	originReplicas, err := ctx.ScaleStrategy.GetCurrentReplicas(
 		ctx.Context,
@@ -231,10 +682,40 @@ func (h *PendingHandler) Handle(ctx *types.ScaleContext) (ctrl.Result, error) {
 	if err != nil {
 		return ctrl.Result{}, err
 	}
+
+	// 同一工作负载上已有生效的 AlertScale 时，当前副本数已被扩容，继承其记录的原始副本数
+	overlapping, err := h.overlappingScales(ctx, inheritableStatuses)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if len(overlapping) > 0 {
+		originReplicas = overlapping[0].Status.ScaleStatus.OriginReplicas
+		log.Info("Inherited origin replicas from overlapping AlertScale",
+			"alertScale", ctx.AlertScale.Name, "overlapping", scaleRefs(overlapping[:1]), "originReplicas", originReplicas)
+	}
+
 	status := &ctx.AlertScale.Status.ScaleStatus
 	status.OriginReplicas = originReplicas
 	status.ScaledReplicas = originReplicas // 初始化为原始副本数
-	status.Status = types.ScaleStatusApprovaling
+	// 进入 Pending 时确定目标副本数，后续调和不再重新计算
+	// 纵向扩缩容不改变副本数
+	targetReplicas := types.CalculateTargetReplicas(&ctx.AlertScale.Spec, originReplicas)
+	message := fmt.Sprintf("Waiting for approval to scale from %d to %d replicas", originReplicas, targetReplicas)
+	if types.IsVertical(ctx.AlertScale) {
+		targetReplicas = originReplicas
+		message = "Waiting for approval to raise resources of containers " + verticalContainerNames(ctx.AlertScale)
+	}
+	status.TargetReplicas = &targetReplicas
+
+	// 超出 ScaleGuardrail 限制时不进入审批
+	if blocked, err := h.enforceGuardrails(ctx, []policy.Target{{
+		ScaleTarget:    ctx.AlertScale.Spec.ScaleTarget,
+		OriginReplicas: originReplicas,
+		TargetReplicas: targetReplicas,
+	}}); blocked || err != nil {
+		return ctrl.Result{Requeue: blocked}, err
+	}
+	h.transitionTo(ctx, types.ScaleStatusApprovaling, types.ReasonAwaitingApproval, message)
 
 	if err := ctx.Client.Status().Update(ctx.Context, ctx.AlertScale); err != nil {
 		log.Error(err, "failed to update status to scaling")
@@ -249,6 +730,51 @@ func (h *PendingHandler) Handle(ctx *types.ScaleContext) (ctrl.Result, error) {
 	return ctrl.Result{Requeue: true}, nil
 }
 
+// handleGroup 确定多目标 AlertScale 的成员后进入审批，成员无法解析时切换到 Failed
+func (h *PendingHandler) handleGroup(ctx *types.ScaleContext) (ctrl.Result, error) {
+	log := logf.FromContext(ctx.Context)
+	status := &ctx.AlertScale.Status.ScaleStatus
+
+	if err := h.resolveGroup(ctx); err != nil {
+		if !isUnresolvableGroup(err) {
+			return ctrl.Result{}, err
+		}
+		status.ScaleEndTime = metav1.Now()
+		status.Message = fmt.Sprintf("Failed to resolve scale targets: %v", err)
+		if err := h.updateStatus(ctx, types.ScaleStatusFailed, types.ReasonTargetsUnresolved, status.Message); err != nil {
+			return ctrl.Result{}, err
+		}
+		h.sendNotification(ctx, "failed")
+		return ctrl.Result{Requeue: true}, nil
+	}
+
+	originReplicas, targetReplicas := groupReplicas(ctx.AlertScale.Status.Targets)
+	status.OriginReplicas = originReplicas
+	status.ScaledReplicas = originReplicas
+	status.TargetReplicas = &targetReplicas
+
+	targets := make([]policy.Target, 0, len(ctx.AlertScale.Status.Targets))
+	for _, member := range ctx.AlertScale.Status.Targets {
+		targets = append(targets, policy.Target{
+			ScaleTarget:    member.Target,
+			OriginReplicas: member.OriginReplicas,
+			TargetReplicas: member.TargetReplicas,
+		})
+	}
+	if blocked, err := h.enforceGuardrails(ctx, targets); blocked || err != nil {
+		return ctrl.Result{Requeue: blocked}, err
+	}
+	if err := h.updateStatus(ctx, types.ScaleStatusApprovaling, types.ReasonAwaitingApproval,
+		fmt.Sprintf("Waiting for approval to scale %d targets from %d to %d replicas",
+			len(ctx.AlertScale.Status.Targets), originReplicas, targetReplicas)); err != nil {
+		log.Error(err, "failed to update status to Approvaling")
+		return ctrl.Result{}, err
+	}
+
+	h.sendNotification(ctx, "pending")
+	return ctrl.Result{Requeue: true}, nil
+}
+
 func (h *PendingHandler) CanTransition(toState string) bool {
 	return toState == types.ScaleStatusScaling
 }
@@ -262,13 +788,31 @@ func (h *ScalingHandler) Handle(ctx *types.ScaleContext) (ctrl.Result, error) {
 	log := logf.FromContext(ctx.Context)
 	log.Info("Handling Scaling state", "alertScale", ctx.AlertScale.Name)
 
-	// 使用策略进行扩缩容
-	if err := h.scaleIfNeeded(ctx); err != nil {
+	// 扩容过程中允许取消，延长操作在扩容完成后处理
+	if result, err := h.processScaleAction(ctx, false); result != nil {
+		return *result, err
+	}
+
+	// 超时重试的退避期间不调整副本数，到期后重新计算扩容超时
+	status := &ctx.AlertScale.Status.ScaleStatus
+	if status.NextRetryTime != nil {
+		if wait := time.Until(status.NextRetryTime.Time); wait > 0 {
+			return ctrl.Result{RequeueAfter: wait}, nil
+		}
+		log.Info("Retrying scaling", "alertScale", ctx.AlertScale.Name, "attempt", status.Attempts)
+		status.NextRetryTime = nil
+		status.ScalingBeginTime = metav1.Now()
+	}
+
+	// 计算生效的目标副本数
+	targetReplicas, err := h.effectiveTargetReplicas(ctx)
+	if err != nil {
 		return ctrl.Result{}, err
 	}
 
-	// 检查扩缩容是否完成
-	if isCompleted, err := h.isScalingCompleted(ctx); err != nil {
+	// 使用策略进行扩缩容并检查是否完成
+	stepWait, isCompleted, err := h.scaleAndCheck(ctx, targetReplicas)
+	if err != nil {
 		return ctrl.Result{}, err
 	} else if isCompleted {
 		// 解析持续时间
@@ -277,71 +821,211 @@ func (h *ScalingHandler) Handle(ctx *types.ScaleContext) (ctrl.Result, error) {
 			return ctrl.Result{}, err
 		}
 		// 更新状态
-		status := &ctx.AlertScale.Status.ScaleStatus
-		status.Status = types.ScaleStatusScaled
 		status.ScaleBeginTime = metav1.Now()
 		status.ScaleEndTime = metav1.NewTime(status.ScaleBeginTime.Add(duration))
+		h.transitionTo(ctx, types.ScaleStatusScaled, types.ReasonScaleUpComplete,
+			fmt.Sprintf("Scaled to %s until %s", scaleGoal(ctx.AlertScale, targetReplicas), status.ScaleEndTime.Format(time.RFC3339)))
 
 		// 发送扩缩容完成通知
 		h.sendNotification(ctx, "scaled")
 	}
 
-	// 检查是否超时
-	currentScaleBeginTime := ctx.AlertScale.Status.ScaleStatus.ScaleBeginTime
-	timeoutDuration, err := h.parseDuration(ctx.AlertScale.Spec.ScaleTimeout)
-	if err != nil {
-		return ctrl.Result{}, err
-	}
-	if h.isTimeout(currentScaleBeginTime, timeoutDuration) {
-		status := &ctx.AlertScale.Status.ScaleStatus
-		status.Status = types.ScaleStatusFailed
-		status.ScaleEndTime = metav1.Now()
+	// 检查是否超时，超时时间覆盖整个扩容过程（包括分步扩容）
+	if status.Status == types.ScaleStatusScaling {
+		scalingBeginTime := status.ScalingBeginTime
+		if scalingBeginTime.IsZero() {
+			scalingBeginTime = status.ScaleBeginTime
+		}
+		timeoutDuration, err := h.parseDuration(ctx.AlertScale.Spec.ScaleTimeout)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if h.isTimeout(scalingBeginTime, timeoutDuration) {
+			message := fmt.Sprintf("Scaling to %s did not complete within %s", scaleGoal(ctx.AlertScale, targetReplicas), timeoutDuration)
+			if backoff, retry := h.retryBackoff(ctx); retry {
+				// 重新进入 Scaling，退避结束后开始下一次尝试
+				status.Attempts = max(status.Attempts, 1) + 1
+				nextRetryTime := metav1.NewTime(time.Now().Add(backoff))
+				status.NextRetryTime = &nextRetryTime
+				status.ScaleUpProgress = nil
+				h.transitionTo(ctx, types.ScaleStatusScaling, types.ReasonScaleRetry,
+					fmt.Sprintf("%s, retrying in %s (attempt %d/%d)", message, backoff, status.Attempts, ctx.AlertScale.Spec.RetryPolicy.MaxAttempts))
+			} else {
+				status.ScaleEndTime = metav1.Now()
+				h.transitionTo(ctx, types.ScaleStatusFailed, types.ReasonScaleTimeout, message)
+			}
+		}
 	}
 
-	// 更新扩缩容后的副本数
-	if availableReplicas, err := ctx.ScaleStrategy.GetAvailableReplicas(
-		ctx.Context,
-		ctx.Client,
-		&ctx.AlertScale.Spec.ScaleTarget,
-	); err != nil {
-		log.Error(err, "failed to get available replicas")
-	} else {
-		ctx.AlertScale.Status.ScaleStatus.ScaledReplicas = availableReplicas
+	// 更新扩缩容后的副本数，多目标 AlertScale 已在 scaleGroup 中汇总
+	if !types.IsGroup(ctx.AlertScale) {
+		if availableReplicas, err := ctx.ScaleStrategy.GetAvailableReplicas(
+			ctx.Context,
+			ctx.Client,
+			&ctx.AlertScale.Spec.ScaleTarget,
+		); err != nil {
+			log.Error(err, "failed to get available replicas")
+		} else {
+			ctx.AlertScale.Status.ScaleStatus.ScaledReplicas = availableReplicas
+		}
 	}
 
 	if err := ctx.Client.Status().Update(ctx.Context, ctx.AlertScale); err != nil {
 		return ctrl.Result{}, err
 	}
 
-	return ctrl.Result{RequeueAfter: time.Second * 10}, nil
+	switch {
+	case status.Status == types.ScaleStatusFailed:
+		// 失败通知只在进入 Failed 时发送一次
+		h.sendNotification(ctx, "failed")
+		return ctrl.Result{Requeue: true}, nil
+	case status.NextRetryTime != nil:
+		return ctrl.Result{RequeueAfter: time.Until(status.NextRetryTime.Time)}, nil
+	}
+
+	requeueAfter := time.Second * 10
+	if stepWait > 0 && stepWait < requeueAfter {
+		requeueAfter = stepWait
+	}
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// retryBackoff 返回扩容超时后下一次尝试前的等待时间，每次重试翻倍，没有剩余尝试次数时返回 false
+func (h *ScalingHandler) retryBackoff(ctx *types.ScaleContext) (time.Duration, bool) {
+	policy := ctx.AlertScale.Spec.RetryPolicy
+	attempts := max(ctx.AlertScale.Status.ScaleStatus.Attempts, 1)
+	if policy == nil || attempts >= policy.MaxAttempts {
+		return 0, false
+	}
+
+	backoff := defaultRetryBackoff
+	if policy.Backoff != "" {
+		if parsed, err := h.parseDuration(policy.Backoff); err != nil {
+			logf.FromContext(ctx.Context).Error(err, "Invalid retry backoff, using default", "backoff", policy.Backoff)
+		} else {
+			backoff = parsed
+		}
+	}
+	for i := int32(1); i < attempts && backoff < maxRetryBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, maxRetryBackoff), true
 }
 
 func (h *ScalingHandler) CanTransition(toState string) bool {
-	return toState == types.ScaleStatusScaled || toState == types.ScaleStatusFailed
+	return toState == types.ScaleStatusScaling || toState == types.ScaleStatusScaled || toState == types.ScaleStatusFailed
+}
+
+// effectiveTargetReplicas 返回当前 AlertScale 与同一工作负载上其他生效 AlertScale 中最大的目标副本数
+func (h *BaseStateHandler) effectiveTargetReplicas(ctx *types.ScaleContext) (int32, error) {
+	targetReplicas := h.targetReplicas(ctx)
+
+	holders, err := h.overlappingScales(ctx, holdingStatuses)
+	if err != nil {
+		return 0, err
+	}
+	ctx.AlertScale.Status.ScaleStatus.Overlaps = scaleRefs(holders)
+	if len(holders) > 0 {
+		targetReplicas = max(targetReplicas, maxTargetReplicas(holders))
+	}
+	return targetReplicas, nil
 }
 
-func (h *ScalingHandler) scaleIfNeeded(ctx *types.ScaleContext) error {
+// scaleAndCheck 调整工作负载并检查扩容是否完成，返回距下一步需要等待的时长；
+// 纵向扩缩容时调整容器资源并等待滚动更新完成，多目标 AlertScale 调整所有成员
+func (h *ScalingHandler) scaleAndCheck(ctx *types.ScaleContext, targetReplicas int32) (time.Duration, bool, error) {
+	if types.IsGroup(ctx.AlertScale) {
+		completed, err := h.scaleGroup(ctx)
+		return 0, completed, err
+	}
+	if types.IsVertical(ctx.AlertScale) {
+		completed, err := h.applyVerticalScale(ctx)
+		return 0, completed, err
+	}
+
+	stepWait, err := h.scaleIfNeeded(ctx, targetReplicas)
+	if err != nil {
+		return 0, false, err
+	}
+	completed, err := h.isScalingCompleted(ctx, targetReplicas)
+	return stepWait, completed, err
+}
+
+// scaleIfNeeded 将副本数调整到目标值（分步扩容时调整到下一步），返回距下一步需要等待的时长
+func (h *ScalingHandler) scaleIfNeeded(ctx *types.ScaleContext, targetReplicas int32) (time.Duration, error) {
 	currentReplicas, err := ctx.ScaleStrategy.GetCurrentReplicas(
 		ctx.Context,
 		ctx.Client,
 		&ctx.AlertScale.Spec.ScaleTarget,
 	)
 	if err != nil {
-		return err
+		return 0, err
 	}
 
-	if currentReplicas != ctx.AlertScale.Spec.ScaleThreshold {
-		return ctx.ScaleStrategy.Scale(
+	desiredReplicas := targetReplicas
+	var stepWait time.Duration
+	if policy := ctx.AlertScale.Spec.ScaleUpPolicy; policy != nil && desiredReplicas > currentReplicas {
+		status := &ctx.AlertScale.Status.ScaleStatus
+		if status.ScaleUpProgress == nil {
+			status.ScaleUpProgress = newStepProgress(policy, status.OriginReplicas, desiredReplicas)
+		}
+		if desiredReplicas, stepWait, err = h.nextStep(ctx, policy, status.ScaleUpProgress, currentReplicas, desiredReplicas); err != nil {
+			return 0, err
+		}
+	}
+
+	// 目标存在 HPA 时先提升其副本数边界，避免 HPA 回滚扩容结果
+	if err := h.raiseHPAIfPresent(ctx, desiredReplicas); err != nil {
+		return 0, err
+	}
+
+	if currentReplicas != desiredReplicas {
+		return stepWait, ctx.ScaleStrategy.Scale(
 			ctx.Context,
 			ctx.Client,
 			&ctx.AlertScale.Spec.ScaleTarget,
-			ctx.AlertScale.Spec.ScaleThreshold,
+			desiredReplicas,
 		)
 	}
-	return nil
+	return stepWait, nil
+}
+
+func (h *ScalingHandler) raiseHPAIfPresent(ctx *types.ScaleContext, replicas int32) error {
+	hpa, err := strategy.FindHPA(ctx.Context, ctx.Client, &ctx.AlertScale.Spec.ScaleTarget)
+	if err != nil || hpa == nil {
+		return err
+	}
+
+	// 试运行时只记录计划，不快照也不修改 HPA
+	if ctx.AlertScale.Spec.DryRun {
+		types.RecordPlannedTargetAction(ctx.AlertScale, types.TargetRef(&ctx.AlertScale.Spec.ScaleTarget), types.PlannedActionRaiseHPA, replicas,
+			fmt.Sprintf("Raise the replica bounds of HPA %s to at least %d", hpa.Name, replicas))
+		return nil
+	}
+
+	// 修改 HPA 前先持久化原始边界，保证控制器重启后仍能恢复
+	// 同一工作负载上已有生效的 AlertScale 时 HPA 已被提升，继承其记录的原始边界
+	if ctx.AlertScale.Status.OriginHPA == nil {
+		ctx.AlertScale.Status.OriginHPA = strategy.SnapshotHPA(hpa)
+		overlapping, err := h.overlappingScales(ctx, inheritableStatuses)
+		if err != nil {
+			return err
+		}
+		for _, other := range overlapping {
+			if other.Status.OriginHPA != nil {
+				ctx.AlertScale.Status.OriginHPA = other.Status.OriginHPA.DeepCopy()
+				break
+			}
+		}
+		if err := ctx.Client.Status().Update(ctx.Context, ctx.AlertScale); err != nil {
+			return err
+		}
+	}
+
+	return strategy.RaiseHPABounds(ctx.Context, ctx.Client, hpa, replicas)
 }
 
-func (h *ScalingHandler) isScalingCompleted(ctx *types.ScaleContext) (bool, error) {
+func (h *ScalingHandler) isScalingCompleted(ctx *types.ScaleContext, targetReplicas int32) (bool, error) {
 	availableReplicas, err := ctx.ScaleStrategy.GetAvailableReplicas(
 		ctx.Context,
 		ctx.Client,
@@ -351,7 +1035,7 @@ func (h *ScalingHandler) isScalingCompleted(ctx *types.ScaleContext) (bool, erro
 		return false, err
 	}
 
-	return availableReplicas == ctx.AlertScale.Spec.ScaleThreshold, nil
+	return availableReplicas == targetReplicas, nil
 }
 
 // ScaledHandler 处理 Scaled 状态
@@ -363,11 +1047,24 @@ func (h *ScaledHandler) Handle(ctx *types.ScaleContext) (ctrl.Result, error) {
 	log := logf.FromContext(ctx.Context)
 	log.Info("Handling Scaled state", "alertScale", ctx.AlertScale.Name)
 
+	// 处理延长/取消操作
+	if result, err := h.processScaleAction(ctx, true); result != nil {
+		return *result, err
+	}
+
 	status := &ctx.AlertScale.Status.ScaleStatus
 
-	// 检查是否到达结束时间
-	if status.ScaleEndTime.Time.Before(time.Now()) {
-		status.Status = types.ScaleStatusCompleted
+	// 检查是否到达结束时间，或告警已恢复需要提前结束
+	if status.ScaleEndTime.Time.Before(time.Now()) || h.isAlertResolved(ctx) {
+		restoring := "restoring original replicas"
+		if types.IsVertical(ctx.AlertScale) {
+			restoring = "restoring original resources"
+		}
+		if h.isAlertResolved(ctx) {
+			h.transitionTo(ctx, types.ScaleStatusCompleted, types.ReasonAlertResolved, "Alert resolved, "+restoring)
+		} else {
+			h.transitionTo(ctx, types.ScaleStatusCompleted, types.ReasonDurationElapsed, "Scale duration elapsed, "+restoring)
+		}
 		if err := ctx.Client.Status().Update(ctx.Context, ctx.AlertScale); err != nil {
 			return ctrl.Result{}, err
 		}
@@ -378,16 +1075,124 @@ func (h *ScaledHandler) Handle(ctx *types.ScaleContext) (ctrl.Result, error) {
 		return ctrl.Result{Requeue: true}, nil
 	}
 
-	// 等待到结束时间
+	// 检查副本数是否被外部修改，纵向扩缩容不持有副本数，多目标 AlertScale 不检测漂移
+	if !types.IsVertical(ctx.AlertScale) && !types.IsGroup(ctx.AlertScale) {
+		if result, err := h.checkDrift(ctx); result != nil {
+			return *result, err
+		}
+	}
+
+	// 等待到结束时间，期间定期检查副本数漂移
 	if !status.ScaleEndTime.IsZero() && status.ScaleEndTime.After(time.Now()) {
-		return ctrl.Result{RequeueAfter: time.Until(status.ScaleEndTime.Time)}, nil
+		return ctrl.Result{RequeueAfter: min(time.Until(status.ScaleEndTime.Time), driftCheckInterval)}, nil
 	}
 
 	return ctrl.Result{Requeue: true}, nil
 }
 
 func (h *ScaledHandler) CanTransition(toState string) bool {
-	return toState == types.ScaleStatusCompleted
+	return toState == types.ScaleStatusCompleted || toState == types.ScaleStatusFailed
+}
+
+// checkDrift 比较实际副本数与扩容目标，发生漂移时按策略处理并记录
+func (h *ScaledHandler) checkDrift(ctx *types.ScaleContext) (*ctrl.Result, error) {
+	log := logf.FromContext(ctx.Context)
+	status := &ctx.AlertScale.Status.ScaleStatus
+
+	expectedReplicas, err := h.effectiveTargetReplicas(ctx)
+	if err != nil {
+		return &ctrl.Result{}, err
+	}
+	currentReplicas, err := ctx.ScaleStrategy.GetCurrentReplicas(
+		ctx.Context,
+		ctx.Client,
+		&ctx.AlertScale.Spec.ScaleTarget,
+	)
+	if err != nil {
+		return &ctrl.Result{}, err
+	}
+
+	// HPA 在提升后的下限之上继续扩容不属于漂移
+	if currentReplicas == expectedReplicas || currentReplicas > expectedReplicas && ctx.AlertScale.Status.OriginHPA != nil {
+		return nil, nil
+	}
+
+	action := ctx.AlertScale.Spec.DriftPolicy
+	if action == "" {
+		action = types.DriftPolicyReassert
+	}
+
+	// 更高目标的 AlertScale 持有副本数时由其漂移策略处理，采纳只会改写自身目标而无法消除漂移
+	if action == types.DriftPolicyAdopt && expectedReplicas > h.targetReplicas(ctx) {
+		return nil, nil
+	}
+
+	// 同一观测值的漂移只记录和通知一次，避免每个检查周期重复告警
+	repeated := isRepeatedDrift(status, expectedReplicas, currentReplicas, action)
+	log.Info("Replica drift detected", "alertScale", ctx.AlertScale.Name,
+		"expected", expectedReplicas, "observed", currentReplicas, "action", action, "repeated", repeated)
+
+	if !repeated {
+		recordDriftEvent(status, opsv1beta1.DriftEvent{
+			Time:             metav1.Now(),
+			ExpectedReplicas: expectedReplicas,
+			ObservedReplicas: currentReplicas,
+			Action:           action,
+		})
+	}
+	status.Message = fmt.Sprintf("replica drift detected: expected %d, observed %d, action %s",
+		expectedReplicas, currentReplicas, action)
+	if action != types.DriftPolicyAbort && !repeated {
+		h.recordEvent(ctx, corev1.EventTypeWarning, types.ReasonReplicaDrift, status.Message)
+	}
+
+	switch action {
+	case types.DriftPolicyAdopt:
+		status.TargetReplicas = &currentReplicas
+	case types.DriftPolicyAbort:
+		status.ScaleEndTime = metav1.Now()
+		h.transitionTo(ctx, types.ScaleStatusFailed, types.ReasonReplicaDrift, status.Message)
+	default:
+		if err := ctx.ScaleStrategy.Scale(
+			ctx.Context,
+			ctx.Client,
+			&ctx.AlertScale.Spec.ScaleTarget,
+			expectedReplicas,
+		); err != nil {
+			return &ctrl.Result{}, err
+		}
+	}
+
+	if err := ctx.Client.Status().Update(ctx.Context, ctx.AlertScale); err != nil {
+		return &ctrl.Result{}, err
+	}
+
+	if !repeated {
+		h.sendNotification(ctx, "drifted")
+	}
+
+	if action == types.DriftPolicyAbort {
+		h.sendNotification(ctx, "failed")
+		return &ctrl.Result{Requeue: true}, nil
+	}
+	return &ctrl.Result{RequeueAfter: driftCheckInterval}, nil
+}
+
+// isRepeatedDrift 判断本次漂移是否与最近一次记录的漂移相同
+func isRepeatedDrift(status *opsv1beta1.ScaleStatus, expectedReplicas, observedReplicas int32, action string) bool {
+	if len(status.DriftEvents) == 0 {
+		return false
+	}
+	last := status.DriftEvents[len(status.DriftEvents)-1]
+	return last.ExpectedReplicas == expectedReplicas && last.ObservedReplicas == observedReplicas && last.Action == action
+}
+
+// recordDriftEvent 记录漂移事件，只保留最近的 maxDriftEvents 条
+func recordDriftEvent(status *opsv1beta1.ScaleStatus, event opsv1beta1.DriftEvent) {
+	status.DriftEvents = append(status.DriftEvents, event)
+	if len(status.DriftEvents) > maxDriftEvents {
+		status.DriftEvents = status.DriftEvents[len(status.DriftEvents)-maxDriftEvents:]
+	}
 }
 
 // CompletedHandler 处理 Completed 状态
@@ -399,8 +1204,52 @@ func (h *CompletedHandler) Handle(ctx *types.ScaleContext) (ctrl.Result, error)
 	log := logf.FromContext(ctx.Context)
 	log.Info("Handling Completed state", "alertScale", ctx.AlertScale.Name)
 
+	// 纵向扩缩容恢复原始资源，滚动更新完成后归档
+	if types.IsVertical(ctx.AlertScale) {
+		restored, holders, err := h.restoreVertical(ctx)
+		if err != nil {
+			metrics.ObserveRestoreFailure(ctx.AlertScale)
+			return ctrl.Result{}, err
+		} else if !restored {
+			return ctrl.Result{RequeueAfter: time.Second * 5}, nil
+		}
+		return h.archive(ctx, holders)
+	}
+
+	// 多目标 AlertScale 恢复所有成员，任一成员失败时回滚其余成员并重试
+	if types.IsGroup(ctx.AlertScale) {
+		restored, err := h.restoreGroup(ctx, true)
+		if err != nil {
+			metrics.ObserveRestoreFailure(ctx.AlertScale)
+			return ctrl.Result{}, err
+		} else if !restored {
+			return ctrl.Result{RequeueAfter: time.Second * 5}, nil
+		}
+		return h.archive(ctx, nil)
+	}
+
 	status := &ctx.AlertScale.Status.ScaleStatus
 
+	// 同一工作负载上仍有其他生效的 AlertScale 时，只恢复到它们的最大目标副本数
+	restoreReplicas, holders, err := h.restoreTarget(ctx)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// 存在 HPA 时先按缩容策略逐步降低 minReplicas，再恢复其原始边界，副本数交还 HPA 管理
+	if requeueAfter, err := h.stepDownHPA(ctx, holders); err != nil {
+		metrics.ObserveRestoreFailure(ctx.AlertScale)
+		return ctrl.Result{}, err
+	} else if requeueAfter > 0 {
+		return ctrl.Result{RequeueAfter: requeueAfter}, nil
+	}
+	if hasHPA, err := h.restoreHPA(ctx, holders); err != nil {
+		metrics.ObserveRestoreFailure(ctx.AlertScale)
+		return ctrl.Result{}, err
+	} else if hasHPA {
+		return h.archive(ctx, holders)
+	}
+
 	// 检查是否已恢复到原始副本数
 	currentReplicas, err := ctx.ScaleStrategy.GetCurrentReplicas(
 		ctx.Context,
@@ -411,29 +1260,109 @@ func (h *CompletedHandler) Handle(ctx *types.ScaleContext) (ctrl.Result, error)
 		return ctrl.Result{}, err
 	}
 
-	if currentReplicas == status.OriginReplicas {
-		status.Status = types.ScaleStatusArchived
-		if err := ctx.Client.Status().Update(ctx.Context, ctx.AlertScale); err != nil {
+	if currentReplicas == restoreReplicas {
+		if converged, err := h.isRestoreConverged(ctx, restoreReplicas); err != nil {
 			return ctrl.Result{}, err
+		} else if !converged {
+			return ctrl.Result{RequeueAfter: time.Second * 5}, nil
 		}
 
-		// 发送归档通知
-		h.sendNotification(ctx, "archived")
+		return h.archive(ctx, holders)
+	}
 
-		return ctrl.Result{Requeue: true}, nil
+	// 恢复原始副本数，配置了缩容策略时分步缩容
+	desiredReplicas := restoreReplicas
+	requeueAfter := time.Second * 5
+	if policy := ctx.AlertScale.Spec.ScaleDownPolicy; policy != nil && desiredReplicas < currentReplicas {
+		if policy.Paused {
+			log.Info("Restore paused by scale-down policy", "alertScale", ctx.AlertScale.Name)
+			return ctrl.Result{RequeueAfter: time.Second * 30}, nil
+		}
+
+		if status.ScaleDownProgress == nil {
+			status.ScaleDownProgress = newStepProgress(&policy.StepPolicy, currentReplicas, desiredReplicas)
+		}
+		stepWait := time.Duration(0)
+		if desiredReplicas, stepWait, err = h.nextStep(ctx, &policy.StepPolicy, status.ScaleDownProgress, currentReplicas, desiredReplicas); err != nil {
+			return ctrl.Result{}, err
+		}
+		if stepWait > 0 && stepWait < requeueAfter {
+			requeueAfter = stepWait
+		}
+	}
+
+	if desiredReplicas != currentReplicas {
+		if err := ctx.ScaleStrategy.Scale(
+			ctx.Context,
+			ctx.Client,
+			&ctx.AlertScale.Spec.ScaleTarget,
+			desiredReplicas,
+		); err != nil {
+			metrics.ObserveRestoreFailure(ctx.AlertScale)
+			return ctrl.Result{}, err
+		}
 	}
 
-	// 恢复原始副本数
-	if err := ctx.ScaleStrategy.Scale(
+	// 更新恢复进度
+	if availableReplicas, err := ctx.ScaleStrategy.GetAvailableReplicas(
 		ctx.Context,
 		ctx.Client,
 		&ctx.AlertScale.Spec.ScaleTarget,
-		status.OriginReplicas,
 	); err != nil {
+		log.Error(err, "failed to get available replicas")
+	} else {
+		status.ScaledReplicas = availableReplicas
+	}
+	if err := ctx.Client.Status().Update(ctx.Context, ctx.AlertScale); err != nil {
 		return ctrl.Result{}, err
 	}
 
-	return ctrl.Result{RequeueAfter: time.Second * 5}, nil
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// archive 归档 AlertScale 并发送归档通知
+func (h *CompletedHandler) archive(ctx *types.ScaleContext, holders []opsv1beta1.AlertScale) (ctrl.Result, error) {
+	if err := h.archiveRestored(ctx, holders); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// 发送归档通知
+	h.sendNotification(ctx, "archived")
+
+	return ctrl.Result{Requeue: true}, nil
+}
+
+// archiveRestored 恢复完成后归档 AlertScale，仍有其他生效的 AlertScale 时记录恢复职责的移交对象
+func (h *BaseStateHandler) archiveRestored(ctx *types.ScaleContext, holders []opsv1beta1.AlertScale) error {
+	status := &ctx.AlertScale.Status.ScaleStatus
+	if len(holders) > 0 {
+		status.Message = fmt.Sprintf("restore handed off to %s", strings.Join(scaleRefs(holders), ", "))
+		h.transitionTo(ctx, types.ScaleStatusArchived, types.ReasonRestoreHandedOff, status.Message)
+	} else if types.IsVertical(ctx.AlertScale) {
+		h.transitionTo(ctx, types.ScaleStatusArchived, types.ReasonRestored,
+			"Restored original resources of containers "+verticalContainerNames(ctx.AlertScale))
+	} else if types.IsGroup(ctx.AlertScale) {
+		h.transitionTo(ctx, types.ScaleStatusArchived, types.ReasonRestored,
+			fmt.Sprintf("Restored %d targets to %d replicas", len(ctx.AlertScale.Status.Targets), status.OriginReplicas))
+	} else {
+		h.transitionTo(ctx, types.ScaleStatusArchived, types.ReasonRestored,
+			fmt.Sprintf("Restored to %d replicas", status.OriginReplicas))
+	}
+	return ctx.Client.Status().Update(ctx.Context, ctx.AlertScale)
+}
+
+// isRestoreConverged 检查多余的副本是否已全部退出
+func (h *BaseStateHandler) isRestoreConverged(ctx *types.ScaleContext, restoreReplicas int32) (bool, error) {
+	availableReplicas, err := ctx.ScaleStrategy.GetAvailableReplicas(
+		ctx.Context,
+		ctx.Client,
+		&ctx.AlertScale.Spec.ScaleTarget,
+	)
+	if err != nil {
+		return false, err
+	}
+	ctx.AlertScale.Status.ScaleStatus.ScaledReplicas = availableReplicas
+	return availableReplicas <= restoreReplicas, nil
 }
 
 func (h *CompletedHandler) CanTransition(toState string) bool {
@@ -445,15 +1374,50 @@ type FailedHandler struct {
 	BaseStateHandler
 }
 
+// Failed 为终态：失败通知在进入 Failed 时已发送，这里只负责恢复工作负载，恢复完成后归档
 func (h *FailedHandler) Handle(ctx *types.ScaleContext) (ctrl.Result, error) {
 	log := logf.FromContext(ctx.Context)
 	log.Info("Handling Failed state", "alertScale", ctx.AlertScale.Name)
 
-	// 发送失败通知
-	h.sendNotification(ctx, "failed")
+	if types.IsVertical(ctx.AlertScale) {
+		restored, holders, err := h.restoreVertical(ctx)
+		if err != nil {
+			metrics.ObserveRestoreFailure(ctx.AlertScale)
+			return ctrl.Result{}, err
+		} else if !restored {
+			return ctrl.Result{RequeueAfter: time.Second * 5}, nil
+		}
+		return ctrl.Result{}, h.archiveRestored(ctx, holders)
+	}
+
+	// 多目标 AlertScale 将所有成员回滚到原始副本数
+	if types.IsGroup(ctx.AlertScale) {
+		restored, err := h.restoreGroup(ctx, false)
+		if err != nil {
+			metrics.ObserveRestoreFailure(ctx.AlertScale)
+			return ctrl.Result{}, err
+		} else if !restored {
+			return ctrl.Result{RequeueAfter: time.Second * 5}, nil
+		}
+		return ctrl.Result{}, h.archiveRestored(ctx, nil)
+	}
+
+	// 同一工作负载上仍有其他生效的 AlertScale 时，只恢复到它们的最大目标副本数
+	restoreReplicas, holders, err := h.restoreTarget(ctx)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// 存在 HPA 时恢复其原始边界，副本数交还 HPA 管理
+	if hasHPA, err := h.restoreHPA(ctx, holders); err != nil {
+		metrics.ObserveRestoreFailure(ctx.AlertScale)
+		return ctrl.Result{}, err
+	} else if hasHPA {
+		return ctrl.Result{}, h.archiveRestored(ctx, holders)
+	}
 
 	// 如果副本数 和原始副本数不一致，恢复原始副本数
-	availableReplicas, err := ctx.ScaleStrategy.GetAvailableReplicas(
+	currentReplicas, err := ctx.ScaleStrategy.GetCurrentReplicas(
 		ctx.Context,
 		ctx.Client,
 		&ctx.AlertScale.Spec.ScaleTarget,
@@ -462,22 +1426,31 @@ func (h *FailedHandler) Handle(ctx *types.ScaleContext) (ctrl.Result, error) {
 		return ctrl.Result{}, err
 	}
 
-	if availableReplicas != ctx.AlertScale.Status.ScaleStatus.OriginReplicas {
+	if currentReplicas != restoreReplicas {
 		if err := ctx.ScaleStrategy.Scale(
 			ctx.Context,
 			ctx.Client,
 			&ctx.AlertScale.Spec.ScaleTarget,
-			ctx.AlertScale.Status.ScaleStatus.OriginReplicas,
+			restoreReplicas,
 		); err != nil {
+			metrics.ObserveRestoreFailure(ctx.AlertScale)
 			return ctrl.Result{}, err
 		}
+		return ctrl.Result{RequeueAfter: time.Second * 5}, nil
 	}
 
-	return ctrl.Result{}, nil
+	// 多余的副本全部退出后归档
+	if converged, err := h.isRestoreConverged(ctx, restoreReplicas); err != nil {
+		return ctrl.Result{}, err
+	} else if !converged {
+		return ctrl.Result{RequeueAfter: time.Second * 5}, nil
+	}
+
+	return ctrl.Result{}, h.archiveRestored(ctx, holders)
 }
 
 func (h *FailedHandler) CanTransition(toState string) bool {
-	return false
+	return toState == types.ScaleStatusArchived
 }
 
 // ArchivedHandler 处理 Archived 状态
@@ -505,12 +1478,13 @@ func (h *DefaultHandler) Handle(ctx *types.ScaleContext) (ctrl.Result, error) {
 	log.Info("Initializing AlertScale status", "alertScale", ctx.AlertScale.Name)
 
 	ctx.AlertScale.Status.ScaleStatus = opsv1beta1.ScaleStatus{
-		Status:         types.ScaleStatusPending,
 		ScaleBeginTime: metav1.Now(), // 设置开始时间为当前时间，初始化时开始计算scale超时时间
 		ScaleEndTime:   metav1.Time{},
 		OriginReplicas: 0,
 		ScaledReplicas: 0,
 	}
+	requester := requestedBy(ctx.AlertScale)
+	h.transitionBy(ctx, requester, types.ScaleStatusPending, types.ReasonCreated, fmt.Sprintf("AlertScale created by %s", requester))
 
 	if err := ctx.Client.Status().Update(ctx.Context, ctx.AlertScale); err != nil {
 		return ctrl.Result{}, err
@@ -17,12 +17,28 @@ limitations under the License.
 package handler
 
 import (
+	"context"
+	"errors"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	appv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	opsv1beta1 "udesk.cn/ops/api/v1beta1"
+	"udesk.cn/ops/constants"
+	"udesk.cn/ops/internal/strategy"
+	"udesk.cn/ops/internal/types"
 )
 
 var _ = Describe("Scale State Handler", func() {
@@ -119,4 +135,1978 @@ var _ = Describe("Scale State Handler", func() {
 			})
 		})
 	})
+
+	Describe("ScalingHandler with step policy", func() {
+		var (
+			handler     *ScalingHandler
+			alertScale  *opsv1beta1.AlertScale
+			fakeClient  client.Client
+			workload    *fakeWorkload
+			scaleCtx    *types.ScaleContext
+			targetCount int32
+		)
+
+		BeforeEach(func() {
+			handler = &ScalingHandler{}
+			targetCount = 10
+			workload = &fakeWorkload{replicas: 2, available: 2}
+
+			alertScale = &opsv1beta1.AlertScale{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "step-scale",
+					Namespace: "default",
+				},
+				Spec: opsv1beta1.AlertScaleSpec{
+					ScaleReason:  "Traffic spike",
+					ScaleTimeout: "10m",
+					ScaleTarget: opsv1beta1.ScaleTarget{
+						Kind:      "Deployment",
+						Name:      "web-app",
+						Namespace: "default",
+					},
+					ScaleUpPolicy: &opsv1beta1.StepPolicy{
+						ReplicasPerStep: 3,
+						StepInterval:    "1m",
+						WaitForReady:    true,
+					},
+				},
+				Status: opsv1beta1.AlertScaleStatus{
+					ScaleStatus: opsv1beta1.ScaleStatus{
+						Status:           types.ScaleStatusScaling,
+						OriginReplicas:   2,
+						TargetReplicas:   &targetCount,
+						ScalingBeginTime: metav1.Now(),
+					},
+				},
+			}
+
+			scheme := runtime.NewScheme()
+			Expect(opsv1beta1.AddToScheme(scheme)).To(Succeed())
+			Expect(autoscalingv2.AddToScheme(scheme)).To(Succeed())
+			fakeClient = fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(alertScale).
+				WithStatusSubresource(alertScale).
+				Build()
+
+			scaleCtx = &types.ScaleContext{
+				AlertScale:    alertScale,
+				Client:        fakeClient,
+				Request:       ctrl.Request{NamespacedName: client.ObjectKeyFromObject(alertScale)},
+				Context:       context.Background(),
+				ScaleStrategy: workload,
+			}
+		})
+
+		It("should apply the first step and plan the remaining steps", func() {
+			result, err := handler.Handle(scaleCtx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(BeNumerically(">", 0))
+
+			Expect(workload.replicas).To(Equal(int32(5)))
+			progress := alertScale.Status.ScaleStatus.ScaleUpProgress
+			Expect(progress).NotTo(BeNil())
+			Expect(progress.CurrentStep).To(Equal(int32(1)))
+			Expect(progress.TotalSteps).To(Equal(int32(3)))
+			Expect(alertScale.Status.ScaleStatus.Status).To(Equal(types.ScaleStatusScaling))
+		})
+
+		It("should wait for the step interval before the next step", func() {
+			_, err := handler.Handle(scaleCtx)
+			Expect(err).NotTo(HaveOccurred())
+			workload.available = workload.replicas
+
+			_, err = handler.Handle(scaleCtx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(workload.replicas).To(Equal(int32(5)))
+			Expect(alertScale.Status.ScaleStatus.ScaleUpProgress.CurrentStep).To(Equal(int32(1)))
+		})
+
+		It("should wait for ready replicas before the next step", func() {
+			alertScale.Spec.ScaleUpPolicy.StepInterval = ""
+			Expect(fakeClient.Update(context.Background(), alertScale)).To(Succeed())
+			_, err := handler.Handle(scaleCtx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(workload.replicas).To(Equal(int32(5)))
+
+			_, err = handler.Handle(scaleCtx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(workload.replicas).To(Equal(int32(5)))
+
+			workload.available = workload.replicas
+			_, err = handler.Handle(scaleCtx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(workload.replicas).To(Equal(int32(8)))
+		})
+
+		It("should reach Scaled after the last step is ready", func() {
+			alertScale.Spec.ScaleUpPolicy.StepInterval = ""
+			Expect(fakeClient.Update(context.Background(), alertScale)).To(Succeed())
+			for i := 0; i < 3; i++ {
+				_, err := handler.Handle(scaleCtx)
+				Expect(err).NotTo(HaveOccurred())
+				workload.available = workload.replicas
+			}
+
+			_, err := handler.Handle(scaleCtx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(workload.replicas).To(Equal(int32(10)))
+			Expect(alertScale.Status.ScaleStatus.Status).To(Equal(types.ScaleStatusScaled))
+			Expect(alertScale.Status.ScaleStatus.ScaledReplicas).To(Equal(int32(10)))
+		})
+
+		It("should fail when the whole ramp exceeds the scale timeout", func() {
+			alertScale.Status.ScaleStatus.ScalingBeginTime = metav1.NewTime(time.Now().Add(-time.Hour))
+
+			_, err := handler.Handle(scaleCtx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(alertScale.Status.ScaleStatus.Status).To(Equal(types.ScaleStatusFailed))
+		})
+	})
+
+	Describe("CompletedHandler with scale-down policy", func() {
+		var (
+			handler    *CompletedHandler
+			alertScale *opsv1beta1.AlertScale
+			fakeClient client.Client
+			workload   *fakeWorkload
+			scaleCtx   *types.ScaleContext
+		)
+
+		BeforeEach(func() {
+			handler = &CompletedHandler{}
+			workload = &fakeWorkload{replicas: 10, available: 10}
+
+			alertScale = &opsv1beta1.AlertScale{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "restore-scale",
+					Namespace: "default",
+				},
+				Spec: opsv1beta1.AlertScaleSpec{
+					ScaleReason: "Traffic spike",
+					ScaleTarget: opsv1beta1.ScaleTarget{
+						Kind:      "Deployment",
+						Name:      "web-app",
+						Namespace: "default",
+					},
+					ScaleDownPolicy: &opsv1beta1.ScaleDownPolicy{
+						StepPolicy: opsv1beta1.StepPolicy{
+							ReplicasPerStep: 4,
+							WaitForReady:    true,
+						},
+					},
+				},
+				Status: opsv1beta1.AlertScaleStatus{
+					ScaleStatus: opsv1beta1.ScaleStatus{
+						Status:         types.ScaleStatusCompleted,
+						OriginReplicas: 2,
+					},
+				},
+			}
+
+			scheme := runtime.NewScheme()
+			Expect(opsv1beta1.AddToScheme(scheme)).To(Succeed())
+			fakeClient = fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(alertScale).
+				WithStatusSubresource(alertScale).
+				Build()
+
+			scaleCtx = &types.ScaleContext{
+				AlertScale:    alertScale,
+				Client:        fakeClient,
+				Request:       ctrl.Request{NamespacedName: client.ObjectKeyFromObject(alertScale)},
+				Context:       context.Background(),
+				ScaleStrategy: workload,
+			}
+		})
+
+		It("should restore the original replicas in steps", func() {
+			_, err := handler.Handle(scaleCtx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(workload.replicas).To(Equal(int32(6)))
+			progress := alertScale.Status.ScaleStatus.ScaleDownProgress
+			Expect(progress).NotTo(BeNil())
+			Expect(progress.CurrentStep).To(Equal(int32(1)))
+			Expect(progress.TotalSteps).To(Equal(int32(2)))
+
+			// 多余副本未退出前不进入下一步
+			_, err = handler.Handle(scaleCtx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(workload.replicas).To(Equal(int32(6)))
+
+			workload.available = workload.replicas
+			_, err = handler.Handle(scaleCtx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(workload.replicas).To(Equal(int32(2)))
+			Expect(alertScale.Status.ScaleStatus.Status).To(Equal(types.ScaleStatusCompleted))
+		})
+
+		It("should archive only after the restore has converged", func() {
+			workload.replicas = 2
+			workload.available = 5
+
+			_, err := handler.Handle(scaleCtx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(alertScale.Status.ScaleStatus.Status).To(Equal(types.ScaleStatusCompleted))
+
+			workload.available = 2
+			_, err = handler.Handle(scaleCtx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(alertScale.Status.ScaleStatus.Status).To(Equal(types.ScaleStatusArchived))
+		})
+
+		It("should hold the restore while paused", func() {
+			alertScale.Spec.ScaleDownPolicy.Paused = true
+			Expect(fakeClient.Update(context.Background(), alertScale)).To(Succeed())
+
+			result, err := handler.Handle(scaleCtx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(BeNumerically(">", 0))
+			Expect(workload.replicas).To(Equal(int32(10)))
+		})
+
+		It("should lower the HPA minReplicas in steps before restoring its bounds", func() {
+			minReplicas, originMin := int32(10), int32(2)
+			hpa := &autoscalingv2.HorizontalPodAutoscaler{
+				ObjectMeta: metav1.ObjectMeta{Name: "web-app", Namespace: "default"},
+				Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+					ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{Kind: "Deployment", Name: "web-app", APIVersion: "apps/v1"},
+					MinReplicas:    &minReplicas,
+					MaxReplicas:    12,
+				},
+			}
+			alertScale.Status.OriginHPA = &opsv1beta1.HPASnapshot{Name: "web-app", MinReplicas: &originMin, MaxReplicas: 5}
+
+			scheme := runtime.NewScheme()
+			Expect(opsv1beta1.AddToScheme(scheme)).To(Succeed())
+			Expect(autoscalingv2.AddToScheme(scheme)).To(Succeed())
+			scaleCtx.Client = fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(alertScale, hpa).
+				WithStatusSubresource(alertScale).
+				Build()
+			getHPA := func() *autoscalingv2.HorizontalPodAutoscaler {
+				current := &autoscalingv2.HorizontalPodAutoscaler{}
+				Expect(scaleCtx.Client.Get(context.Background(), client.ObjectKeyFromObject(hpa), current)).To(Succeed())
+				return current
+			}
+
+			_, err := handler.Handle(scaleCtx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*getHPA().Spec.MinReplicas).To(Equal(int32(6)))
+			Expect(getHPA().Spec.MaxReplicas).To(Equal(int32(12)))
+			Expect(alertScale.Status.ScaleStatus.Status).To(Equal(types.ScaleStatusCompleted))
+
+			// 多余副本未退出前不进入下一步
+			_, err = handler.Handle(scaleCtx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*getHPA().Spec.MinReplicas).To(Equal(int32(6)))
+
+			workload.available = 6
+			_, err = handler.Handle(scaleCtx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*getHPA().Spec.MinReplicas).To(Equal(int32(2)))
+			Expect(alertScale.Status.ScaleStatus.Status).To(Equal(types.ScaleStatusCompleted))
+
+			workload.available = 2
+			_, err = handler.Handle(scaleCtx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(getHPA().Spec.MaxReplicas).To(Equal(int32(5)))
+			Expect(alertScale.Status.OriginHPA).To(BeNil())
+			Expect(alertScale.Status.ScaleStatus.Status).To(Equal(types.ScaleStatusArchived))
+		})
+	})
+
+	Describe("Overlapping AlertScales on the same workload", func() {
+		var (
+			fakeClient client.Client
+			workload   *fakeWorkload
+			active     *opsv1beta1.AlertScale
+			current    *opsv1beta1.AlertScale
+		)
+
+		newAlertScale := func(name, status string, threshold int32) *opsv1beta1.AlertScale {
+			return &opsv1beta1.AlertScale{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: "default",
+				},
+				Spec: opsv1beta1.AlertScaleSpec{
+					ScaleReason:    "Traffic spike",
+					ScaleThreshold: threshold,
+					ScaleTimeout:   "10m",
+					ScaleTarget: opsv1beta1.ScaleTarget{
+						Kind:      "Deployment",
+						Name:      "web-app",
+						Namespace: "default",
+					},
+				},
+				Status: opsv1beta1.AlertScaleStatus{
+					ScaleStatus: opsv1beta1.ScaleStatus{
+						Status:           status,
+						OriginReplicas:   2,
+						ScalingBeginTime: metav1.Now(),
+					},
+				},
+			}
+		}
+
+		buildContext := func() *types.ScaleContext {
+			scheme := runtime.NewScheme()
+			Expect(opsv1beta1.AddToScheme(scheme)).To(Succeed())
+			Expect(autoscalingv2.AddToScheme(scheme)).To(Succeed())
+			fakeClient = fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(active, current).
+				WithStatusSubresource(active, current).
+				Build()
+
+			return &types.ScaleContext{
+				AlertScale:    current,
+				Client:        fakeClient,
+				Request:       ctrl.Request{NamespacedName: client.ObjectKeyFromObject(current)},
+				Context:       context.Background(),
+				ScaleStrategy: workload,
+			}
+		}
+
+		BeforeEach(func() {
+			workload = &fakeWorkload{replicas: 10, available: 10}
+			active = newAlertScale("active-scale", types.ScaleStatusScaled, 10)
+		})
+
+		It("should inherit origin replicas from the active AlertScale", func() {
+			current = newAlertScale("new-scale", types.ScaleStatusPending, 6)
+			current.Status.ScaleStatus.OriginReplicas = 0
+
+			_, err := (&PendingHandler{}).Handle(buildContext())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(current.Status.ScaleStatus.OriginReplicas).To(Equal(int32(2)))
+			Expect(*current.Status.ScaleStatus.TargetReplicas).To(Equal(int32(6)))
+		})
+
+		It("should keep the largest desired replicas while scaling", func() {
+			current = newAlertScale("new-scale", types.ScaleStatusScaling, 6)
+
+			_, err := (&ScalingHandler{}).Handle(buildContext())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(workload.replicas).To(Equal(int32(10)))
+			Expect(current.Status.ScaleStatus.Overlaps).To(ConsistOf("default/active-scale"))
+			Expect(current.Status.ScaleStatus.Status).To(Equal(types.ScaleStatusScaled))
+		})
+
+		It("should hand off restore to the remaining active AlertScale", func() {
+			active = newAlertScale("active-scale", types.ScaleStatusScaled, 6)
+			current = newAlertScale("ending-scale", types.ScaleStatusCompleted, 10)
+			scaleCtx := buildContext()
+
+			_, err := (&CompletedHandler{}).Handle(scaleCtx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(workload.replicas).To(Equal(int32(6)))
+
+			workload.available = workload.replicas
+			_, err = (&CompletedHandler{}).Handle(scaleCtx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(current.Status.ScaleStatus.Status).To(Equal(types.ScaleStatusArchived))
+			Expect(current.Status.ScaleStatus.Message).To(ContainSubstring("default/active-scale"))
+		})
+
+		It("should restore the original replicas when the last AlertScale ends", func() {
+			active = newAlertScale("active-scale", types.ScaleStatusArchived, 6)
+			current = newAlertScale("ending-scale", types.ScaleStatusCompleted, 10)
+
+			_, err := (&CompletedHandler{}).Handle(buildContext())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(workload.replicas).To(Equal(int32(2)))
+		})
+
+		It("should ignore dry-run AlertScales", func() {
+			active = newAlertScale("active-scale", types.ScaleStatusScaled, 6)
+			active.Spec.DryRun = true
+			current = newAlertScale("ending-scale", types.ScaleStatusCompleted, 10)
+
+			_, err := (&CompletedHandler{}).Handle(buildContext())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(workload.replicas).To(Equal(int32(2)))
+		})
+	})
+
+	Describe("ScaledHandler drift detection", func() {
+		var (
+			handler    *ScaledHandler
+			alertScale *opsv1beta1.AlertScale
+			workload   *fakeWorkload
+			scaleCtx   *types.ScaleContext
+		)
+
+		buildContext := func(driftPolicy string) {
+			targetReplicas := int32(8)
+			alertScale = &opsv1beta1.AlertScale{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "drift-scale",
+					Namespace: "default",
+				},
+				Spec: opsv1beta1.AlertScaleSpec{
+					ScaleReason: "Traffic spike",
+					DriftPolicy: driftPolicy,
+					ScaleTarget: opsv1beta1.ScaleTarget{
+						Kind:      "Deployment",
+						Name:      "web-app",
+						Namespace: "default",
+					},
+				},
+				Status: opsv1beta1.AlertScaleStatus{
+					ScaleStatus: opsv1beta1.ScaleStatus{
+						Status:         types.ScaleStatusScaled,
+						OriginReplicas: 2,
+						TargetReplicas: &targetReplicas,
+						ScaleEndTime:   metav1.NewTime(time.Now().Add(time.Hour)),
+					},
+				},
+			}
+
+			scheme := runtime.NewScheme()
+			Expect(opsv1beta1.AddToScheme(scheme)).To(Succeed())
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(alertScale).
+				WithStatusSubresource(alertScale).
+				Build()
+
+			scaleCtx = &types.ScaleContext{
+				AlertScale:    alertScale,
+				Client:        fakeClient,
+				Request:       ctrl.Request{NamespacedName: client.ObjectKeyFromObject(alertScale)},
+				Context:       context.Background(),
+				ScaleStrategy: workload,
+			}
+		}
+
+		BeforeEach(func() {
+			handler = &ScaledHandler{}
+			workload = &fakeWorkload{replicas: 8, available: 8}
+		})
+
+		It("should keep waiting when replicas match the target", func() {
+			buildContext("")
+			result, err := handler.Handle(scaleCtx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(driftCheckInterval))
+			Expect(alertScale.Status.ScaleStatus.DriftEvents).To(BeEmpty())
+		})
+
+		It("should re-assert the scaled replicas by default", func() {
+			buildContext("")
+			workload.replicas = 3
+
+			_, err := handler.Handle(scaleCtx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(workload.replicas).To(Equal(int32(8)))
+			Expect(alertScale.Status.ScaleStatus.DriftEvents).To(HaveLen(1))
+			event := alertScale.Status.ScaleStatus.DriftEvents[0]
+			Expect(event.ExpectedReplicas).To(Equal(int32(8)))
+			Expect(event.ObservedReplicas).To(Equal(int32(3)))
+			Expect(event.Action).To(Equal(types.DriftPolicyReassert))
+		})
+
+		It("should adopt the new replica count", func() {
+			buildContext(types.DriftPolicyAdopt)
+			workload.replicas = 12
+
+			_, err := handler.Handle(scaleCtx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(workload.replicas).To(Equal(int32(12)))
+			Expect(*alertScale.Status.ScaleStatus.TargetReplicas).To(Equal(int32(12)))
+			Expect(alertScale.Status.ScaleStatus.Status).To(Equal(types.ScaleStatusScaled))
+		})
+
+		It("should abort to Failed", func() {
+			buildContext(types.DriftPolicyAbort)
+			workload.replicas = 3
+
+			_, err := handler.Handle(scaleCtx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(alertScale.Status.ScaleStatus.Status).To(Equal(types.ScaleStatusFailed))
+			Expect(alertScale.Status.ScaleStatus.Message).To(ContainSubstring("drift"))
+		})
+
+		It("should keep only the most recent drift events", func() {
+			buildContext("")
+			for i := 0; i < maxDriftEvents+3; i++ {
+				workload.replicas = int32(i)
+				_, err := handler.Handle(scaleCtx)
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			events := alertScale.Status.ScaleStatus.DriftEvents
+			Expect(events).To(HaveLen(maxDriftEvents))
+			Expect(events[len(events)-1].ObservedReplicas).To(Equal(int32(maxDriftEvents + 2)))
+		})
+
+		It("should record the same observed drift only once", func() {
+			buildContext("")
+			for i := 0; i < 3; i++ {
+				workload.replicas = 3
+				_, err := handler.Handle(scaleCtx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(workload.replicas).To(Equal(int32(8)))
+			}
+			Expect(alertScale.Status.ScaleStatus.DriftEvents).To(HaveLen(1))
+		})
+
+		It("should not adopt while a higher overlapping AlertScale holds the workload", func() {
+			buildContext(types.DriftPolicyAdopt)
+			holderTarget := int32(10)
+			holder := &opsv1beta1.AlertScale{
+				ObjectMeta: metav1.ObjectMeta{Name: "holder-scale", Namespace: "default"},
+				Spec:       alertScale.Spec,
+				Status: opsv1beta1.AlertScaleStatus{
+					ScaleStatus: opsv1beta1.ScaleStatus{
+						Status:         types.ScaleStatusScaled,
+						TargetReplicas: &holderTarget,
+					},
+				},
+			}
+			Expect(scaleCtx.Client.Create(scaleCtx.Context, holder)).To(Succeed())
+			holder.Status.ScaleStatus.Status = types.ScaleStatusScaled
+			holder.Status.ScaleStatus.TargetReplicas = &holderTarget
+			Expect(scaleCtx.Client.Status().Update(scaleCtx.Context, holder)).To(Succeed())
+			workload.replicas = 12
+
+			for i := 0; i < 2; i++ {
+				_, err := handler.Handle(scaleCtx)
+				Expect(err).NotTo(HaveOccurred())
+			}
+			Expect(*alertScale.Status.ScaleStatus.TargetReplicas).To(Equal(int32(8)))
+			Expect(alertScale.Status.ScaleStatus.DriftEvents).To(BeEmpty())
+			Expect(alertScale.Status.ScaleStatus.Overlaps).To(ConsistOf("default/holder-scale"))
+		})
+	})
+
+	Describe("Extend and cancel actions", func() {
+		var (
+			alertScale *opsv1beta1.AlertScale
+			scaleCtx   *types.ScaleContext
+			endTime    time.Time
+		)
+
+		buildContext := func(status string, annotations map[string]string, objects ...client.Object) {
+			targetReplicas := int32(8)
+			endTime = time.Now().Add(time.Hour).Truncate(time.Second)
+			alertScale = &opsv1beta1.AlertScale{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "action-scale",
+					Namespace:   "default",
+					Annotations: annotations,
+				},
+				Spec: opsv1beta1.AlertScaleSpec{
+					ScaleReason:   "Traffic spike",
+					ScaleDuration: "1h",
+					ScaleTarget: opsv1beta1.ScaleTarget{
+						Kind:      "Deployment",
+						Name:      "web-app",
+						Namespace: "default",
+					},
+				},
+				Status: opsv1beta1.AlertScaleStatus{
+					ScaleStatus: opsv1beta1.ScaleStatus{
+						Status:         status,
+						OriginReplicas: 2,
+						TargetReplicas: &targetReplicas,
+						ScaleBeginTime: metav1.NewTime(endTime.Add(-time.Hour)),
+						ScaleEndTime:   metav1.NewTime(endTime),
+					},
+				},
+			}
+
+			scheme := runtime.NewScheme()
+			Expect(opsv1beta1.AddToScheme(scheme)).To(Succeed())
+			Expect(autoscalingv2.AddToScheme(scheme)).To(Succeed())
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(append(objects, alertScale)...).
+				WithStatusSubresource(alertScale).
+				Build()
+
+			scaleCtx = &types.ScaleContext{
+				AlertScale:    alertScale,
+				Client:        fakeClient,
+				Request:       ctrl.Request{NamespacedName: client.ObjectKeyFromObject(alertScale)},
+				Context:       context.Background(),
+				ScaleStrategy: &fakeWorkload{replicas: 8, available: 8},
+			}
+		}
+
+		actionAnnotations := func(action, duration string) map[string]string {
+			return map[string]string{
+				constants.ScaleActionAnnotation:           action,
+				constants.ScaleActionDurationAnnotation:   duration,
+				constants.ScaleActionOperatorAnnotation:   "alice",
+				constants.ScaleActionReasonAnnotation:     "traffic still high",
+				constants.ScaleActionProcessingAnnotation: constants.ApprovalProcessingPending,
+			}
+		}
+
+		It("should extend the scale end time", func() {
+			buildContext(types.ScaleStatusScaled, actionAnnotations(constants.ScaleActionExtend, "30m"))
+
+			_, err := (&ScaledHandler{}).Handle(scaleCtx)
+			Expect(err).NotTo(HaveOccurred())
+
+			status := alertScale.Status.ScaleStatus
+			Expect(status.Status).To(Equal(types.ScaleStatusScaled))
+			Expect(status.ScaleEndTime.Time).To(BeTemporally("==", endTime.Add(30*time.Minute)))
+			Expect(status.LastAction).NotTo(BeNil())
+			Expect(status.LastAction.Action).To(Equal(constants.ScaleActionExtend))
+			Expect(status.LastAction.Operator).To(Equal("alice"))
+			Expect(status.LastAction.Duration).To(Equal("30m"))
+			Expect(alertScale.Annotations).To(HaveKeyWithValue(constants.ScaleActionProcessingAnnotation, constants.ApprovalProcessingCompleted))
+		})
+
+		It("should reject an extend beyond the maxDuration of a ScaleGuardrail", func() {
+			guardrail := &opsv1beta1.ScaleGuardrail{
+				ObjectMeta: metav1.ObjectMeta{Name: "duration-limit", Namespace: "default"},
+				Spec:       opsv1beta1.ScaleGuardrailSpec{MaxDuration: "75m"},
+			}
+			buildContext(types.ScaleStatusScaled, actionAnnotations(constants.ScaleActionExtend, "30m"), guardrail)
+			recorder := record.NewFakeRecorder(10)
+			scaleCtx.Recorder = recorder
+
+			_, err := (&ScaledHandler{}).Handle(scaleCtx)
+			Expect(err).NotTo(HaveOccurred())
+
+			status := alertScale.Status.ScaleStatus
+			Expect(status.ScaleEndTime.Time).To(BeTemporally("==", endTime))
+			Expect(status.LastAction).To(BeNil())
+			Expect(alertScale.Annotations).To(HaveKeyWithValue(constants.ScaleActionProcessingAnnotation, constants.ApprovalProcessingCompleted))
+			Expect(recorder.Events).To(Receive(ContainSubstring("ScaleGuardrail duration-limit: extended duration 1h30m0s exceeds the maximum of 75m")))
+		})
+
+		It("should cancel a scaled AlertScale", func() {
+			buildContext(types.ScaleStatusScaled, actionAnnotations(constants.ScaleActionCancel, ""))
+
+			_, err := (&ScaledHandler{}).Handle(scaleCtx)
+			Expect(err).NotTo(HaveOccurred())
+
+			status := alertScale.Status.ScaleStatus
+			Expect(status.Status).To(Equal(types.ScaleStatusCompleted))
+			Expect(status.ScaleEndTime.Time).To(BeTemporally("<", endTime))
+			Expect(status.LastAction.Action).To(Equal(constants.ScaleActionCancel))
+			Expect(status.LastAction.Reason).To(Equal("traffic still high"))
+		})
+
+		It("should cancel while still scaling", func() {
+			buildContext(types.ScaleStatusScaling, actionAnnotations(constants.ScaleActionCancel, ""))
+
+			_, err := (&ScalingHandler{}).Handle(scaleCtx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(alertScale.Status.ScaleStatus.Status).To(Equal(types.ScaleStatusCompleted))
+		})
+
+		It("should defer an extend until scaling has finished", func() {
+			buildContext(types.ScaleStatusScaling, actionAnnotations(constants.ScaleActionExtend, "30m"))
+
+			_, err := (&ScalingHandler{}).Handle(scaleCtx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(alertScale.Status.ScaleStatus.LastAction).To(BeNil())
+			Expect(alertScale.Annotations).To(HaveKeyWithValue(constants.ScaleActionProcessingAnnotation, constants.ApprovalProcessingPending))
+		})
+
+		It("should ignore actions that were already processed", func() {
+			annotations := actionAnnotations(constants.ScaleActionCancel, "")
+			annotations[constants.ScaleActionProcessingAnnotation] = constants.ApprovalProcessingCompleted
+			buildContext(types.ScaleStatusScaled, annotations)
+
+			_, err := (&ScaledHandler{}).Handle(scaleCtx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(alertScale.Status.ScaleStatus.Status).To(Equal(types.ScaleStatusScaled))
+		})
+	})
+
+	Describe("Conditions and events", func() {
+		var (
+			alertScale *opsv1beta1.AlertScale
+			scaleCtx   *types.ScaleContext
+			recorder   *record.FakeRecorder
+		)
+
+		buildContext := func(status opsv1beta1.ScaleStatus, spec opsv1beta1.AlertScaleSpec) {
+			spec.ScaleTarget = opsv1beta1.ScaleTarget{Kind: "Deployment", Name: "web-app", Namespace: "default"}
+			alertScale = &opsv1beta1.AlertScale{
+				ObjectMeta: metav1.ObjectMeta{Name: "condition-scale", Namespace: "default", Generation: 3},
+				Spec:       spec,
+				Status:     opsv1beta1.AlertScaleStatus{ScaleStatus: status},
+			}
+
+			scheme := runtime.NewScheme()
+			Expect(opsv1beta1.AddToScheme(scheme)).To(Succeed())
+			Expect(autoscalingv2.AddToScheme(scheme)).To(Succeed())
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(alertScale).
+				WithStatusSubresource(alertScale).
+				Build()
+
+			recorder = record.NewFakeRecorder(10)
+			scaleCtx = &types.ScaleContext{
+				AlertScale:    alertScale,
+				Client:        fakeClient,
+				Request:       ctrl.Request{NamespacedName: client.ObjectKeyFromObject(alertScale)},
+				Context:       context.Background(),
+				ScaleStrategy: &fakeWorkload{replicas: 2, available: 2},
+				Recorder:      recorder,
+			}
+		}
+
+		It("should mark the AlertScale as approved", func() {
+			buildContext(opsv1beta1.ScaleStatus{
+				Status:         types.ScaleStatusApprovaling,
+				ScaleBeginTime: metav1.Now(),
+			}, opsv1beta1.AlertScaleSpec{ScaleAutoApproval: true})
+
+			_, err := (&ApprovalingHandler{}).Handle(scaleCtx)
+			Expect(err).NotTo(HaveOccurred())
+
+			condition := meta.FindStatusCondition(alertScale.Status.Conditions, types.ConditionApproved)
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+			Expect(condition.Reason).To(Equal(types.ReasonAutoApproved))
+			Expect(condition.ObservedGeneration).To(Equal(int64(3)))
+			Expect(recorder.Events).To(Receive(Equal("Normal AutoApproved Auto-approved by system")))
+		})
+
+		It("should record the matched auto-approval rule as the approver", func() {
+			targetReplicas := int32(3)
+			buildContext(opsv1beta1.ScaleStatus{
+				Status:         types.ScaleStatusApprovaling,
+				ScaleBeginTime: metav1.Now(),
+				OriginReplicas: 2,
+				TargetReplicas: &targetReplicas,
+			}, opsv1beta1.AlertScaleSpec{
+				ScaleTimeout: "10m",
+				AutoApprovalRules: []opsv1beta1.AutoApprovalRule{
+					{Name: "prod-only", Expression: `namespaceName == "prod"`},
+					{Name: "small-delta", Expression: `delta <= 2`},
+				},
+			})
+
+			_, err := (&ApprovalingHandler{}).Handle(scaleCtx)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(alertScale.Status.ScaleStatus.Status).To(Equal(types.ScaleStatusApproved))
+			last := alertScale.Status.History[len(alertScale.Status.History)-1]
+			Expect(last.Actor).To(Equal("rule:small-delta"))
+			Expect(last.Reason).To(Equal(types.ReasonAutoApproved))
+			Expect(recorder.Events).To(Receive(Equal("Normal AutoApproved Auto-approved by rule small-delta")))
+		})
+
+		It("should keep waiting for approval when no rule matches", func() {
+			targetReplicas := int32(8)
+			buildContext(opsv1beta1.ScaleStatus{
+				Status:         types.ScaleStatusApprovaling,
+				ScaleBeginTime: metav1.Now(),
+				OriginReplicas: 2,
+				TargetReplicas: &targetReplicas,
+			}, opsv1beta1.AlertScaleSpec{
+				ScaleTimeout:      "10m",
+				AutoApprovalRules: []opsv1beta1.AutoApprovalRule{{Name: "small-delta", Expression: `delta <= 2`}},
+			})
+
+			result, err := (&ApprovalingHandler{}).Handle(scaleCtx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(BeNumerically(">", 0))
+			Expect(alertScale.Status.ScaleStatus.Status).To(Equal(types.ScaleStatusApprovaling))
+		})
+
+		It("should emit a warning when scaling times out", func() {
+			targetReplicas := int32(6)
+			buildContext(opsv1beta1.ScaleStatus{
+				Status:           types.ScaleStatusScaling,
+				OriginReplicas:   2,
+				TargetReplicas:   &targetReplicas,
+				ScalingBeginTime: metav1.NewTime(time.Now().Add(-time.Hour)),
+			}, opsv1beta1.AlertScaleSpec{
+				ScaleTimeout:  "10m",
+				ScaleUpPolicy: &opsv1beta1.StepPolicy{ReplicasPerStep: 1, WaitForReady: true},
+			})
+
+			_, err := (&ScalingHandler{}).Handle(scaleCtx)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(alertScale.Status.ScaleStatus.Status).To(Equal(types.ScaleStatusFailed))
+			Expect(meta.IsStatusConditionTrue(alertScale.Status.Conditions, types.ConditionFailed)).To(BeTrue())
+			Expect(meta.IsStatusConditionFalse(alertScale.Status.Conditions, types.ConditionProgressing)).To(BeTrue())
+			Expect(recorder.Events).To(Receive(HavePrefix("Warning ScaleTimeout")))
+		})
+
+		It("should report the scaled workload as available", func() {
+			targetReplicas := int32(2)
+			buildContext(opsv1beta1.ScaleStatus{
+				Status:           types.ScaleStatusScaling,
+				OriginReplicas:   2,
+				TargetReplicas:   &targetReplicas,
+				ScalingBeginTime: metav1.Now(),
+			}, opsv1beta1.AlertScaleSpec{ScaleDuration: "1h"})
+
+			_, err := (&ScalingHandler{}).Handle(scaleCtx)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(alertScale.Status.ScaleStatus.Status).To(Equal(types.ScaleStatusScaled))
+			Expect(meta.IsStatusConditionTrue(alertScale.Status.Conditions, types.ConditionAvailable)).To(BeTrue())
+			Expect(recorder.Events).To(Receive(HavePrefix("Normal ScaleUpComplete")))
+		})
+	})
+
+	Describe("Capacity pre-check", func() {
+		var (
+			alertScale *opsv1beta1.AlertScale
+			scaleCtx   *types.ScaleContext
+			recorder   *record.FakeRecorder
+		)
+
+		buildContext := func(policy string, targetReplicas int32) {
+			alertScale = &opsv1beta1.AlertScale{
+				ObjectMeta: metav1.ObjectMeta{Name: "capacity-scale", Namespace: "default"},
+				Spec: opsv1beta1.AlertScaleSpec{
+					ScaleTarget:         opsv1beta1.ScaleTarget{Kind: "Deployment", Name: "web-app", Namespace: "default"},
+					CapacityCheckPolicy: policy,
+				},
+				Status: opsv1beta1.AlertScaleStatus{ScaleStatus: opsv1beta1.ScaleStatus{
+					Status:         types.ScaleStatusApproved,
+					OriginReplicas: 2,
+					TargetReplicas: &targetReplicas,
+				}},
+			}
+			deployment := &appv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Name: "web-app", Namespace: "default"},
+				Spec: appv1.DeploymentSpec{
+					Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+						Containers: []corev1.Container{{
+							Name: "web",
+							Resources: corev1.ResourceRequirements{
+								Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+							},
+						}},
+					}},
+				},
+			}
+			node := &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{Name: "node-a"},
+				Status: corev1.NodeStatus{
+					Allocatable: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+					Conditions:  []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}},
+				},
+			}
+
+			scheme := runtime.NewScheme()
+			Expect(opsv1beta1.AddToScheme(scheme)).To(Succeed())
+			Expect(appv1.AddToScheme(scheme)).To(Succeed())
+			Expect(corev1.AddToScheme(scheme)).To(Succeed())
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(alertScale, deployment, node).
+				WithStatusSubresource(alertScale).
+				WithIndex(&corev1.Pod{}, "spec.nodeName", func(obj client.Object) []string {
+					return []string{obj.(*corev1.Pod).Spec.NodeName}
+				}).
+				Build()
+
+			recorder = record.NewFakeRecorder(10)
+			scaleCtx = &types.ScaleContext{
+				AlertScale:    alertScale,
+				Client:        fakeClient,
+				Request:       ctrl.Request{NamespacedName: client.ObjectKeyFromObject(alertScale)},
+				Context:       context.Background(),
+				ScaleStrategy: &fakeWorkload{replicas: 2, available: 2},
+				Recorder:      recorder,
+			}
+		}
+
+		It("should start scaling when the capacity is sufficient", func() {
+			buildContext(types.CapacityCheckPolicyBlock, 4)
+
+			_, err := (&ApprovedHandler{}).Handle(scaleCtx)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(alertScale.Status.ScaleStatus.Status).To(Equal(types.ScaleStatusScaling))
+			Expect(alertScale.Status.CapacityCheck).NotTo(BeNil())
+			Expect(alertScale.Status.CapacityCheck.Result).To(Equal(types.CapacityResultSufficient))
+			Expect(alertScale.Status.CapacityCheck.AdditionalReplicas).To(Equal(int32(2)))
+		})
+
+		It("should fail when the capacity is insufficient and the policy is Block", func() {
+			buildContext(types.CapacityCheckPolicyBlock, 6)
+
+			_, err := (&ApprovedHandler{}).Handle(scaleCtx)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(alertScale.Status.ScaleStatus.Status).To(Equal(types.ScaleStatusFailed))
+			Expect(alertScale.Status.CapacityCheck.Result).To(Equal(types.CapacityResultInsufficient))
+			Expect(alertScale.Status.CapacityCheck.Policy).To(Equal(types.CapacityCheckPolicyBlock))
+			Expect(meta.IsStatusConditionTrue(alertScale.Status.Conditions, types.ConditionFailed)).To(BeTrue())
+			Expect(recorder.Events).To(Receive(HavePrefix("Warning CapacityShortfall Insufficient capacity for 4 additional replicas")))
+		})
+
+		It("should warn and scale anyway by default", func() {
+			buildContext("", 6)
+
+			_, err := (&ApprovedHandler{}).Handle(scaleCtx)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(alertScale.Status.ScaleStatus.Status).To(Equal(types.ScaleStatusScaling))
+			Expect(alertScale.Status.CapacityCheck.Policy).To(Equal(types.CapacityCheckPolicyWarn))
+			Expect(recorder.Events).To(Receive(HavePrefix("Warning CapacityShortfall")))
+		})
+
+		It("should only record the result when the policy is Proceed", func() {
+			buildContext(types.CapacityCheckPolicyProceed, 6)
+
+			_, err := (&ApprovedHandler{}).Handle(scaleCtx)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(alertScale.Status.ScaleStatus.Status).To(Equal(types.ScaleStatusScaling))
+			Expect(alertScale.Status.CapacityCheck.Result).To(Equal(types.CapacityResultInsufficient))
+			Expect(recorder.Events).To(Receive(HavePrefix("Normal ScalingStarted")))
+		})
+	})
+
+	Describe("Vertical scaling", func() {
+		var (
+			alertScale *opsv1beta1.AlertScale
+			scaleCtx   *types.ScaleContext
+			resources  *fakeResources
+		)
+
+		memory := func(request, limit string) corev1.ResourceRequirements {
+			return corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse(request)},
+				Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse(limit)},
+			}
+		}
+
+		newVerticalScale := func(name, status, limit string) *opsv1beta1.AlertScale {
+			targetReplicas := int32(2)
+			return &opsv1beta1.AlertScale{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+				Spec: opsv1beta1.AlertScaleSpec{
+					ScaleTarget:   opsv1beta1.ScaleTarget{Kind: "Deployment", Name: "web-app", Namespace: "default"},
+					ScaleType:     types.ScaleTypeVertical,
+					ScaleDuration: "1h",
+					ScaleTimeout:  "10m",
+					VerticalScale: &opsv1beta1.VerticalScale{Containers: []opsv1beta1.ContainerResources{{
+						Name:      "app",
+						Resources: corev1.ResourceRequirements{Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse(limit)}},
+					}}},
+				},
+				Status: opsv1beta1.AlertScaleStatus{ScaleStatus: opsv1beta1.ScaleStatus{
+					Status:           status,
+					OriginReplicas:   2,
+					TargetReplicas:   &targetReplicas,
+					ScalingBeginTime: metav1.Now(),
+				}},
+			}
+		}
+
+		buildContext := func(others ...client.Object) {
+			scheme := runtime.NewScheme()
+			Expect(opsv1beta1.AddToScheme(scheme)).To(Succeed())
+			Expect(autoscalingv2.AddToScheme(scheme)).To(Succeed())
+			objects := append([]client.Object{alertScale}, others...)
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(objects...).
+				WithStatusSubresource(objects...).
+				Build()
+
+			scaleCtx = &types.ScaleContext{
+				AlertScale:       alertScale,
+				Client:           fakeClient,
+				Request:          ctrl.Request{NamespacedName: client.ObjectKeyFromObject(alertScale)},
+				Context:          context.Background(),
+				ScaleStrategy:    &fakeWorkload{replicas: 2, available: 2},
+				ResourceStrategy: resources,
+			}
+		}
+
+		BeforeEach(func() {
+			resources = &fakeResources{
+				containers: []opsv1beta1.ContainerResources{
+					{Name: "app", Resources: memory("1Gi", "2Gi")},
+					{Name: "sidecar", Resources: memory("64Mi", "128Mi")},
+				},
+				rolledOut: true,
+			}
+		})
+
+		It("should snapshot and raise the container resources", func() {
+			alertScale = newVerticalScale("vertical-scale", types.ScaleStatusScaling, "4Gi")
+			buildContext()
+
+			_, err := (&ScalingHandler{}).Handle(scaleCtx)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(alertScale.Status.OriginResources).To(Equal([]opsv1beta1.ContainerResources{
+				{Name: "app", Resources: memory("1Gi", "2Gi")},
+			}))
+			Expect(resources.containers[0].Resources.Limits.Memory().String()).To(Equal("4Gi"))
+			Expect(resources.containers[0].Resources.Requests.Memory().String()).To(Equal("1Gi"))
+			Expect(resources.containers[1].Resources).To(Equal(memory("64Mi", "128Mi")))
+			Expect(alertScale.Status.ScaleStatus.Status).To(Equal(types.ScaleStatusScaled))
+			Expect(alertScale.Status.History[len(alertScale.Status.History)-1].Message).
+				To(HavePrefix("Scaled to raised resources of containers app until"))
+		})
+
+		It("should keep scaling until the rollout completes", func() {
+			alertScale = newVerticalScale("vertical-scale", types.ScaleStatusScaling, "4Gi")
+			resources.rolledOut = false
+			buildContext()
+
+			_, err := (&ScalingHandler{}).Handle(scaleCtx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(alertScale.Status.ScaleStatus.Status).To(Equal(types.ScaleStatusScaling))
+			Expect(resources.sets).To(Equal(1))
+		})
+
+		It("should restore the original resources when completed", func() {
+			alertScale = newVerticalScale("vertical-scale", types.ScaleStatusCompleted, "4Gi")
+			alertScale.Status.OriginResources = []opsv1beta1.ContainerResources{{Name: "app", Resources: memory("1Gi", "2Gi")}}
+			resources.containers[0].Resources = memory("1Gi", "4Gi")
+			buildContext()
+
+			_, err := (&CompletedHandler{}).Handle(scaleCtx)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(resources.containers[0].Resources).To(Equal(memory("1Gi", "2Gi")))
+			Expect(alertScale.Status.ScaleStatus.Status).To(Equal(types.ScaleStatusArchived))
+		})
+
+		It("should inherit the original resources and hand off the restore", func() {
+			earlier := newVerticalScale("earlier-scale", types.ScaleStatusScaled, "3Gi")
+			earlier.CreationTimestamp = metav1.NewTime(time.Now().Add(-time.Hour))
+			earlier.Status.OriginResources = []opsv1beta1.ContainerResources{{Name: "app", Resources: memory("1Gi", "2Gi")}}
+			resources.containers[0].Resources = memory("1Gi", "3Gi")
+
+			alertScale = newVerticalScale("vertical-scale", types.ScaleStatusScaling, "4Gi")
+			buildContext(earlier)
+			_, err := (&ScalingHandler{}).Handle(scaleCtx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(alertScale.Status.OriginResources[0].Resources).To(Equal(memory("1Gi", "2Gi")))
+			Expect(resources.containers[0].Resources.Limits.Memory().String()).To(Equal("4Gi"))
+
+			// 后创建的先结束时重新应用仍在生效的 AlertScale 的资源
+			alertScale.Status.ScaleStatus.Status = types.ScaleStatusCompleted
+			_, err = (&CompletedHandler{}).Handle(scaleCtx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resources.containers[0].Resources).To(Equal(memory("1Gi", "3Gi")))
+			Expect(alertScale.Status.ScaleStatus.Status).To(Equal(types.ScaleStatusArchived))
+			Expect(alertScale.Status.ScaleStatus.Message).To(Equal("restore handed off to default/earlier-scale"))
+		})
+	})
+
+	Describe("Multi-target AlertScale", func() {
+		var (
+			alertScale *opsv1beta1.AlertScale
+			scaleCtx   *types.ScaleContext
+			group      *fakeGroup
+			others     []client.Object
+		)
+
+		deployment := func(name string, labels map[string]string) *appv1.Deployment {
+			return &appv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: labels}}
+		}
+
+		member := func(name string, phase string, origin, target int32) opsv1beta1.TargetStatus {
+			return opsv1beta1.TargetStatus{
+				Target:         opsv1beta1.ScaleTarget{Kind: "Deployment", Name: name, Namespace: "default"},
+				Phase:          phase,
+				OriginReplicas: origin,
+				TargetReplicas: target,
+			}
+		}
+
+		single := func(name string, status string, origin, target int32) *opsv1beta1.AlertScale {
+			return &opsv1beta1.AlertScale{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+				Spec: opsv1beta1.AlertScaleSpec{
+					ScaleTarget: opsv1beta1.ScaleTarget{Kind: "Deployment", Name: "gateway", Namespace: "default"},
+				},
+				Status: opsv1beta1.AlertScaleStatus{
+					ScaleStatus: opsv1beta1.ScaleStatus{Status: status, OriginReplicas: origin, TargetReplicas: &target},
+				},
+			}
+		}
+
+		buildContext := func(status string, targets ...opsv1beta1.TargetStatus) {
+			gatewayReplicas := int32(6)
+			alertScale = &opsv1beta1.AlertScale{
+				ObjectMeta: metav1.ObjectMeta{Name: "group-scale", Namespace: "default"},
+				Spec: opsv1beta1.AlertScaleSpec{
+					ScaleDuration:  "1h",
+					ScaleTimeout:   "10m",
+					ScaleMode:      types.ScaleModePercentage,
+					ScaleThreshold: 200,
+					ScaleTargets: []opsv1beta1.GroupTarget{{
+						ScaleTarget: opsv1beta1.ScaleTarget{Kind: "Deployment", Name: "gateway"},
+						Replicas:    &gatewayReplicas,
+					}},
+					ScaleTargetSelector: &opsv1beta1.ScaleTargetSelector{
+						Kind:     "Deployment",
+						Selector: metav1.LabelSelector{MatchLabels: map[string]string{"tier": "api"}},
+					},
+				},
+				Status: opsv1beta1.AlertScaleStatus{
+					ScaleStatus: opsv1beta1.ScaleStatus{Status: status, ScalingBeginTime: metav1.Now()},
+					Targets:     targets,
+				},
+			}
+
+			scheme := runtime.NewScheme()
+			Expect(opsv1beta1.AddToScheme(scheme)).To(Succeed())
+			Expect(appv1.AddToScheme(scheme)).To(Succeed())
+			Expect(autoscalingv2.AddToScheme(scheme)).To(Succeed())
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(append(others, alertScale,
+					deployment("gateway", nil),
+					deployment("api", map[string]string{"tier": "api"}),
+					deployment("worker", map[string]string{"tier": "worker"}))...).
+				WithStatusSubresource(alertScale).
+				Build()
+
+			scaleCtx = &types.ScaleContext{
+				AlertScale:    alertScale,
+				Client:        fakeClient,
+				Request:       ctrl.Request{NamespacedName: client.ObjectKeyFromObject(alertScale)},
+				Context:       context.Background(),
+				ScaleStrategy: group,
+			}
+		}
+
+		BeforeEach(func() {
+			group = &fakeGroup{
+				workloads: map[string]*fakeWorkload{
+					"gateway": {replicas: 2, available: 2},
+					"api":     {replicas: 3, available: 3},
+				},
+				failures: map[string]error{},
+			}
+			others = nil
+		})
+
+		It("should resolve the members with their own desired replicas", func() {
+			buildContext(types.ScaleStatusPending)
+
+			_, err := (&PendingHandler{}).Handle(scaleCtx)
+			Expect(err).NotTo(HaveOccurred())
+
+			targets := alertScale.Status.Targets
+			Expect(targets).To(HaveLen(2))
+			Expect(targets[0].Target.Name).To(Equal("gateway"))
+			Expect(targets[0].Target.Namespace).To(Equal("default"))
+			Expect(targets[0].TargetReplicas).To(Equal(int32(6)))
+			Expect(targets[1].Target.Name).To(Equal("api"))
+			Expect(targets[1].TargetReplicas).To(Equal(int32(6)))
+			Expect(alertScale.Status.ScaleStatus.OriginReplicas).To(Equal(int32(5)))
+			Expect(*alertScale.Status.ScaleStatus.TargetReplicas).To(Equal(int32(12)))
+			Expect(alertScale.Status.ScaleStatus.Status).To(Equal(types.ScaleStatusApprovaling))
+		})
+
+		It("should fail when the selector matches nothing", func() {
+			buildContext(types.ScaleStatusPending)
+			alertScale.Spec.ScaleTargets = nil
+			alertScale.Spec.ScaleTargetSelector.Selector.MatchLabels["tier"] = "missing"
+
+			_, err := (&PendingHandler{}).Handle(scaleCtx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(alertScale.Status.ScaleStatus.Status).To(Equal(types.ScaleStatusFailed))
+			Expect(alertScale.Status.ScaleStatus.Message).To(ContainSubstring("no scale targets matched"))
+		})
+
+		It("should scale every member and reach Scaled once all are available", func() {
+			buildContext(types.ScaleStatusScaling,
+				member("gateway", types.TargetPhasePending, 2, 6),
+				member("api", types.TargetPhasePending, 3, 6))
+			group.workloads["gateway"].available = 6
+
+			_, err := (&ScalingHandler{}).Handle(scaleCtx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(group.workloads["gateway"].replicas).To(Equal(int32(6)))
+			Expect(group.workloads["api"].replicas).To(Equal(int32(6)))
+			Expect(alertScale.Status.Targets[0].Phase).To(Equal(types.TargetPhaseScaled))
+			Expect(alertScale.Status.Targets[1].Phase).To(Equal(types.TargetPhaseScaling))
+			Expect(alertScale.Status.ScaleStatus.Status).To(Equal(types.ScaleStatusScaling))
+
+			group.workloads["api"].available = 6
+			_, err = (&ScalingHandler{}).Handle(scaleCtx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(alertScale.Status.ScaleStatus.Status).To(Equal(types.ScaleStatusScaled))
+			Expect(alertScale.Status.ScaleStatus.ScaledReplicas).To(Equal(int32(12)))
+		})
+
+		It("should roll back every member when one fails to scale", func() {
+			buildContext(types.ScaleStatusScaling,
+				member("gateway", types.TargetPhasePending, 2, 6),
+				member("api", types.TargetPhasePending, 3, 6))
+			group.failures["api"] = errors.New("admission webhook denied the request")
+
+			_, err := (&ScalingHandler{}).Handle(scaleCtx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(group.workloads["gateway"].replicas).To(Equal(int32(6)))
+			Expect(alertScale.Status.ScaleStatus.Status).To(Equal(types.ScaleStatusFailed))
+			Expect(alertScale.Status.Targets[1].Phase).To(Equal(types.TargetPhaseFailed))
+			Expect(alertScale.Status.ScaleStatus.Message).To(ContainSubstring("Scaling Deployment default/api failed"))
+
+			delete(group.failures, "api")
+			group.workloads["gateway"].available = 2
+			_, err = (&FailedHandler{}).Handle(scaleCtx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(group.workloads["gateway"].replicas).To(Equal(int32(2)))
+			Expect(group.workloads["api"].replicas).To(Equal(int32(3)))
+			Expect(alertScale.Status.ScaleStatus.Status).To(Equal(types.ScaleStatusArchived))
+		})
+
+		It("should scale restored members back up when another member fails to restore", func() {
+			group.workloads["gateway"].replicas, group.workloads["gateway"].available = 6, 6
+			group.workloads["api"].replicas, group.workloads["api"].available = 6, 6
+			buildContext(types.ScaleStatusCompleted,
+				member("gateway", types.TargetPhaseScaled, 2, 6),
+				member("api", types.TargetPhaseScaled, 3, 6))
+			group.failures["api"] = errors.New("conflict")
+
+			_, err := (&CompletedHandler{}).Handle(scaleCtx)
+			Expect(err).To(MatchError(ContainSubstring("Deployment default/api: conflict")))
+			Expect(group.workloads["gateway"].replicas).To(Equal(int32(6)))
+			Expect(alertScale.Status.Targets[0].Phase).To(Equal(types.TargetPhaseScaled))
+			Expect(alertScale.Status.Targets[1].Phase).To(Equal(types.TargetPhaseFailed))
+			Expect(alertScale.Status.ScaleStatus.Status).To(Equal(types.ScaleStatusCompleted))
+
+			delete(group.failures, "api")
+			group.workloads["gateway"].available = 2
+			group.workloads["api"].available = 3
+			_, err = (&CompletedHandler{}).Handle(scaleCtx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(group.workloads["gateway"].replicas).To(Equal(int32(2)))
+			Expect(group.workloads["api"].replicas).To(Equal(int32(3)))
+			Expect(alertScale.Status.ScaleStatus.Status).To(Equal(types.ScaleStatusArchived))
+		})
+
+		It("should inherit the origin and respect a single AlertScale holding a member", func() {
+			group.workloads["gateway"].replicas, group.workloads["gateway"].available = 10, 10
+			others = []client.Object{single("gateway-scale", types.ScaleStatusScaled, 2, 10)}
+			buildContext(types.ScaleStatusPending)
+
+			_, err := (&PendingHandler{}).Handle(scaleCtx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(alertScale.Status.Targets[0].OriginReplicas).To(Equal(int32(2)))
+
+			alertScale.Status.ScaleStatus.Status = types.ScaleStatusScaling
+			group.workloads["api"].available = 6
+			_, err = (&ScalingHandler{}).Handle(scaleCtx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(group.workloads["gateway"].replicas).To(Equal(int32(10)))
+			Expect(alertScale.Status.Targets[0].Phase).To(Equal(types.TargetPhaseScaled))
+			Expect(alertScale.Status.ScaleStatus.Status).To(Equal(types.ScaleStatusScaled))
+		})
+
+		It("should restore a member only to the replicas a single AlertScale still holds", func() {
+			group.workloads["gateway"].replicas, group.workloads["gateway"].available = 6, 4
+			group.workloads["api"].replicas, group.workloads["api"].available = 6, 3
+			others = []client.Object{single("gateway-scale", types.ScaleStatusScaled, 2, 4)}
+			buildContext(types.ScaleStatusCompleted,
+				member("gateway", types.TargetPhaseScaled, 2, 6),
+				member("api", types.TargetPhaseScaled, 3, 6))
+
+			_, err := (&CompletedHandler{}).Handle(scaleCtx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(group.workloads["gateway"].replicas).To(Equal(int32(4)))
+			Expect(group.workloads["api"].replicas).To(Equal(int32(3)))
+			Expect(alertScale.Status.ScaleStatus.Status).To(Equal(types.ScaleStatusArchived))
+		})
+
+		It("should count an unrestored member as overlapping a single AlertScale", func() {
+			buildContext(types.ScaleStatusScaled,
+				member("gateway", types.TargetPhaseScaled, 2, 6),
+				member("api", types.TargetPhaseScaled, 3, 6))
+			singleCtx := &types.ScaleContext{
+				AlertScale: single("gateway-scale", types.ScaleStatusPending, 0, 0),
+				Client:     scaleCtx.Client,
+				Context:    context.Background(),
+			}
+
+			overlapping, err := (&BaseStateHandler{}).overlappingScales(singleCtx, inheritableStatuses)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(overlapping).To(HaveLen(1))
+			Expect(overlapping[0].Name).To(Equal("group-scale"))
+			Expect(overlapping[0].Status.ScaleStatus.OriginReplicas).To(Equal(int32(2)))
+			Expect(maxTargetReplicas(overlapping)).To(Equal(int32(6)))
+
+			alertScale.Status.Targets[0].Phase = types.TargetPhaseRestored
+			Expect(scaleCtx.Client.Status().Update(scaleCtx.Context, alertScale)).To(Succeed())
+			overlapping, err = (&BaseStateHandler{}).overlappingScales(singleCtx, inheritableStatuses)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(overlapping).To(BeEmpty())
+		})
+	})
+
+	Describe("ScaleGuardrail", func() {
+		var (
+			alertScale *opsv1beta1.AlertScale
+			guardrail  *opsv1beta1.ScaleGuardrail
+		)
+
+		buildContext := func() *types.ScaleContext {
+			scheme := runtime.NewScheme()
+			Expect(opsv1beta1.AddToScheme(scheme)).To(Succeed())
+			Expect(autoscalingv2.AddToScheme(scheme)).To(Succeed())
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(alertScale, guardrail).
+				WithStatusSubresource(alertScale).
+				Build()
+
+			return &types.ScaleContext{
+				AlertScale:    alertScale,
+				Client:        fakeClient,
+				Request:       ctrl.Request{NamespacedName: client.ObjectKeyFromObject(alertScale)},
+				Context:       context.Background(),
+				ScaleStrategy: &fakeWorkload{replicas: 4, available: 4},
+			}
+		}
+
+		BeforeEach(func() {
+			maxReplicas := int32(10)
+			guardrail = &opsv1beta1.ScaleGuardrail{
+				ObjectMeta: metav1.ObjectMeta{Name: "limits", Namespace: "default"},
+				Spec:       opsv1beta1.ScaleGuardrailSpec{MaxReplicas: &maxReplicas, MaxMultiplier: "2"},
+			}
+			alertScale = &opsv1beta1.AlertScale{
+				ObjectMeta: metav1.ObjectMeta{Name: "web-scale", Namespace: "default"},
+				Spec: opsv1beta1.AlertScaleSpec{
+					ScaleTarget:    opsv1beta1.ScaleTarget{Kind: "Deployment", Name: "web-app", Namespace: "default"},
+					ScaleThreshold: 8,
+					ScaleDuration:  "1h",
+					ScaleTimeout:   "10m",
+				},
+				Status: opsv1beta1.AlertScaleStatus{
+					ScaleStatus: opsv1beta1.ScaleStatus{Status: types.ScaleStatusPending},
+				},
+			}
+		})
+
+		It("should wait for approval within the limits", func() {
+			_, err := (&PendingHandler{}).Handle(buildContext())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(alertScale.Status.ScaleStatus.Status).To(Equal(types.ScaleStatusApprovaling))
+			Expect(alertScale.Status.GuardrailViolations).To(BeEmpty())
+		})
+
+		It("should fail with the violations explained when a limit is exceeded", func() {
+			alertScale.Spec.ScaleThreshold = 12
+
+			_, err := (&PendingHandler{}).Handle(buildContext())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(alertScale.Status.ScaleStatus.Status).To(Equal(types.ScaleStatusFailed))
+			Expect(alertScale.Status.GuardrailViolations).To(HaveLen(2))
+			Expect(alertScale.Status.GuardrailViolations[0].Rule).To(Equal("MaxReplicas"))
+			Expect(alertScale.Status.GuardrailViolations[1].Rule).To(Equal("MaxMultiplier"))
+			Expect(alertScale.Status.ScaleStatus.Message).To(HavePrefix(
+				"Blocked by ScaleGuardrail limits: Deployment default/web-app: target replicas 12 exceed the maximum of 10"))
+
+			failed := meta.FindStatusCondition(alertScale.Status.Conditions, types.ConditionFailed)
+			Expect(failed).NotTo(BeNil())
+			Expect(failed.Reason).To(Equal(types.ReasonGuardrailViolated))
+		})
+	})
+
+	Describe("ApprovalPolicy quorum", func() {
+		var (
+			alertScale *opsv1beta1.AlertScale
+			scaleCtx   *types.ScaleContext
+			recorder   *record.FakeRecorder
+		)
+
+		// submit 模拟审批人通过注解提交决策，并由 ApprovalingHandler 处理
+		submit := func(operator, decision string) {
+			alertScale.Annotations = map[string]string{
+				constants.ApprovalDecisionAnnotation:   decision,
+				constants.ApprovalOperatorAnnotation:   operator,
+				constants.ApprovalProcessingAnnotation: constants.ApprovalProcessingPending,
+			}
+			_, err := (&ApprovalingHandler{}).Handle(scaleCtx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(alertScale.Annotations[constants.ApprovalProcessingAnnotation]).To(Equal(constants.ApprovalProcessingCompleted))
+		}
+
+		BeforeEach(func() {
+			alertScale = &opsv1beta1.AlertScale{
+				ObjectMeta: metav1.ObjectMeta{Name: "web-scale", Namespace: "production", Labels: map[string]string{"tier": "critical"}},
+				Spec: opsv1beta1.AlertScaleSpec{
+					ScaleTarget:       opsv1beta1.ScaleTarget{Kind: "Deployment", Name: "web-app", Namespace: "production"},
+					ScaleTimeout:      "10m",
+					ScaleAutoApproval: true,
+				},
+				Status: opsv1beta1.AlertScaleStatus{
+					ScaleStatus: opsv1beta1.ScaleStatus{Status: types.ScaleStatusApprovaling, ScaleBeginTime: metav1.Now()},
+				},
+			}
+			policy := &opsv1beta1.ApprovalPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "prod"},
+				Spec: opsv1beta1.ApprovalPolicySpec{
+					Namespaces: []string{"production"},
+					Selector:   &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "critical"}},
+					ApproverGroups: []opsv1beta1.ApproverGroup{
+						{Name: "owner", Users: []string{"alice"}, Required: 1},
+						{Name: "sre", Users: []string{"carol", "dave"}, Required: 1},
+					},
+				},
+			}
+
+			scheme := runtime.NewScheme()
+			Expect(opsv1beta1.AddToScheme(scheme)).To(Succeed())
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(alertScale, policy).
+				WithStatusSubresource(alertScale).
+				Build()
+
+			recorder = record.NewFakeRecorder(10)
+			scaleCtx = &types.ScaleContext{
+				AlertScale: alertScale,
+				Client:     fakeClient,
+				Request:    ctrl.Request{NamespacedName: client.ObjectKeyFromObject(alertScale)},
+				Context:    context.Background(),
+				Recorder:   recorder,
+			}
+		})
+
+		It("should not auto-approve an AlertScale selected by a policy", func() {
+			_, err := (&ApprovalingHandler{}).Handle(scaleCtx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(alertScale.Status.ScaleStatus.Status).To(Equal(types.ScaleStatusApprovaling))
+		})
+
+		It("should approve only once every group has approved", func() {
+			submit("alice", constants.ApprovalDecisionApprove)
+			Expect(alertScale.Status.ScaleStatus.Status).To(Equal(types.ScaleStatusApprovaling))
+			Expect(alertScale.Status.ScaleStatus.Message).To(Equal("Waiting for approvals: prod/owner 1/1, prod/sre 0/1"))
+			Expect(alertScale.Status.Approvals).To(HaveLen(1))
+			Expect(alertScale.Status.Approvals[0].Groups).To(Equal([]string{"prod/owner"}))
+			Expect(recorder.Events).To(Receive(Equal(
+				"Normal ApprovalRecorded Approval from alice recorded, waiting for approvals: prod/owner 1/1, prod/sre 0/1")))
+
+			// 重复的批准不会重复计数
+			submit("alice", constants.ApprovalDecisionApprove)
+			Expect(alertScale.Status.ScaleStatus.Status).To(Equal(types.ScaleStatusApprovaling))
+			Expect(alertScale.Status.Approvals).To(HaveLen(1))
+
+			submit("carol", constants.ApprovalDecisionApprove)
+			Expect(alertScale.Status.ScaleStatus.Status).To(Equal(types.ScaleStatusApproved))
+			Expect(alertScale.Status.Approvals).To(HaveLen(2))
+			last := alertScale.Status.History[len(alertScale.Status.History)-1]
+			Expect(last.Actor).To(Equal("carol"))
+			Expect(last.Message).To(Equal("Approved by alice, carol (prod/owner 1/1, prod/sre 1/1)"))
+		})
+
+		It("should reject on a single rejection from a member", func() {
+			submit("alice", constants.ApprovalDecisionApprove)
+			submit("dave", constants.ApprovalDecisionReject)
+			Expect(alertScale.Status.ScaleStatus.Status).To(Equal(types.ScaleStatusRejected))
+			last := alertScale.Status.History[len(alertScale.Status.History)-1]
+			Expect(last.Actor).To(Equal("dave"))
+			Expect(last.Reason).To(Equal(types.ReasonRejected))
+		})
+
+		It("should ignore decisions from users outside the approver groups", func() {
+			submit("mallory", constants.ApprovalDecisionReject)
+			Expect(alertScale.Status.ScaleStatus.Status).To(Equal(types.ScaleStatusApprovaling))
+			Expect(alertScale.Status.Approvals).To(BeEmpty())
+			Expect(recorder.Events).To(Receive(Equal(
+				"Warning ApprovalIgnored Ignored reject from mallory: not a member of any approver group")))
+		})
+	})
+
+	Describe("Transition history", func() {
+		var (
+			alertScale *opsv1beta1.AlertScale
+			scaleCtx   *types.ScaleContext
+		)
+
+		buildContext := func(status opsv1beta1.ScaleStatus, annotations map[string]string) {
+			alertScale = &opsv1beta1.AlertScale{
+				ObjectMeta: metav1.ObjectMeta{Name: "history-scale", Namespace: "default", Annotations: annotations},
+				Spec: opsv1beta1.AlertScaleSpec{
+					ScaleTarget: opsv1beta1.ScaleTarget{Kind: "Deployment", Name: "web-app", Namespace: "default"},
+				},
+				Status: opsv1beta1.AlertScaleStatus{ScaleStatus: status},
+			}
+
+			scheme := runtime.NewScheme()
+			Expect(opsv1beta1.AddToScheme(scheme)).To(Succeed())
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(alertScale).
+				WithStatusSubresource(alertScale).
+				Build()
+
+			scaleCtx = &types.ScaleContext{
+				AlertScale: alertScale,
+				Client:     fakeClient,
+				Request:    ctrl.Request{NamespacedName: client.ObjectKeyFromObject(alertScale)},
+				Context:    context.Background(),
+			}
+		}
+
+		It("should record the approver of an API approval", func() {
+			buildContext(opsv1beta1.ScaleStatus{
+				Status:         types.ScaleStatusApprovaling,
+				ScaleBeginTime: metav1.Now(),
+			}, map[string]string{
+				constants.ApprovalDecisionAnnotation:   "approve",
+				constants.ApprovalOperatorAnnotation:   "alice",
+				constants.ApprovalProcessingAnnotation: constants.ApprovalProcessingPending,
+			})
+
+			_, err := (&ApprovalingHandler{}).Handle(scaleCtx)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(alertScale.Status.History).To(HaveLen(1))
+			transition := alertScale.Status.History[0]
+			Expect(transition.From).To(Equal(types.ScaleStatusApprovaling))
+			Expect(transition.To).To(Equal(types.ScaleStatusApproved))
+			Expect(transition.Actor).To(Equal("alice"))
+			Expect(transition.Reason).To(Equal(types.ReasonApproved))
+			Expect(transition.Time.IsZero()).To(BeFalse())
+		})
+
+		It("should record controller driven transitions as system", func() {
+			buildContext(opsv1beta1.ScaleStatus{}, nil)
+
+			_, err := (&DefaultHandler{}).Handle(scaleCtx)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(alertScale.Status.History).To(HaveLen(1))
+			Expect(alertScale.Status.History[0].From).To(BeEmpty())
+			Expect(alertScale.Status.History[0].To).To(Equal(types.ScaleStatusPending))
+			Expect(alertScale.Status.History[0].Actor).To(Equal(types.ActorSystem))
+		})
+
+		It("should record the requester stamped by the webhook as the creator", func() {
+			buildContext(opsv1beta1.ScaleStatus{}, map[string]string{
+				constants.RequestedByAnnotation: "bob",
+			})
+
+			_, err := (&DefaultHandler{}).Handle(scaleCtx)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(alertScale.Status.History).To(HaveLen(1))
+			Expect(alertScale.Status.History[0].Actor).To(Equal("bob"))
+		})
+
+		It("should expose the requester and the latest actor to templates", func() {
+			buildContext(opsv1beta1.ScaleStatus{
+				Status:         types.ScaleStatusApprovaling,
+				ScaleBeginTime: metav1.Now(),
+			}, map[string]string{
+				constants.RequestedByAnnotation:        "bob",
+				constants.ApprovalDecisionAnnotation:   "approve",
+				constants.ApprovalOperatorAnnotation:   "alice",
+				constants.ApprovalProcessingAnnotation: constants.ApprovalProcessingPending,
+			})
+
+			_, err := (&ApprovalingHandler{}).Handle(scaleCtx)
+			Expect(err).NotTo(HaveOccurred())
+
+			data := NewNotificationService(scaleCtx.Client).prepareTemplateData(scaleCtx, "approved")
+			Expect(data.RequestedBy).To(Equal("bob"))
+			Expect(data.Operator).To(Equal("alice"))
+		})
+	})
+
+	Describe("Retry policy", func() {
+		var (
+			alertScale *opsv1beta1.AlertScale
+			scaleCtx   *types.ScaleContext
+			workload   *fakeWorkload
+		)
+
+		buildContext := func(status opsv1beta1.ScaleStatus, retryPolicy *opsv1beta1.RetryPolicy) {
+			targetReplicas := int32(6)
+			status.OriginReplicas = 2
+			status.TargetReplicas = &targetReplicas
+			alertScale = &opsv1beta1.AlertScale{
+				ObjectMeta: metav1.ObjectMeta{Name: "retry-scale", Namespace: "default"},
+				Spec: opsv1beta1.AlertScaleSpec{
+					ScaleTimeout:  "10m",
+					ScaleUpPolicy: &opsv1beta1.StepPolicy{ReplicasPerStep: 1, WaitForReady: true},
+					RetryPolicy:   retryPolicy,
+					ScaleTarget:   opsv1beta1.ScaleTarget{Kind: "Deployment", Name: "web-app", Namespace: "default"},
+				},
+				Status: opsv1beta1.AlertScaleStatus{ScaleStatus: status},
+			}
+
+			scheme := runtime.NewScheme()
+			Expect(opsv1beta1.AddToScheme(scheme)).To(Succeed())
+			Expect(autoscalingv2.AddToScheme(scheme)).To(Succeed())
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(alertScale).
+				WithStatusSubresource(alertScale).
+				Build()
+
+			workload = &fakeWorkload{replicas: 2, available: 2}
+			scaleCtx = &types.ScaleContext{
+				AlertScale:    alertScale,
+				Client:        fakeClient,
+				Request:       ctrl.Request{NamespacedName: client.ObjectKeyFromObject(alertScale)},
+				Context:       context.Background(),
+				ScaleStrategy: workload,
+			}
+		}
+
+		timedOut := func(attempts int32) opsv1beta1.ScaleStatus {
+			return opsv1beta1.ScaleStatus{
+				Status:           types.ScaleStatusScaling,
+				Attempts:         attempts,
+				ScalingBeginTime: metav1.NewTime(time.Now().Add(-time.Hour)),
+			}
+		}
+
+		It("should re-enter Scaling after a backoff when attempts remain", func() {
+			buildContext(timedOut(2), &opsv1beta1.RetryPolicy{MaxAttempts: 3, Backoff: "1m"})
+
+			result, err := (&ScalingHandler{}).Handle(scaleCtx)
+			Expect(err).NotTo(HaveOccurred())
+
+			status := alertScale.Status.ScaleStatus
+			Expect(status.Status).To(Equal(types.ScaleStatusScaling))
+			Expect(status.Attempts).To(Equal(int32(3)))
+			Expect(status.ScaleUpProgress).To(BeNil())
+			Expect(status.NextRetryTime).NotTo(BeNil())
+			// 第二次重试的退避时间翻倍
+			Expect(status.NextRetryTime.Time).To(BeTemporally("~", time.Now().Add(2*time.Minute), 5*time.Second))
+			Expect(result.RequeueAfter).To(BeNumerically("~", 2*time.Minute, 5*time.Second))
+			Expect(alertScale.Status.History[len(alertScale.Status.History)-1].Reason).To(Equal(types.ReasonScaleRetry))
+		})
+
+		It("should fail once all attempts are used", func() {
+			buildContext(timedOut(3), &opsv1beta1.RetryPolicy{MaxAttempts: 3, Backoff: "1m"})
+
+			_, err := (&ScalingHandler{}).Handle(scaleCtx)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(alertScale.Status.ScaleStatus.Status).To(Equal(types.ScaleStatusFailed))
+			Expect(alertScale.Status.ScaleStatus.NextRetryTime).To(BeNil())
+		})
+
+		It("should fail on the first timeout without a retry policy", func() {
+			buildContext(timedOut(1), nil)
+
+			_, err := (&ScalingHandler{}).Handle(scaleCtx)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(alertScale.Status.ScaleStatus.Status).To(Equal(types.ScaleStatusFailed))
+		})
+
+		It("should not touch the workload during the backoff", func() {
+			status := timedOut(2)
+			nextRetryTime := metav1.NewTime(time.Now().Add(time.Minute))
+			status.NextRetryTime = &nextRetryTime
+			buildContext(status, &opsv1beta1.RetryPolicy{MaxAttempts: 3})
+
+			result, err := (&ScalingHandler{}).Handle(scaleCtx)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(workload.replicas).To(Equal(int32(2)))
+			Expect(result.RequeueAfter).To(BeNumerically(">", 50*time.Second))
+		})
+
+		It("should start the next attempt with a fresh timeout once the backoff elapsed", func() {
+			status := timedOut(2)
+			nextRetryTime := metav1.NewTime(time.Now().Add(-time.Second))
+			status.NextRetryTime = &nextRetryTime
+			buildContext(status, &opsv1beta1.RetryPolicy{MaxAttempts: 3})
+
+			_, err := (&ScalingHandler{}).Handle(scaleCtx)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(alertScale.Status.ScaleStatus.Status).To(Equal(types.ScaleStatusScaling))
+			Expect(alertScale.Status.ScaleStatus.NextRetryTime).To(BeNil())
+			Expect(alertScale.Status.ScaleStatus.ScalingBeginTime.Time).To(BeTemporally("~", time.Now(), 5*time.Second))
+			Expect(workload.replicas).To(Equal(int32(3)))
+		})
+
+		It("should archive a failed AlertScale once the workload is restored", func() {
+			buildContext(opsv1beta1.ScaleStatus{Status: types.ScaleStatusFailed}, nil)
+			workload.replicas, workload.available = 6, 6
+
+			_, err := (&FailedHandler{}).Handle(scaleCtx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(workload.replicas).To(Equal(int32(2)))
+			Expect(alertScale.Status.ScaleStatus.Status).To(Equal(types.ScaleStatusFailed))
+
+			workload.available = 2
+			_, err = (&FailedHandler{}).Handle(scaleCtx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(alertScale.Status.ScaleStatus.Status).To(Equal(types.ScaleStatusArchived))
+		})
+	})
+
+	Describe("Deletion", func() {
+		var (
+			alertScale *opsv1beta1.AlertScale
+			scaleCtx   *types.ScaleContext
+			workload   *fakeWorkload
+		)
+
+		buildContext := func(status opsv1beta1.ScaleStatus) {
+			now := metav1.Now()
+			alertScale = &opsv1beta1.AlertScale{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "deleted-scale",
+					Namespace:         "default",
+					DeletionTimestamp: &now,
+					Finalizers:        []string{constants.AlertScaleFinalizer},
+				},
+				Spec: opsv1beta1.AlertScaleSpec{
+					ScaleTarget: opsv1beta1.ScaleTarget{Kind: "Deployment", Name: "web-app", Namespace: "default"},
+				},
+				Status: opsv1beta1.AlertScaleStatus{ScaleStatus: status},
+			}
+
+			scheme := runtime.NewScheme()
+			Expect(opsv1beta1.AddToScheme(scheme)).To(Succeed())
+			Expect(autoscalingv2.AddToScheme(scheme)).To(Succeed())
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(alertScale).
+				WithStatusSubresource(alertScale).
+				Build()
+
+			workload = &fakeWorkload{replicas: 6, available: 6}
+			scaleCtx = &types.ScaleContext{
+				AlertScale:    alertScale,
+				Client:        fakeClient,
+				Request:       ctrl.Request{NamespacedName: client.ObjectKeyFromObject(alertScale)},
+				Context:       context.Background(),
+				ScaleStrategy: workload,
+			}
+		}
+
+		expectReleased := func() {
+			err := scaleCtx.Client.Get(context.Background(), client.ObjectKeyFromObject(alertScale), &opsv1beta1.AlertScale{})
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		}
+
+		It("should restore the origin replicas of a scaled AlertScale", func() {
+			targetReplicas := int32(6)
+			buildContext(opsv1beta1.ScaleStatus{
+				Status:         types.ScaleStatusScaled,
+				OriginReplicas: 2,
+				TargetReplicas: &targetReplicas,
+			})
+
+			_, err := (&DeletionHandler{}).Handle(scaleCtx)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(workload.replicas).To(Equal(int32(2)))
+			expectReleased()
+		})
+
+		It("should release an AlertScale that never scaled without touching the workload", func() {
+			buildContext(opsv1beta1.ScaleStatus{
+				Status:         types.ScaleStatusApprovaling,
+				OriginReplicas: 2,
+			})
+
+			_, err := (&DeletionHandler{}).Handle(scaleCtx)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(workload.replicas).To(Equal(int32(6)))
+			expectReleased()
+		})
+	})
+
+	Describe("Dry run", func() {
+		var (
+			alertScale *opsv1beta1.AlertScale
+			fakeClient client.Client
+			workload   *fakeWorkload
+			hpa        *autoscalingv2.HorizontalPodAutoscaler
+			scaleCtx   *types.ScaleContext
+		)
+
+		BeforeEach(func() {
+			workload = &fakeWorkload{replicas: 2, available: 2}
+			targetReplicas := int32(8)
+			alertScale = &opsv1beta1.AlertScale{
+				ObjectMeta: metav1.ObjectMeta{Name: "dry-run-scale", Namespace: "default"},
+				Spec: opsv1beta1.AlertScaleSpec{
+					ScaleTimeout: "10m",
+					ScaleTarget:  opsv1beta1.ScaleTarget{Kind: "Deployment", Name: "web-app", Namespace: "default"},
+					DryRun:       true,
+				},
+				Status: opsv1beta1.AlertScaleStatus{
+					ScaleStatus: opsv1beta1.ScaleStatus{
+						Status:           types.ScaleStatusScaling,
+						OriginReplicas:   2,
+						TargetReplicas:   &targetReplicas,
+						ScalingBeginTime: metav1.Now(),
+					},
+				},
+			}
+			minReplicas := int32(2)
+			hpa = &autoscalingv2.HorizontalPodAutoscaler{
+				ObjectMeta: metav1.ObjectMeta{Name: "web-app", Namespace: "default"},
+				Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+					ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{Kind: "Deployment", Name: "web-app", APIVersion: "apps/v1"},
+					MinReplicas:    &minReplicas,
+					MaxReplicas:    5,
+				},
+			}
+
+			scheme := runtime.NewScheme()
+			Expect(opsv1beta1.AddToScheme(scheme)).To(Succeed())
+			Expect(autoscalingv2.AddToScheme(scheme)).To(Succeed())
+			fakeClient = fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(alertScale, hpa).
+				WithStatusSubresource(alertScale).
+				Build()
+
+			scaleCtx = &types.ScaleContext{
+				AlertScale:    alertScale,
+				Client:        fakeClient,
+				Request:       ctrl.Request{NamespacedName: client.ObjectKeyFromObject(alertScale)},
+				Context:       context.Background(),
+				ScaleStrategy: strategy.NewDryRunStrategy(workload, alertScale),
+			}
+		})
+
+		It("should record the plan instead of scaling the workload and its HPA", func() {
+			_, err := (&ScalingHandler{}).Handle(scaleCtx)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(workload.replicas).To(Equal(int32(2)))
+			current := &autoscalingv2.HorizontalPodAutoscaler{}
+			Expect(fakeClient.Get(context.Background(), client.ObjectKeyFromObject(hpa), current)).To(Succeed())
+			Expect(*current.Spec.MinReplicas).To(Equal(int32(2)))
+			Expect(current.Spec.MaxReplicas).To(Equal(int32(5)))
+			Expect(alertScale.Status.OriginHPA).To(BeNil())
+
+			plan := alertScale.Status.Plan
+			Expect(plan).To(HaveLen(2))
+			Expect(plan[0].Action).To(Equal(types.PlannedActionRaiseHPA))
+			Expect(plan[1].Action).To(Equal(types.PlannedActionScale))
+			Expect(plan[1].Replicas).To(Equal(int32(8)))
+			Expect(plan[1].Status).To(Equal(types.ScaleStatusScaling))
+		})
+
+		It("should run through Scaled and restore to Archived against the plan", func() {
+			_, err := (&ScalingHandler{}).Handle(scaleCtx)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = (&ScalingHandler{}).Handle(scaleCtx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(alertScale.Status.ScaleStatus.Status).To(Equal(types.ScaleStatusScaled))
+			Expect(alertScale.Status.ScaleStatus.ScaledReplicas).To(Equal(int32(8)))
+
+			alertScale.Status.ScaleStatus.Status = types.ScaleStatusCompleted
+			Expect(fakeClient.Status().Update(context.Background(), alertScale)).To(Succeed())
+			_, err = (&CompletedHandler{}).Handle(scaleCtx)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = (&CompletedHandler{}).Handle(scaleCtx)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(alertScale.Status.ScaleStatus.Status).To(Equal(types.ScaleStatusArchived))
+			Expect(workload.replicas).To(Equal(int32(2)))
+			plan := alertScale.Status.Plan
+			Expect(plan[len(plan)-1].Action).To(Equal(types.PlannedActionScale))
+			Expect(plan[len(plan)-1].Replicas).To(Equal(int32(2)))
+		})
+
+		It("should include the plan in the default notification", func() {
+			_, err := (&ScalingHandler{}).Handle(scaleCtx)
+			Expect(err).NotTo(HaveOccurred())
+
+			service := NewNotificationService(fakeClient)
+			data := service.prepareTemplateData(scaleCtx, "scaled")
+			Expect(data.DryRun).To(BeTrue())
+			Expect(data.Plan).To(HaveLen(2))
+
+			message := service.renderDefaultMessage(data)
+			Expect(message).To(ContainSubstring("试运行"))
+			Expect(message).To(ContainSubstring("Scale Deployment default/web-app to 8 replicas"))
+		})
+	})
 })
+
+// fakeWorkload 模拟工作负载副本数的扩缩容策略
+type fakeWorkload struct {
+	replicas  int32
+	available int32
+}
+
+func (w *fakeWorkload) Scale(_ context.Context, _ client.Client, _ *opsv1beta1.ScaleTarget, replicas int32) error {
+	w.replicas = replicas
+	return nil
+}
+
+func (w *fakeWorkload) GetCurrentReplicas(_ context.Context, _ client.Client, _ *opsv1beta1.ScaleTarget) (int32, error) {
+	return w.replicas, nil
+}
+
+func (w *fakeWorkload) GetAvailableReplicas(_ context.Context, _ client.Client, _ *opsv1beta1.ScaleTarget) (int32, error) {
+	return w.available, nil
+}
+
+// fakeGroup 按目标名称模拟多个工作负载的扩缩容策略，failures 中的目标调整副本数时返回错误
+type fakeGroup struct {
+	workloads map[string]*fakeWorkload
+	failures  map[string]error
+}
+
+func (g *fakeGroup) Scale(ctx context.Context, c client.Client, target *opsv1beta1.ScaleTarget, replicas int32) error {
+	if err := g.failures[target.Name]; err != nil {
+		return err
+	}
+	return g.workloads[target.Name].Scale(ctx, c, target, replicas)
+}
+
+func (g *fakeGroup) GetCurrentReplicas(ctx context.Context, c client.Client, target *opsv1beta1.ScaleTarget) (int32, error) {
+	return g.workloads[target.Name].GetCurrentReplicas(ctx, c, target)
+}
+
+func (g *fakeGroup) GetAvailableReplicas(ctx context.Context, c client.Client, target *opsv1beta1.ScaleTarget) (int32, error) {
+	return g.workloads[target.Name].GetAvailableReplicas(ctx, c, target)
+}
+
+// fakeResources 记录容器资源的纵向扩缩容策略
+type fakeResources struct {
+	containers []opsv1beta1.ContainerResources
+	rolledOut  bool
+	sets       int
+}
+
+func (r *fakeResources) GetResources(_ context.Context, _ client.Client, _ *opsv1beta1.ScaleTarget) ([]opsv1beta1.ContainerResources, error) {
+	result := make([]opsv1beta1.ContainerResources, len(r.containers))
+	for i := range r.containers {
+		result[i] = *r.containers[i].DeepCopy()
+	}
+	return result, nil
+}
+
+func (r *fakeResources) SetResources(_ context.Context, _ client.Client, _ *opsv1beta1.ScaleTarget, resources []opsv1beta1.ContainerResources) error {
+	r.sets++
+	for _, desired := range resources {
+		for i := range r.containers {
+			if r.containers[i].Name == desired.Name {
+				r.containers[i].Resources = *desired.Resources.DeepCopy()
+			}
+		}
+	}
+	return nil
+}
+
+func (r *fakeResources) IsRolledOut(_ context.Context, _ client.Client, _ *opsv1beta1.ScaleTarget) (bool, error) {
+	return r.rolledOut, nil
+}
@@ -0,0 +1,149 @@
+package handler
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	opsv1beta1 "udesk.cn/ops/api/v1beta1"
+	"udesk.cn/ops/internal/strategy"
+	"udesk.cn/ops/internal/types"
+)
+
+// 纵向扩缩容在 Scaling 状态将 spec.verticalScale 中的资源应用到 Pod 模板，
+// 在 Completed、Failed 和删除时恢复 status.originResources 中记录的原始资源。
+// 同一工作负载上的多个纵向 AlertScale 按与副本数相同的规则合并：
+// 后创建的继承原始资源，先结束的重新应用仍在生效的 AlertScale 的资源，由最后结束的恢复原始资源
+
+// applyVerticalScale 快照原始资源后应用目标资源，返回滚动更新是否已完成
+func (h *BaseStateHandler) applyVerticalScale(ctx *types.ScaleContext) (bool, error) {
+	target := &ctx.AlertScale.Spec.ScaleTarget
+	current, err := ctx.ResourceStrategy.GetResources(ctx.Context, ctx.Client, target)
+	if err != nil {
+		return false, err
+	}
+	current, err = selectContainers(current, ctx.AlertScale.Spec.VerticalScale)
+	if err != nil {
+		return false, err
+	}
+
+	// 修改前先持久化原始资源，保证控制器重启后仍能恢复
+	// 同一工作负载上已有生效的纵向 AlertScale 时资源已被调整，继承其记录的原始资源
+	if ctx.AlertScale.Status.OriginResources == nil {
+		origin := current
+		overlapping, err := h.overlappingScales(ctx, inheritableStatuses)
+		if err != nil {
+			return false, err
+		}
+		for i := len(overlapping) - 1; i >= 0; i-- {
+			origin = inheritResources(origin, overlapping[i].Status.OriginResources)
+		}
+		ctx.AlertScale.Status.OriginResources = origin
+		if err := ctx.Client.Status().Update(ctx.Context, ctx.AlertScale); err != nil {
+			return false, err
+		}
+	}
+
+	desired := strategy.OverrideResources(current, ctx.AlertScale.Spec.VerticalScale.Containers)
+	if !equality.Semantic.DeepEqual(current, desired) {
+		if err := ctx.ResourceStrategy.SetResources(ctx.Context, ctx.Client, target, desired); err != nil {
+			return false, err
+		}
+	}
+	return ctx.ResourceStrategy.IsRolledOut(ctx.Context, ctx.Client, target)
+}
+
+// restoreVertical 恢复原始资源，返回滚动更新是否已完成以及仍在生效的纵向 AlertScale；
+// 仍有其他生效的纵向 AlertScale 时，在原始资源上重新应用它们的资源
+func (h *BaseStateHandler) restoreVertical(ctx *types.ScaleContext) (bool, []opsv1beta1.AlertScale, error) {
+	holders, err := h.overlappingScales(ctx, holdingStatuses)
+	if err != nil {
+		return false, nil, err
+	}
+
+	// 尚未修改过资源时无需恢复
+	restore := ctx.AlertScale.Status.OriginResources
+	if len(restore) == 0 {
+		return true, holders, nil
+	}
+	for _, holder := range holders {
+		if holder.Spec.VerticalScale != nil {
+			restore = strategy.OverrideResources(restore, holder.Spec.VerticalScale.Containers)
+		}
+	}
+
+	target := &ctx.AlertScale.Spec.ScaleTarget
+	current, err := ctx.ResourceStrategy.GetResources(ctx.Context, ctx.Client, target)
+	if err != nil {
+		return false, holders, err
+	}
+	if !equality.Semantic.DeepEqual(inheritResources(current, restore), current) {
+		if err := ctx.ResourceStrategy.SetResources(ctx.Context, ctx.Client, target, restore); err != nil {
+			return false, holders, err
+		}
+	}
+
+	rolledOut, err := ctx.ResourceStrategy.IsRolledOut(ctx.Context, ctx.Client, target)
+	return rolledOut, holders, err
+}
+
+// selectContainers 返回 verticalScale 中列出的容器的当前资源，容器不存在时返回错误
+func selectContainers(resources []opsv1beta1.ContainerResources, verticalScale *opsv1beta1.VerticalScale) ([]opsv1beta1.ContainerResources, error) {
+	if verticalScale == nil || len(verticalScale.Containers) == 0 {
+		return nil, fmt.Errorf("spec.verticalScale.containers is required for vertical scaling")
+	}
+
+	selected := make([]opsv1beta1.ContainerResources, 0, len(verticalScale.Containers))
+	for _, container := range verticalScale.Containers {
+		found := false
+		for _, current := range resources {
+			if current.Name == container.Name {
+				selected = append(selected, current)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("container %s not found in the pod template", container.Name)
+		}
+	}
+	return selected, nil
+}
+
+// inheritResources 用 inherited 中同名容器的资源替换 resources 中的值
+func inheritResources(resources, inherited []opsv1beta1.ContainerResources) []opsv1beta1.ContainerResources {
+	result := make([]opsv1beta1.ContainerResources, len(resources))
+	for i, container := range resources {
+		result[i] = *container.DeepCopy()
+		for _, other := range inherited {
+			if other.Name == container.Name {
+				result[i].Resources = *other.Resources.DeepCopy()
+				break
+			}
+		}
+	}
+	return result
+}
+
+// scaleGoal 描述扩容目标，用于状态说明
+func scaleGoal(alertScale *opsv1beta1.AlertScale, targetReplicas int32) string {
+	if types.IsGroup(alertScale) {
+		return fmt.Sprintf("%d replicas across %d targets", targetReplicas, len(alertScale.Status.Targets))
+	}
+	if !types.IsVertical(alertScale) {
+		return fmt.Sprintf("%d replicas", targetReplicas)
+	}
+	return "raised resources of containers " + verticalContainerNames(alertScale)
+}
+
+// verticalContainerNames 返回纵向扩缩容调整的容器名
+func verticalContainerNames(alertScale *opsv1beta1.AlertScale) string {
+	if alertScale.Spec.VerticalScale == nil {
+		return ""
+	}
+	names := make([]string, 0, len(alertScale.Spec.VerticalScale.Containers))
+	for _, container := range alertScale.Spec.VerticalScale.Containers {
+		names = append(names, container.Name)
+	}
+	return strings.Join(names, ", ")
+}
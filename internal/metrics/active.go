@@ -0,0 +1,75 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	opsv1beta1 "udesk.cn/ops/api/v1beta1"
+	"udesk.cn/ops/internal/types"
+)
+
+// collectTimeout 采集时列出 AlertScale 的超时时间
+const collectTimeout = 5 * time.Second
+
+// inactiveStatuses 已结束的状态，不计入生效中的 AlertScale
+var inactiveStatuses = map[string]bool{
+	types.ScaleStatusArchived: true,
+	types.ScaleStatusRejected: true,
+	types.ScaleStatusFailed:   true,
+}
+
+var activeDesc = prometheus.NewDesc(
+	"alertscale_active",
+	"Number of AlertScales that have not finished, by namespace, target kind and status.",
+	[]string{"namespace", "kind", "status"},
+	nil,
+)
+
+// activeCollector 采集时从缓存统计生效中的 AlertScale，删除的对象不会残留在指标中
+type activeCollector struct {
+	reader client.Reader
+}
+
+// NewActiveCollector 创建统计生效中 AlertScale 的采集器，reader 通常为 manager 的缓存客户端
+func NewActiveCollector(reader client.Reader) prometheus.Collector {
+	return &activeCollector{reader: reader}
+}
+
+// RegisterActiveCollector 向 controller-runtime 的指标注册表注册生效中 AlertScale 的采集器
+func RegisterActiveCollector(reader client.Reader) error {
+	return metrics.Registry.Register(NewActiveCollector(reader))
+}
+
+func (c *activeCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- activeDesc
+}
+
+func (c *activeCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), collectTimeout)
+	defer cancel()
+
+	alertScaleList := &opsv1beta1.AlertScaleList{}
+	if err := c.reader.List(ctx, alertScaleList); err != nil {
+		logf.Log.WithName("metrics").Error(err, "Failed to list AlertScales for metrics")
+		return
+	}
+
+	type key struct{ namespace, kind, status string }
+	counts := map[key]int{}
+	for _, alertScale := range alertScaleList.Items {
+		status := alertScale.Status.ScaleStatus.Status
+		if inactiveStatuses[status] {
+			continue
+		}
+		counts[key{alertScale.Namespace, alertScale.Spec.ScaleTarget.Kind, status}]++
+	}
+
+	for k, count := range counts {
+		ch <- prometheus.MustNewConstMetric(activeDesc, prometheus.GaugeValue, float64(count), k.namespace, k.kind, k.status)
+	}
+}
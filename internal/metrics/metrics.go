@@ -0,0 +1,106 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	opsv1beta1 "udesk.cn/ops/api/v1beta1"
+	"udesk.cn/ops/internal/types"
+)
+
+// durationBuckets 覆盖 1 秒到约 9 小时，审批等待与扩容收敛共用
+var durationBuckets = prometheus.ExponentialBuckets(1, 2, 16)
+
+var (
+	// transitionsTotal 按状态对统计的状态切换次数
+	transitionsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "alertscale_transitions_total",
+			Help: "Total number of AlertScale status transitions by from and to status.",
+		},
+		[]string{"from", "to"},
+	)
+
+	// stateDurationSeconds 离开某个状态时在该状态停留的时间
+	stateDurationSeconds = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "alertscale_state_duration_seconds",
+			Help:    "Time an AlertScale spent in a status before leaving it.",
+			Buckets: durationBuckets,
+		},
+		[]string{"state"},
+	)
+
+	// approvalLatencySeconds 从 Approvaling 到 Approved/Rejected 的审批耗时
+	approvalLatencySeconds = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "alertscale_approval_latency_seconds",
+			Help:    "Time from Approvaling to Approved or Rejected.",
+			Buckets: durationBuckets,
+		},
+		[]string{"result"},
+	)
+
+	// scaleConvergenceSeconds 从 Scaling 到 Scaled 的扩容收敛耗时
+	scaleConvergenceSeconds = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "alertscale_scale_convergence_seconds",
+			Help:    "Time from Scaling to Scaled, i.e. until the workload reached the target replicas.",
+			Buckets: durationBuckets,
+		},
+		[]string{"kind"},
+	)
+
+	// restoreFailuresTotal 恢复原始副本数或 HPA 边界失败的次数
+	restoreFailuresTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "alertscale_restore_failures_total",
+			Help: "Total number of failed attempts to restore the original replicas or HPA bounds.",
+		},
+		[]string{"namespace", "kind"},
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(
+		transitionsTotal,
+		stateDurationSeconds,
+		approvalLatencySeconds,
+		scaleConvergenceSeconds,
+		restoreFailuresTotal,
+	)
+}
+
+// ObserveTransition 记录一次状态切换，需在追加状态切换记录之前调用，
+// 以最近一条记录的时间作为进入原状态的时间，没有记录时使用创建时间
+func ObserveTransition(alertScale *opsv1beta1.AlertScale, from, to string, now time.Time) {
+	transitionsTotal.WithLabelValues(from, to).Inc()
+	if from == "" {
+		return
+	}
+
+	enteredAt := alertScale.CreationTimestamp.Time
+	if history := alertScale.Status.History; len(history) > 0 {
+		enteredAt = history[len(history)-1].Time.Time
+	}
+	if enteredAt.IsZero() {
+		return
+	}
+	seconds := now.Sub(enteredAt).Seconds()
+	stateDurationSeconds.WithLabelValues(from).Observe(seconds)
+
+	switch {
+	case from == types.ScaleStatusApprovaling && (to == types.ScaleStatusApproved || to == types.ScaleStatusRejected):
+		approvalLatencySeconds.WithLabelValues(to).Observe(seconds)
+	case from == types.ScaleStatusScaling && to == types.ScaleStatusScaled && !alertScale.Spec.DryRun:
+		// 试运行不等待工作负载就绪，不计入扩容收敛耗时
+		scaleConvergenceSeconds.WithLabelValues(alertScale.Spec.ScaleTarget.Kind).Observe(seconds)
+	}
+}
+
+// ObserveRestoreFailure 记录一次恢复失败
+func ObserveRestoreFailure(alertScale *opsv1beta1.AlertScale) {
+	restoreFailuresTotal.WithLabelValues(alertScale.Namespace, alertScale.Spec.ScaleTarget.Kind).Inc()
+}
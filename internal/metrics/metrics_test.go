@@ -0,0 +1,160 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	opsv1beta1 "udesk.cn/ops/api/v1beta1"
+	"udesk.cn/ops/internal/types"
+)
+
+// metricValue 返回注册表中指定指标的值，直方图返回样本数，不存在时返回 0
+func metricValue(gatherer prometheus.Gatherer, name string, labels map[string]string) float64 {
+	families, err := gatherer.Gather()
+	Expect(err).NotTo(HaveOccurred())
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			matched := 0
+			for _, label := range metric.GetLabel() {
+				if labels[label.GetName()] == label.GetValue() {
+					matched++
+				}
+			}
+			if matched != len(labels) {
+				continue
+			}
+			switch {
+			case metric.Counter != nil:
+				return metric.GetCounter().GetValue()
+			case metric.Gauge != nil:
+				return metric.GetGauge().GetValue()
+			case metric.Histogram != nil:
+				return float64(metric.GetHistogram().GetSampleCount())
+			}
+		}
+	}
+	return 0
+}
+
+var _ = Describe("Metrics", func() {
+	var (
+		alertScale *opsv1beta1.AlertScale
+		now        time.Time
+	)
+
+	BeforeEach(func() {
+		now = time.Now()
+		alertScale = &opsv1beta1.AlertScale{
+			ObjectMeta: metav1.ObjectMeta{Name: "metrics-scale", Namespace: "metrics"},
+			Spec: opsv1beta1.AlertScaleSpec{
+				ScaleTarget: opsv1beta1.ScaleTarget{Kind: "StatefulSet", Name: "db"},
+			},
+		}
+	})
+
+	enter := func(status string, at time.Time) {
+		alertScale.Status.History = append(alertScale.Status.History, opsv1beta1.TransitionRecord{To: status, Time: metav1.NewTime(at)})
+	}
+
+	It("should count transitions per state pair", func() {
+		labels := map[string]string{"from": types.ScaleStatusPending, "to": types.ScaleStatusApprovaling}
+		before := metricValue(metrics.Registry, "alertscale_transitions_total", labels)
+
+		enter(types.ScaleStatusPending, now.Add(-time.Second))
+		ObserveTransition(alertScale, types.ScaleStatusPending, types.ScaleStatusApprovaling, now)
+
+		Expect(metricValue(metrics.Registry, "alertscale_transitions_total", labels)).To(Equal(before + 1))
+		Expect(metricValue(metrics.Registry, "alertscale_state_duration_seconds", map[string]string{"state": types.ScaleStatusPending})).To(BeNumerically(">=", 1))
+	})
+
+	It("should observe approval latency", func() {
+		labels := map[string]string{"result": types.ScaleStatusRejected}
+		before := metricValue(metrics.Registry, "alertscale_approval_latency_seconds", labels)
+
+		enter(types.ScaleStatusApprovaling, now.Add(-10*time.Minute))
+		ObserveTransition(alertScale, types.ScaleStatusApprovaling, types.ScaleStatusRejected, now)
+
+		Expect(metricValue(metrics.Registry, "alertscale_approval_latency_seconds", labels)).To(Equal(before + 1))
+	})
+
+	It("should observe scale convergence time by target kind", func() {
+		labels := map[string]string{"kind": "StatefulSet"}
+		before := metricValue(metrics.Registry, "alertscale_scale_convergence_seconds", labels)
+
+		enter(types.ScaleStatusScaling, now.Add(-time.Minute))
+		ObserveTransition(alertScale, types.ScaleStatusScaling, types.ScaleStatusScaled, now)
+
+		Expect(metricValue(metrics.Registry, "alertscale_scale_convergence_seconds", labels)).To(Equal(before + 1))
+	})
+
+	It("should count failed restores", func() {
+		labels := map[string]string{"namespace": "metrics", "kind": "StatefulSet"}
+		before := metricValue(metrics.Registry, "alertscale_restore_failures_total", labels)
+
+		ObserveRestoreFailure(alertScale)
+
+		Expect(metricValue(metrics.Registry, "alertscale_restore_failures_total", labels)).To(Equal(before + 1))
+	})
+
+	It("should report active AlertScales by namespace, kind and status", func() {
+		newAlertScale := func(name, kind, status string) client.Object {
+			return &opsv1beta1.AlertScale{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+				Spec:       opsv1beta1.AlertScaleSpec{ScaleTarget: opsv1beta1.ScaleTarget{Kind: kind}},
+				Status:     opsv1beta1.AlertScaleStatus{ScaleStatus: opsv1beta1.ScaleStatus{Status: status}},
+			}
+		}
+
+		scheme := runtime.NewScheme()
+		Expect(opsv1beta1.AddToScheme(scheme)).To(Succeed())
+		fakeClient := fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(
+				newAlertScale("a", "Deployment", types.ScaleStatusApprovaling),
+				newAlertScale("b", "Deployment", types.ScaleStatusApprovaling),
+				newAlertScale("c", "StatefulSet", types.ScaleStatusScaled),
+				newAlertScale("d", "Deployment", types.ScaleStatusArchived),
+			).
+			Build()
+
+		registry := prometheus.NewRegistry()
+		Expect(registry.Register(NewActiveCollector(fakeClient))).To(Succeed())
+
+		Expect(metricValue(registry, "alertscale_active", map[string]string{
+			"namespace": "default", "kind": "Deployment", "status": types.ScaleStatusApprovaling,
+		})).To(Equal(2.0))
+		Expect(metricValue(registry, "alertscale_active", map[string]string{
+			"namespace": "default", "kind": "StatefulSet", "status": types.ScaleStatusScaled,
+		})).To(Equal(1.0))
+		Expect(metricValue(registry, "alertscale_active", map[string]string{
+			"status": types.ScaleStatusArchived,
+		})).To(BeZero())
+	})
+})
@@ -0,0 +1,139 @@
+package policy
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/cel-go/cel"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/lru"
+	opsv1beta1 "udesk.cn/ops/api/v1beta1"
+)
+
+// approvalRuleCostLimit 单条自动审批规则一次求值的成本上限，防止表达式遍历过大的对象
+const approvalRuleCostLimit = 1000000
+
+// approvalEnv 自动审批规则的 CEL 环境，所有规则共用
+var approvalEnv = sync.OnceValues(func() (*cel.Env, error) {
+	return cel.NewEnv(
+		cel.Variable("alertScale", cel.MapType(cel.StringType, cel.DynType)),
+		cel.Variable("target", cel.MapType(cel.StringType, cel.DynType)),
+		cel.Variable("namespaceName", cel.StringType),
+		cel.Variable("originReplicas", cel.IntType),
+		cel.Variable("targetReplicas", cel.IntType),
+		cel.Variable("delta", cel.IntType),
+		cel.Variable("now", cel.TimestampType),
+	)
+})
+
+// approvalProgramCacheSize 缓存的表达式数量上限，表达式由用户填写，超出后淘汰最久未使用的编译结果
+const approvalProgramCacheSize = 256
+
+// approvalPrograms 按表达式缓存编译结果，避免每次调和都重新编译；cel.Program 可以并发求值
+var approvalPrograms = lru.New(approvalProgramCacheSize)
+
+// compiledRule 表达式的编译结果，编译失败的表达式同样缓存错误
+type compiledRule struct {
+	program cel.Program
+	err     error
+}
+
+// ApprovalInput 自动审批规则求值时可用的变量
+type ApprovalInput struct {
+	AlertScale *opsv1beta1.AlertScale
+	// Target 扩缩容目标对象，多目标 AlertScale 或目标无法读取时为空
+	Target map[string]any
+	Now    time.Time
+}
+
+// CompileApprovalRule 编译自动审批规则，表达式必须返回 bool
+func CompileApprovalRule(expression string) (cel.Program, error) {
+	env, err := approvalEnv()
+	if err != nil {
+		return nil, err
+	}
+	ast, issues := env.Compile(expression)
+	if issues != nil && issues.Err() != nil {
+		return nil, issues.Err()
+	}
+	if ast.OutputType() != cel.BoolType && ast.OutputType() != cel.DynType {
+		return nil, fmt.Errorf("expression must return bool, got %s", ast.OutputType())
+	}
+	return env.Program(ast, cel.CostLimit(approvalRuleCostLimit))
+}
+
+// approvalProgram 返回表达式缓存的编译结果，未缓存时编译并缓存
+func approvalProgram(expression string) (cel.Program, error) {
+	if cached, ok := approvalPrograms.Get(expression); ok {
+		rule := cached.(compiledRule)
+		return rule.program, rule.err
+	}
+	program, err := CompileApprovalRule(expression)
+	approvalPrograms.Add(expression, compiledRule{program: program, err: err})
+	return program, err
+}
+
+// MatchApprovalRules 按顺序求值自动审批规则，返回第一条结果为 true 的规则名称，没有规则匹配时返回空字符串。
+// 编译或求值失败的规则视为不匹配，错误合并返回，其余规则照常求值
+func MatchApprovalRules(rules []opsv1beta1.AutoApprovalRule, input ApprovalInput) (string, error) {
+	if len(rules) == 0 {
+		return "", nil
+	}
+	activation, err := approvalActivation(input)
+	if err != nil {
+		return "", err
+	}
+
+	var errs []error
+	for _, rule := range rules {
+		program, err := approvalProgram(rule.Expression)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("rule %s: %w", rule.Name, err))
+			continue
+		}
+		out, _, err := program.Eval(activation)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("rule %s: %w", rule.Name, err))
+			continue
+		}
+		matched, ok := out.Value().(bool)
+		if !ok {
+			errs = append(errs, fmt.Errorf("rule %s: expression returned %s instead of bool", rule.Name, out.Type().TypeName()))
+			continue
+		}
+		if matched {
+			return rule.Name, errors.Join(errs...)
+		}
+	}
+	return "", errors.Join(errs...)
+}
+
+// approvalActivation 构造规则求值的变量，副本数取进入 Pending 时确定的原始和目标副本数
+func approvalActivation(input ApprovalInput) (map[string]any, error) {
+	alertScale, err := runtime.DefaultUnstructuredConverter.ToUnstructured(input.AlertScale)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert AlertScale: %w", err)
+	}
+	target := input.Target
+	if target == nil {
+		target = map[string]any{}
+	}
+
+	status := &input.AlertScale.Status.ScaleStatus
+	originReplicas := int64(status.OriginReplicas)
+	targetReplicas := originReplicas
+	if status.TargetReplicas != nil {
+		targetReplicas = int64(*status.TargetReplicas)
+	}
+	return map[string]any{
+		"alertScale":     alertScale,
+		"target":         target,
+		"namespaceName":  input.AlertScale.Namespace,
+		"originReplicas": originReplicas,
+		"targetReplicas": targetReplicas,
+		"delta":          targetReplicas - originReplicas,
+		"now":            input.Now,
+	}, nil
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	opsv1beta1 "udesk.cn/ops/api/v1beta1"
+)
+
+var _ = Describe("Auto-approval rules", func() {
+	var (
+		alertScale *opsv1beta1.AlertScale
+		input      ApprovalInput
+	)
+
+	businessHours := `now.getDayOfWeek("Asia/Shanghai") >= 1 && now.getDayOfWeek("Asia/Shanghai") <= 5 &&
+		now.getHours("Asia/Shanghai") >= 9 && now.getHours("Asia/Shanghai") < 18`
+
+	BeforeEach(func() {
+		alertScale = &opsv1beta1.AlertScale{
+			ObjectMeta: metav1.ObjectMeta{Name: "web-scale", Namespace: "staging", Labels: map[string]string{"team": "web"}},
+			Spec: opsv1beta1.AlertScaleSpec{
+				ScaleTarget: opsv1beta1.ScaleTarget{Kind: "Deployment", Name: "web", Namespace: "staging"},
+			},
+			Status: opsv1beta1.AlertScaleStatus{
+				ScaleStatus: opsv1beta1.ScaleStatus{OriginReplicas: 4, TargetReplicas: ptr.To(int32(6))},
+			},
+		}
+		input = ApprovalInput{
+			AlertScale: alertScale,
+			Target:     map[string]any{"metadata": map[string]any{"labels": map[string]any{"tier": "frontend"}}},
+			// 2025-06-04 是周三，北京时间 10:00
+			Now: time.Date(2025, 6, 4, 2, 0, 0, 0, time.UTC),
+		}
+	})
+
+	rules := func(expressions ...string) []opsv1beta1.AutoApprovalRule {
+		result := make([]opsv1beta1.AutoApprovalRule, 0, len(expressions))
+		for i, expression := range expressions {
+			result = append(result, opsv1beta1.AutoApprovalRule{Name: []string{"first", "second", "third"}[i], Expression: expression})
+		}
+		return result
+	}
+
+	It("should reject expressions that do not compile or return bool", func() {
+		_, err := CompileApprovalRule(`delta <=`)
+		Expect(err).To(HaveOccurred())
+
+		_, err = CompileApprovalRule(`delta + 1`)
+		Expect(err).To(MatchError(ContainSubstring("must return bool")))
+
+		_, err = CompileApprovalRule(`delta <= 2 && namespaceName != "prod"`)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should approve small scale-ups outside prod", func() {
+		rule, err := MatchApprovalRules(rules(`delta <= 2 && namespaceName != "prod"`), input)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(rule).To(Equal("first"))
+
+		alertScale.Namespace = "prod"
+		rule, err = MatchApprovalRules(rules(`delta <= 2 && namespaceName != "prod"`), input)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(rule).To(BeEmpty())
+	})
+
+	It("should approve during business hours only", func() {
+		rule, err := MatchApprovalRules(rules(businessHours), input)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(rule).To(Equal("first"))
+
+		input.Now = time.Date(2025, 6, 4, 14, 0, 0, 0, time.UTC)
+		rule, err = MatchApprovalRules(rules(businessHours), input)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(rule).To(BeEmpty())
+	})
+
+	It("should expose the AlertScale and its target", func() {
+		rule, err := MatchApprovalRules(rules(
+			`alertScale.metadata.labels.team == "ops"`,
+			`alertScale.metadata.labels.team == "web" && target.metadata.labels.tier == "frontend"`,
+		), input)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(rule).To(Equal("second"))
+	})
+
+	It("should skip rules that fail to evaluate and report the error", func() {
+		rule, err := MatchApprovalRules(rules(`target.spec.replicas > 2`, `targetReplicas <= 6`), input)
+		Expect(rule).To(Equal("second"))
+		Expect(err).To(MatchError(ContainSubstring("rule first")))
+	})
+
+	It("should compile each expression only once", func() {
+		program, err := approvalProgram(`delta <= 3`)
+		Expect(err).NotTo(HaveOccurred())
+		cached, err := approvalProgram(`delta <= 3`)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cached).To(BeIdenticalTo(program))
+
+		_, err = approvalProgram(`delta <=`)
+		Expect(err).To(HaveOccurred())
+		_, err = approvalProgram(`delta <=`)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should evict the least recently used expressions", func() {
+		program, err := approvalProgram(`delta <= 4`)
+		Expect(err).NotTo(HaveOccurred())
+		for i := range approvalProgramCacheSize {
+			_, err := approvalProgram(fmt.Sprintf("delta <= %d", 100+i))
+			Expect(err).NotTo(HaveOccurred())
+		}
+		Expect(approvalPrograms.Len()).To(Equal(approvalProgramCacheSize))
+
+		recompiled, err := approvalProgram(`delta <= 4`)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(recompiled).NotTo(BeIdenticalTo(program))
+	})
+})
@@ -0,0 +1,163 @@
+package policy
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	opsv1beta1 "udesk.cn/ops/api/v1beta1"
+	"udesk.cn/ops/internal/types"
+)
+
+// ScaleGuardrail 规则名称，记录在 GuardrailViolation.Rule 中
+const (
+	RuleMaxReplicas   = "MaxReplicas"
+	RuleMaxMultiplier = "MaxMultiplier"
+	RuleMaxDuration   = "MaxDuration"
+	RuleAllowedKinds  = "AllowedKinds"
+	RuleAutoApproval  = "AutoApproval"
+)
+
+// Target 受 ScaleGuardrail 约束的扩缩容目标及其原始、目标副本数
+type Target struct {
+	ScaleTarget    opsv1beta1.ScaleTarget
+	OriginReplicas int32
+	TargetReplicas int32
+}
+
+// Check 列出 AlertScale 所在命名空间的 ScaleGuardrail 并检查 AlertScale 及其目标
+func Check(ctx context.Context, c client.Client, alertScale *opsv1beta1.AlertScale, targets []Target) ([]opsv1beta1.GuardrailViolation, error) {
+	guardrails := &opsv1beta1.ScaleGuardrailList{}
+	if err := c.List(ctx, guardrails, client.InNamespace(alertScale.Namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list ScaleGuardrails: %w", err)
+	}
+	return Evaluate(guardrails.Items, alertScale, targets), nil
+}
+
+// Evaluate 按名称顺序检查每个 ScaleGuardrail，返回所有违规项；未设置的限制不做检查
+func Evaluate(guardrails []opsv1beta1.ScaleGuardrail, alertScale *opsv1beta1.AlertScale, targets []Target) []opsv1beta1.GuardrailViolation {
+	sorted := slices.Clone(guardrails)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+
+	var violations []opsv1beta1.GuardrailViolation
+	for _, guardrail := range sorted {
+		violate := func(rule, target, format string, args ...any) {
+			violations = append(violations, opsv1beta1.GuardrailViolation{
+				Guardrail: guardrail.Name,
+				Rule:      rule,
+				Target:    target,
+				Message:   fmt.Sprintf(format, args...),
+			})
+		}
+		spec := &guardrail.Spec
+
+		autoApproval := alertScale.Spec.ScaleAutoApproval || len(alertScale.Spec.AutoApprovalRules) > 0
+		if spec.AllowAutoApproval != nil && !*spec.AllowAutoApproval && autoApproval {
+			violate(RuleAutoApproval, "", "auto-approval is not permitted")
+		}
+		if spec.MaxDuration != "" {
+			checkDuration(spec.MaxDuration, alertScale.Spec.ScaleDuration, violate)
+		}
+
+		for _, target := range targets {
+			ref := types.TargetRef(&target.ScaleTarget)
+			if len(spec.AllowedKinds) > 0 && !slices.Contains(spec.AllowedKinds, target.ScaleTarget.Kind) {
+				violate(RuleAllowedKinds, ref, "kind %s is not allowed, allowed kinds: %s",
+					target.ScaleTarget.Kind, strings.Join(spec.AllowedKinds, ", "))
+			}
+			if spec.MaxReplicas != nil && target.TargetReplicas > *spec.MaxReplicas {
+				violate(RuleMaxReplicas, ref, "target replicas %d exceed the maximum of %d",
+					target.TargetReplicas, *spec.MaxReplicas)
+			}
+			if spec.MaxMultiplier != "" {
+				checkMultiplier(spec.MaxMultiplier, target, ref, violate)
+			}
+		}
+	}
+	return violations
+}
+
+// CheckExtension 列出 AlertScale 所在命名空间的 ScaleGuardrail，检查延长后的总持续时间
+func CheckExtension(ctx context.Context, c client.Client, alertScale *opsv1beta1.AlertScale, total time.Duration) ([]opsv1beta1.GuardrailViolation, error) {
+	guardrails := &opsv1beta1.ScaleGuardrailList{}
+	if err := c.List(ctx, guardrails, client.InNamespace(alertScale.Namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list ScaleGuardrails: %w", err)
+	}
+	return EvaluateExtension(guardrails.Items, total), nil
+}
+
+// EvaluateExtension 按名称顺序检查通过 extend 操作延长后的总持续时间不超过每个 ScaleGuardrail 的 maxDuration
+func EvaluateExtension(guardrails []opsv1beta1.ScaleGuardrail, total time.Duration) []opsv1beta1.GuardrailViolation {
+	sorted := slices.Clone(guardrails)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+
+	var violations []opsv1beta1.GuardrailViolation
+	for _, guardrail := range sorted {
+		maxDuration := guardrail.Spec.MaxDuration
+		if maxDuration == "" {
+			continue
+		}
+		violation := opsv1beta1.GuardrailViolation{Guardrail: guardrail.Name, Rule: RuleMaxDuration}
+		limit, err := types.ParseDuration(maxDuration)
+		switch {
+		case err != nil:
+			violation.Message = fmt.Sprintf("invalid maxDuration %q: %v", maxDuration, err)
+		case total > limit:
+			violation.Message = fmt.Sprintf("extended duration %s exceeds the maximum of %s", total, maxDuration)
+		default:
+			continue
+		}
+		violations = append(violations, violation)
+	}
+	return violations
+}
+
+// checkDuration 检查扩容持续时间不超过上限，AlertScale 的持续时间无法解析时由 webhook 负责拒绝
+func checkDuration(maxDuration, scaleDuration string, violate func(rule, target, format string, args ...any)) {
+	limit, err := types.ParseDuration(maxDuration)
+	if err != nil {
+		violate(RuleMaxDuration, "", "invalid maxDuration %q: %v", maxDuration, err)
+		return
+	}
+	duration, err := types.ParseDuration(scaleDuration)
+	if err != nil {
+		return
+	}
+	if duration > limit {
+		violate(RuleMaxDuration, "", "scaleDuration %s exceeds the maximum of %s", scaleDuration, maxDuration)
+	}
+}
+
+// checkMultiplier 检查目标副本数不超过原始副本数的倍数上限，原始副本数为 0 时不做限制
+func checkMultiplier(maxMultiplier string, target Target, ref string, violate func(rule, target, format string, args ...any)) {
+	multiplier, err := strconv.ParseFloat(maxMultiplier, 64)
+	if err != nil {
+		violate(RuleMaxMultiplier, ref, "invalid maxMultiplier %q: %v", maxMultiplier, err)
+		return
+	}
+	if target.OriginReplicas <= 0 {
+		return
+	}
+	if float64(target.TargetReplicas) > float64(target.OriginReplicas)*multiplier {
+		violate(RuleMaxMultiplier, ref, "target replicas %d exceed %sx the origin replicas %d",
+			target.TargetReplicas, maxMultiplier, target.OriginReplicas)
+	}
+}
+
+// Summarize 将违规项拼接为一行说明，用于状态消息和 webhook 的拒绝原因
+func Summarize(violations []opsv1beta1.GuardrailViolation) string {
+	parts := make([]string, 0, len(violations))
+	for _, violation := range violations {
+		part := "ScaleGuardrail " + violation.Guardrail + ": "
+		if violation.Target != "" {
+			part += violation.Target + ": "
+		}
+		parts = append(parts, part+violation.Message)
+	}
+	return strings.Join(parts, "; ")
+}
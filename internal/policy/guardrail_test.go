@@ -0,0 +1,115 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	opsv1beta1 "udesk.cn/ops/api/v1beta1"
+)
+
+var _ = Describe("ScaleGuardrail", func() {
+	var (
+		alertScale *opsv1beta1.AlertScale
+		targets    []Target
+	)
+
+	guardrail := func(name string, spec opsv1beta1.ScaleGuardrailSpec) opsv1beta1.ScaleGuardrail {
+		return opsv1beta1.ScaleGuardrail{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"}, Spec: spec}
+	}
+
+	BeforeEach(func() {
+		alertScale = &opsv1beta1.AlertScale{
+			ObjectMeta: metav1.ObjectMeta{Name: "web-scale", Namespace: "default"},
+			Spec:       opsv1beta1.AlertScaleSpec{ScaleDuration: "2h", ScaleAutoApproval: true},
+		}
+		targets = []Target{{
+			ScaleTarget:    opsv1beta1.ScaleTarget{Kind: "Deployment", Name: "web", Namespace: "default"},
+			OriginReplicas: 4,
+			TargetReplicas: 10,
+		}}
+	})
+
+	It("should allow anything when no limit is set", func() {
+		Expect(Evaluate([]opsv1beta1.ScaleGuardrail{guardrail("empty", opsv1beta1.ScaleGuardrailSpec{})}, alertScale, targets)).To(BeEmpty())
+	})
+
+	It("should report every exceeded limit", func() {
+		violations := Evaluate([]opsv1beta1.ScaleGuardrail{guardrail("strict", opsv1beta1.ScaleGuardrailSpec{
+			MaxReplicas:       ptr.To(int32(8)),
+			MaxMultiplier:     "2",
+			MaxDuration:       "1h",
+			AllowedKinds:      []string{"StatefulSet"},
+			AllowAutoApproval: ptr.To(false),
+		})}, alertScale, targets)
+
+		rules := make([]string, 0, len(violations))
+		for _, violation := range violations {
+			Expect(violation.Guardrail).To(Equal("strict"))
+			rules = append(rules, violation.Rule)
+		}
+		Expect(rules).To(Equal([]string{RuleAutoApproval, RuleMaxDuration, RuleAllowedKinds, RuleMaxReplicas, RuleMaxMultiplier}))
+		Expect(violations[3].Target).To(Equal("Deployment default/web"))
+		Expect(violations[3].Message).To(Equal("target replicas 10 exceed the maximum of 8"))
+		Expect(violations[4].Message).To(Equal("target replicas 10 exceed 2x the origin replicas 4"))
+	})
+
+	It("should allow fractional multipliers and skip targets without origin replicas", func() {
+		limit := []opsv1beta1.ScaleGuardrail{guardrail("ratio", opsv1beta1.ScaleGuardrailSpec{MaxMultiplier: "2.5"})}
+		Expect(Evaluate(limit, alertScale, targets)).To(BeEmpty())
+
+		targets[0].TargetReplicas = 11
+		Expect(Evaluate(limit, alertScale, targets)).To(HaveLen(1))
+
+		targets[0].OriginReplicas = 0
+		Expect(Evaluate(limit, alertScale, targets)).To(BeEmpty())
+	})
+
+	It("should limit the total duration after an extension", func() {
+		limits := []opsv1beta1.ScaleGuardrail{
+			guardrail("short", opsv1beta1.ScaleGuardrailSpec{MaxDuration: "3h"}),
+			guardrail("unlimited", opsv1beta1.ScaleGuardrailSpec{MaxReplicas: ptr.To(int32(1))}),
+		}
+		Expect(EvaluateExtension(limits, 3*time.Hour)).To(BeEmpty())
+
+		violations := EvaluateExtension(limits, 4*time.Hour)
+		Expect(violations).To(HaveLen(1))
+		Expect(violations[0].Rule).To(Equal(RuleMaxDuration))
+		Expect(Summarize(violations)).To(Equal("ScaleGuardrail short: extended duration 4h0m0s exceeds the maximum of 3h"))
+	})
+
+	It("should list the guardrails in the namespace of the AlertScale", func() {
+		scheme := runtime.NewScheme()
+		Expect(opsv1beta1.AddToScheme(scheme)).To(Succeed())
+		other := guardrail("other", opsv1beta1.ScaleGuardrailSpec{MaxReplicas: ptr.To(int32(1))})
+		other.Namespace = "other"
+		local := guardrail("local", opsv1beta1.ScaleGuardrailSpec{AllowAutoApproval: ptr.To(false)})
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(&other, &local).Build()
+
+		violations, err := Check(context.Background(), fakeClient, alertScale, targets)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(violations).To(HaveLen(1))
+		Expect(Summarize(violations)).To(Equal("ScaleGuardrail local: auto-approval is not permitted"))
+	})
+})
@@ -0,0 +1,179 @@
+package policy
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	opsv1beta1 "udesk.cn/ops/api/v1beta1"
+)
+
+// MatchApprovalPolicies 列出所有 ApprovalPolicy，按名称顺序返回选中 AlertScale 的策略
+func MatchApprovalPolicies(ctx context.Context, c client.Client, alertScale *opsv1beta1.AlertScale) ([]opsv1beta1.ApprovalPolicy, error) {
+	policies := &opsv1beta1.ApprovalPolicyList{}
+	if err := c.List(ctx, policies); err != nil {
+		return nil, fmt.Errorf("failed to list ApprovalPolicies: %w", err)
+	}
+	sort.Slice(policies.Items, func(i, j int) bool { return policies.Items[i].Name < policies.Items[j].Name })
+
+	var matched []opsv1beta1.ApprovalPolicy
+	for _, policy := range policies.Items {
+		selected, err := SelectsAlertScale(&policy, alertScale)
+		if err != nil {
+			return nil, fmt.Errorf("ApprovalPolicy %s: %w", policy.Name, err)
+		}
+		if selected {
+			matched = append(matched, policy)
+		}
+	}
+	return matched, nil
+}
+
+// ExcludedApprovalPolicies 返回按命名空间本应适用、但被 AlertScale 的标签排除的策略名称。
+// oldAlertScale 不为空时只返回此前选中旧对象的策略，即本次标签变更使其脱离的策略
+func ExcludedApprovalPolicies(ctx context.Context, c client.Client, oldAlertScale, alertScale *opsv1beta1.AlertScale) ([]string, error) {
+	policies := &opsv1beta1.ApprovalPolicyList{}
+	if err := c.List(ctx, policies); err != nil {
+		return nil, fmt.Errorf("failed to list ApprovalPolicies: %w", err)
+	}
+
+	var excluded []string
+	for _, policy := range policies.Items {
+		if !selectsNamespace(&policy, alertScale.Namespace) {
+			continue
+		}
+		selected, err := SelectsAlertScale(&policy, alertScale)
+		if err != nil {
+			return nil, fmt.Errorf("ApprovalPolicy %s: %w", policy.Name, err)
+		}
+		if selected {
+			continue
+		}
+		if oldAlertScale != nil {
+			if selected, err = SelectsAlertScale(&policy, oldAlertScale); err != nil || !selected {
+				continue
+			}
+		}
+		excluded = append(excluded, policy.Name)
+	}
+	sort.Strings(excluded)
+	return excluded, nil
+}
+
+// SelectsAlertScale 判断 ApprovalPolicy 是否通过命名空间和标签选中 AlertScale，未设置的条件视为匹配
+func SelectsAlertScale(policy *opsv1beta1.ApprovalPolicy, alertScale *opsv1beta1.AlertScale) (bool, error) {
+	if !selectsNamespace(policy, alertScale.Namespace) {
+		return false, nil
+	}
+	if policy.Spec.Selector == nil {
+		return true, nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(policy.Spec.Selector)
+	if err != nil {
+		return false, fmt.Errorf("invalid selector: %w", err)
+	}
+	return selector.Matches(labels.Set(alertScale.Labels)), nil
+}
+
+// selectsNamespace 判断 ApprovalPolicy 是否选中命名空间，未设置命名空间时选中所有命名空间
+func selectsNamespace(policy *opsv1beta1.ApprovalPolicy, namespace string) bool {
+	return len(policy.Spec.Namespaces) == 0 || slices.Contains(policy.Spec.Namespaces, namespace)
+}
+
+// ApproverGroups 返回用户所属的审批组，格式为 "策略名/组名"
+func ApproverGroups(policies []opsv1beta1.ApprovalPolicy, user string) []string {
+	var groups []string
+	for _, policy := range policies {
+		for _, group := range policy.Spec.ApproverGroups {
+			if slices.Contains(group.Users, user) {
+				groups = append(groups, groupRef(&policy, &group))
+			}
+		}
+	}
+	return groups
+}
+
+// EvaluateQuorum 根据已记录的审批判断是否满足所有策略的法定人数，同时返回每个审批组的进度说明。
+// 审批人按当前的组成员计算，已不在任何组中的审批人不再计数
+func EvaluateQuorum(policies []opsv1beta1.ApprovalPolicy, approvals []opsv1beta1.ApprovalRecord) (bool, string) {
+	approvers := make([]string, 0, len(approvals))
+	for _, approval := range approvals {
+		if !slices.Contains(approvers, approval.Approver) {
+			approvers = append(approvers, approval.Approver)
+		}
+	}
+
+	met := true
+	var progress []string
+	for i := range policies {
+		counts := assignApprovers(&policies[i], approvers)
+		for j, group := range policies[i].Spec.ApproverGroups {
+			required := requiredApprovals(&group)
+			if counts[j] < required {
+				met = false
+			}
+			progress = append(progress, fmt.Sprintf("%s %d/%d", groupRef(&policies[i], &group), counts[j], required))
+		}
+	}
+	return met, strings.Join(progress, ", ")
+}
+
+// assignApprovers 将审批人分配到其所属的审批组，每人只计入一个组，每组最多计入所需人数，
+// 通过二分图最大匹配使尽可能多的组满足要求，返回每个组计入的人数
+func assignApprovers(policy *opsv1beta1.ApprovalPolicy, approvers []string) []int32 {
+	// 每个审批组按所需人数展开为多个席位
+	var seats []int
+	for i := range policy.Spec.ApproverGroups {
+		for range requiredApprovals(&policy.Spec.ApproverGroups[i]) {
+			seats = append(seats, i)
+		}
+	}
+
+	holder := make([]int, len(seats))
+	for i := range holder {
+		holder[i] = -1
+	}
+	var assign func(approver int, visited []bool) bool
+	assign = func(approver int, visited []bool) bool {
+		for seat, group := range seats {
+			if visited[seat] || !slices.Contains(policy.Spec.ApproverGroups[group].Users, approvers[approver]) {
+				continue
+			}
+			visited[seat] = true
+			if holder[seat] < 0 || assign(holder[seat], visited) {
+				holder[seat] = approver
+				return true
+			}
+		}
+		return false
+	}
+	for approver := range approvers {
+		assign(approver, make([]bool, len(seats)))
+	}
+
+	counts := make([]int32, len(policy.Spec.ApproverGroups))
+	for seat, group := range seats {
+		if holder[seat] >= 0 {
+			counts[group]++
+		}
+	}
+	return counts
+}
+
+// requiredApprovals 返回审批组所需的审批人数，未设置时为 1
+func requiredApprovals(group *opsv1beta1.ApproverGroup) int32 {
+	if group.Required < 1 {
+		return 1
+	}
+	return group.Required
+}
+
+// groupRef 返回审批组的引用，格式为 "策略名/组名"
+func groupRef(policy *opsv1beta1.ApprovalPolicy, group *opsv1beta1.ApproverGroup) string {
+	return policy.Name + "/" + group.Name
+}
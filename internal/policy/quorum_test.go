@@ -0,0 +1,153 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	opsv1beta1 "udesk.cn/ops/api/v1beta1"
+)
+
+var _ = Describe("ApprovalPolicy", func() {
+	var policy opsv1beta1.ApprovalPolicy
+
+	approvals := func(approvers ...string) []opsv1beta1.ApprovalRecord {
+		records := make([]opsv1beta1.ApprovalRecord, 0, len(approvers))
+		for _, approver := range approvers {
+			records = append(records, opsv1beta1.ApprovalRecord{Approver: approver})
+		}
+		return records
+	}
+
+	BeforeEach(func() {
+		policy = opsv1beta1.ApprovalPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "prod"},
+			Spec: opsv1beta1.ApprovalPolicySpec{
+				ApproverGroups: []opsv1beta1.ApproverGroup{
+					{Name: "owner", Users: []string{"alice", "bob"}, Required: 1},
+					{Name: "sre", Users: []string{"bob", "carol", "dave"}, Required: 2},
+				},
+			},
+		}
+	})
+
+	It("should select AlertScales by namespace and labels", func() {
+		policy.Spec.Namespaces = []string{"production"}
+		policy.Spec.Selector = &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "critical"}}
+		other := opsv1beta1.ApprovalPolicy{ObjectMeta: metav1.ObjectMeta{Name: "staging"}, Spec: opsv1beta1.ApprovalPolicySpec{
+			Namespaces: []string{"staging"},
+		}}
+		all := opsv1beta1.ApprovalPolicy{ObjectMeta: metav1.ObjectMeta{Name: "all"}}
+
+		scheme := runtime.NewScheme()
+		Expect(opsv1beta1.AddToScheme(scheme)).To(Succeed())
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(&policy, &other, &all).Build()
+
+		alertScale := &opsv1beta1.AlertScale{ObjectMeta: metav1.ObjectMeta{
+			Name: "web-scale", Namespace: "production", Labels: map[string]string{"tier": "critical"},
+		}}
+		matched, err := MatchApprovalPolicies(context.Background(), fakeClient, alertScale)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(matched).To(HaveLen(2))
+		Expect(matched[0].Name).To(Equal("all"))
+		Expect(matched[1].Name).To(Equal("prod"))
+
+		alertScale.Labels = nil
+		matched, err = MatchApprovalPolicies(context.Background(), fakeClient, alertScale)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(matched).To(HaveLen(1))
+		Expect(matched[0].Name).To(Equal("all"))
+	})
+
+	It("should report the policies the labels exclude an AlertScale from", func() {
+		policy.Spec.Namespaces = []string{"production"}
+		policy.Spec.Selector = &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "critical"}}
+		other := opsv1beta1.ApprovalPolicy{ObjectMeta: metav1.ObjectMeta{Name: "staging"}, Spec: opsv1beta1.ApprovalPolicySpec{
+			Namespaces: []string{"staging"},
+			Selector:   &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "critical"}},
+		}}
+
+		scheme := runtime.NewScheme()
+		Expect(opsv1beta1.AddToScheme(scheme)).To(Succeed())
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(&policy, &other).Build()
+
+		oldAlertScale := &opsv1beta1.AlertScale{ObjectMeta: metav1.ObjectMeta{
+			Name: "web-scale", Namespace: "production", Labels: map[string]string{"tier": "critical"},
+		}}
+		excluded, err := ExcludedApprovalPolicies(context.Background(), fakeClient, nil, oldAlertScale)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(excluded).To(BeEmpty())
+
+		alertScale := oldAlertScale.DeepCopy()
+		alertScale.Labels = nil
+		excluded, err = ExcludedApprovalPolicies(context.Background(), fakeClient, nil, alertScale)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(excluded).To(Equal([]string{"prod"}))
+
+		excluded, err = ExcludedApprovalPolicies(context.Background(), fakeClient, oldAlertScale, alertScale)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(excluded).To(Equal([]string{"prod"}))
+
+		// 此前未被选中时，标签变更不会使其脱离策略
+		excluded, err = ExcludedApprovalPolicies(context.Background(), fakeClient, alertScale, alertScale)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(excluded).To(BeEmpty())
+	})
+
+	It("should list the groups of an approver", func() {
+		policies := []opsv1beta1.ApprovalPolicy{policy}
+		Expect(ApproverGroups(policies, "bob")).To(Equal([]string{"prod/owner", "prod/sre"}))
+		Expect(ApproverGroups(policies, "mallory")).To(BeEmpty())
+	})
+
+	It("should require every group to reach its quorum", func() {
+		policies := []opsv1beta1.ApprovalPolicy{policy}
+
+		met, progress := EvaluateQuorum(policies, approvals("alice", "carol"))
+		Expect(met).To(BeFalse())
+		Expect(progress).To(Equal("prod/owner 1/1, prod/sre 1/2"))
+
+		met, progress = EvaluateQuorum(policies, approvals("alice", "carol", "dave"))
+		Expect(met).To(BeTrue())
+		Expect(progress).To(Equal("prod/owner 1/1, prod/sre 2/2"))
+	})
+
+	It("should count an approver in only one group", func() {
+		policies := []opsv1beta1.ApprovalPolicy{policy}
+
+		met, _ := EvaluateQuorum(policies, approvals("bob", "carol"))
+		Expect(met).To(BeFalse())
+
+		// sre 排在前面时 bob 先占用 sre 的席位，dave 审批后应将 bob 调整到 owner
+		groups := policy.Spec.ApproverGroups
+		policy.Spec.ApproverGroups = []opsv1beta1.ApproverGroup{groups[1], groups[0]}
+		met, progress := EvaluateQuorum([]opsv1beta1.ApprovalPolicy{policy}, approvals("bob", "carol", "bob", "dave"))
+		Expect(met).To(BeTrue())
+		Expect(progress).To(Equal("prod/sre 2/2, prod/owner 1/1"))
+	})
+
+	It("should ignore approvers who are no longer members", func() {
+		met, progress := EvaluateQuorum([]opsv1beta1.ApprovalPolicy{policy}, approvals("mallory", "alice", "carol", "erin"))
+		Expect(met).To(BeFalse())
+		Expect(progress).To(Equal("prod/owner 1/1, prod/sre 1/2"))
+	})
+})
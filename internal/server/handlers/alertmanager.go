@@ -0,0 +1,520 @@
+package handlers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gorilla/mux"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	opsv1beta1 "udesk.cn/ops/api/v1beta1"
+	"udesk.cn/ops/constants"
+	"udesk.cn/ops/internal/policy"
+	scaletypes "udesk.cn/ops/internal/types"
+)
+
+// Alertmanager alert status values
+const (
+	AlertStatusFiring   = "firing"
+	AlertStatusResolved = "resolved"
+)
+
+// Actions reported for each alert in the webhook response
+const (
+	alertActionCreated  = "created"
+	alertActionSkipped  = "skipped"
+	alertActionResolved = "resolved"
+	alertActionIgnored  = "ignored"
+)
+
+// maxScaleReasonLength mirrors the MaxLength validation of AlertScaleSpec.ScaleReason, counted in characters
+const maxScaleReasonLength = 1024
+
+// alertmanagerFingerprintPattern matches the 16 hex digit fingerprints Alertmanager generates
+var alertmanagerFingerprintPattern = regexp.MustCompile(`^[0-9a-f]{1,16}$`)
+
+// init registers the Alertmanager handler automatically
+func init() {
+	RegisterHandler("alertmanager", func(k8sClient client.Client) Handler {
+		return NewAlertmanagerHandler(k8sClient)
+	})
+}
+
+// AlertmanagerHandler receives Alertmanager webhook notifications and turns them into AlertScale objects
+type AlertmanagerHandler struct {
+	client client.Client
+}
+
+// NewAlertmanagerHandler creates a new Alertmanager handler
+func NewAlertmanagerHandler(k8sClient client.Client) *AlertmanagerHandler {
+	return &AlertmanagerHandler{
+		client: k8sClient,
+	}
+}
+
+// RegisterRoutes registers Alertmanager routes to the router
+func (h *AlertmanagerHandler) RegisterRoutes(router *mux.Router, responseWriter ResponseWriter) {
+	api := GetAPIRouter(router)
+
+	api.HandleFunc("/alertmanager/webhook", h.withResponseWriter(responseWriter, h.receiveWebhook)).Methods("POST")
+}
+
+// AlertmanagerHandlerFuncWithWriter wrapper type
+type AlertmanagerHandlerFuncWithWriter func(ResponseWriter, http.ResponseWriter, *http.Request)
+
+// withResponseWriter wraps handler functions with ResponseWriter
+func (h *AlertmanagerHandler) withResponseWriter(rw ResponseWriter, handler AlertmanagerHandlerFuncWithWriter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		handler(rw, w, r)
+	}
+}
+
+// AlertmanagerWebhookMessage is the standard Alertmanager webhook payload (version 4)
+type AlertmanagerWebhookMessage struct {
+	Version           string              `json:"version"`
+	GroupKey          string              `json:"groupKey"`
+	TruncatedAlerts   int                 `json:"truncatedAlerts"`
+	Status            string              `json:"status"`
+	Receiver          string              `json:"receiver"`
+	GroupLabels       map[string]string   `json:"groupLabels"`
+	CommonLabels      map[string]string   `json:"commonLabels"`
+	CommonAnnotations map[string]string   `json:"commonAnnotations"`
+	ExternalURL       string              `json:"externalURL"`
+	Alerts            []AlertmanagerAlert `json:"alerts"`
+}
+
+// AlertmanagerAlert is a single alert inside an Alertmanager webhook payload
+type AlertmanagerAlert struct {
+	Status       string            `json:"status"`
+	Labels       map[string]string `json:"labels"`
+	Annotations  map[string]string `json:"annotations"`
+	StartsAt     time.Time         `json:"startsAt"`
+	EndsAt       time.Time         `json:"endsAt"`
+	GeneratorURL string            `json:"generatorURL"`
+	Fingerprint  string            `json:"fingerprint"`
+}
+
+// AlertResult describes how a single alert was handled
+type AlertResult struct {
+	Fingerprint string `json:"fingerprint"`
+	AlertName   string `json:"alertName,omitempty"`
+	Status      string `json:"status"`
+	Action      string `json:"action"`
+	Namespace   string `json:"namespace,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Error       string `json:"error,omitempty"`
+}
+
+// receiveWebhook handles POST /api/v1/alertmanager/webhook
+func (h *AlertmanagerHandler) receiveWebhook(responseWriter ResponseWriter, w http.ResponseWriter, r *http.Request) {
+	user, ok := requestUser(responseWriter, w, r)
+	if !ok {
+		return
+	}
+
+	var msg AlertmanagerWebhookMessage
+	if err := json.NewDecoder(r.Body).Decode(&msg); err != nil {
+		responseWriter.WriteError(w, http.StatusBadRequest, "Invalid Alertmanager payload", err)
+		return
+	}
+
+	ctx := r.Context()
+	log := logf.FromContext(ctx).WithName("alertmanager-webhook")
+
+	results := make([]AlertResult, 0, len(msg.Alerts))
+	failureCount := 0
+
+	for _, alert := range msg.Alerts {
+		var result AlertResult
+		switch alert.Status {
+		case AlertStatusResolved:
+			result = h.handleResolved(ctx, user, alert)
+		default:
+			result = h.handleFiring(ctx, user, alert)
+		}
+
+		if result.Error != "" {
+			failureCount++
+			log.Info("Alert not processed", "fingerprint", result.Fingerprint, "action", result.Action, "error", result.Error)
+		}
+		results = append(results, result)
+	}
+
+	responseData := map[string]interface{}{
+		"receiver": msg.Receiver,
+		"results":  results,
+		"total":    len(msg.Alerts),
+		"failed":   failureCount,
+	}
+
+	responseWriter.WriteSuccess(w, "Alertmanager webhook processed", responseData)
+}
+
+// handleFiring creates an AlertScale for a firing alert unless an active one already exists for its fingerprint.
+// The caller needs the create permission in the target namespace, and the approve permission to request auto-approval
+// or to create an AlertScale whose labels exclude it from an ApprovalPolicy selecting its namespace
+func (h *AlertmanagerHandler) handleFiring(ctx context.Context, user authenticationv1.UserInfo, alert AlertmanagerAlert) AlertResult {
+	log := logf.FromContext(ctx)
+
+	result := AlertResult{
+		Fingerprint: alertFingerprint(alert),
+		AlertName:   alert.Labels["alertname"],
+		Status:      AlertStatusFiring,
+	}
+
+	alertScale, err := buildAlertScale(alert, result.Fingerprint)
+	if err != nil {
+		result.Action = alertActionIgnored
+		result.Error = err.Error()
+		return result
+	}
+	result.Namespace = alertScale.Namespace
+
+	// The API server creates the AlertScale under its own identity, so the webhook cannot check the caller
+	// for the ApprovalPolicies the labels exclude the AlertScale from
+	excluded, err := policy.ExcludedApprovalPolicies(ctx, h.client, nil, alertScale)
+	if err != nil {
+		log.Error(err, "Failed to match ApprovalPolicies", "fingerprint", result.Fingerprint)
+		result.Action = alertActionIgnored
+		result.Error = err.Error()
+		return result
+	}
+
+	verbs := []string{"create"}
+	if alertScale.Spec.ScaleAutoApproval || len(excluded) > 0 {
+		verbs = append(verbs, constants.ApprovalVerb)
+	}
+	for _, verb := range verbs {
+		if err := h.authorize(ctx, user, verb, alertScale.Namespace); err != nil {
+			result.Action = alertActionIgnored
+			result.Error = err.Error()
+			return result
+		}
+	}
+	// The webhook keeps the requester claimed by the API server, so approvals are attributed to the caller
+	alertScale.Annotations[constants.RequestedByAnnotation] = user.Username
+
+	existing, err := h.listAlertScales(ctx, alertScale.Namespace, result.Fingerprint)
+	if err != nil {
+		log.Error(err, "Failed to list AlertScales for deduplication", "fingerprint", result.Fingerprint)
+		result.Action = alertActionIgnored
+		result.Error = err.Error()
+		return result
+	}
+	if active := activeAlertScales(existing); len(active) > 0 {
+		result.Action = alertActionSkipped
+		result.Name = active[0].Name
+		return result
+	}
+
+	// Concurrent or retried deliveries of the same alert derive the same name, so only one of them is created
+	alertScale.Name = alertScaleName(result.Fingerprint, nextAlertSequence(existing, result.Fingerprint))
+	if err := h.client.Create(ctx, alertScale); apierrors.IsAlreadyExists(err) {
+		result.Action = alertActionSkipped
+		result.Name = alertScale.Name
+		return result
+	} else if err != nil {
+		log.Error(err, "Failed to create AlertScale from alert", "fingerprint", result.Fingerprint)
+		result.Action = alertActionIgnored
+		result.Error = err.Error()
+		return result
+	}
+
+	log.Info("AlertScale created from Alertmanager alert",
+		"namespace", alertScale.Namespace, "name", alertScale.Name, "fingerprint", result.Fingerprint)
+
+	result.Action = alertActionCreated
+	result.Name = alertScale.Name
+	return result
+}
+
+// handleResolved marks active AlertScales of the alert as resolved so the controller ends them early,
+// which needs the update permission in the target namespace
+func (h *AlertmanagerHandler) handleResolved(ctx context.Context, user authenticationv1.UserInfo, alert AlertmanagerAlert) AlertResult {
+	log := logf.FromContext(ctx)
+
+	result := AlertResult{
+		Fingerprint: alertFingerprint(alert),
+		AlertName:   alert.Labels["alertname"],
+		Status:      AlertStatusResolved,
+		Namespace:   alertTargetNamespace(alert),
+	}
+	if err := h.authorize(ctx, user, "update", result.Namespace); err != nil {
+		result.Action = alertActionIgnored
+		result.Error = err.Error()
+		return result
+	}
+
+	all, err := h.listAlertScales(ctx, result.Namespace, result.Fingerprint)
+	if err != nil {
+		log.Error(err, "Failed to list AlertScales for resolved alert", "fingerprint", result.Fingerprint)
+		result.Action = alertActionIgnored
+		result.Error = err.Error()
+		return result
+	}
+	existing := activeAlertScales(all)
+	if len(existing) == 0 {
+		result.Action = alertActionSkipped
+		return result
+	}
+
+	resolvedAt := alert.EndsAt
+	if resolvedAt.IsZero() {
+		resolvedAt = time.Now()
+	}
+
+	names := make([]string, 0, len(existing))
+	for i := range existing {
+		alertScale := &existing[i]
+		if _, ok := alertScale.Annotations[constants.AlertResolvedAnnotation]; ok {
+			names = append(names, alertScale.Name)
+			continue
+		}
+
+		// Declarative approach: Only update annotations, controller will end the scale
+		if alertScale.Annotations == nil {
+			alertScale.Annotations = make(map[string]string)
+		}
+		alertScale.Annotations[constants.AlertResolvedAnnotation] = resolvedAt.UTC().Format(time.RFC3339)
+
+		if err := h.client.Update(ctx, alertScale); err != nil {
+			log.Error(err, "Failed to mark AlertScale as resolved", "namespace", alertScale.Namespace, "name", alertScale.Name)
+			result.Action = alertActionIgnored
+			result.Error = err.Error()
+			return result
+		}
+		names = append(names, alertScale.Name)
+	}
+
+	result.Action = alertActionResolved
+	result.Name = strings.Join(names, ",")
+	return result
+}
+
+// authorize checks that the caller may perform verb on AlertScales in the namespace,
+// since the AlertScales are written with the manager's service account
+func (h *AlertmanagerHandler) authorize(ctx context.Context, user authenticationv1.UserInfo, verb, namespace string) error {
+	allowed, err := authorizeAlertScale(ctx, h.client, user, verb, namespace, "")
+	if err != nil {
+		return fmt.Errorf("failed to check permission: %w", err)
+	}
+	if !allowed {
+		return fmt.Errorf("user %q is not allowed to %s alertscales in namespace %q", user.Username, verb, namespace)
+	}
+	return nil
+}
+
+// listAlertScales returns the AlertScales created for the fingerprint
+func (h *AlertmanagerHandler) listAlertScales(ctx context.Context, namespace, fingerprint string) ([]opsv1beta1.AlertScale, error) {
+	var alertScaleList opsv1beta1.AlertScaleList
+	if err := h.client.List(ctx, &alertScaleList,
+		client.InNamespace(namespace),
+		client.MatchingLabels{constants.AlertFingerprintLabel: fingerprint},
+	); err != nil {
+		return nil, err
+	}
+	return alertScaleList.Items, nil
+}
+
+// activeAlertScales filters out the AlertScales that have finished
+func activeAlertScales(alertScales []opsv1beta1.AlertScale) []opsv1beta1.AlertScale {
+	active := make([]opsv1beta1.AlertScale, 0, len(alertScales))
+	for _, alertScale := range alertScales {
+		switch alertScale.Status.ScaleStatus.Status {
+		case scaletypes.ScaleStatusCompleted, scaletypes.ScaleStatusArchived,
+			scaletypes.ScaleStatusFailed, scaletypes.ScaleStatusRejected:
+			continue
+		}
+		active = append(active, alertScale)
+	}
+	return active
+}
+
+// alertScaleNamePrefix is the name prefix of the AlertScales created for the fingerprint
+func alertScaleNamePrefix(fingerprint string) string {
+	return "alert-" + fingerprint + "-"
+}
+
+// alertScaleName returns the name of the sequence-th AlertScale created for the fingerprint
+func alertScaleName(fingerprint string, sequence int) string {
+	return alertScaleNamePrefix(fingerprint) + strconv.Itoa(sequence)
+}
+
+// nextAlertSequence returns the sequence after the highest one used by the AlertScales of the fingerprint,
+// so a firing after the previous AlertScale has finished gets a new name
+func nextAlertSequence(alertScales []opsv1beta1.AlertScale, fingerprint string) int {
+	sequence := 0
+	for _, alertScale := range alertScales {
+		value, ok := strings.CutPrefix(alertScale.Name, alertScaleNamePrefix(fingerprint))
+		if !ok {
+			continue
+		}
+		if n, err := strconv.Atoi(value); err == nil && n > sequence {
+			sequence = n
+		}
+	}
+	return sequence + 1
+}
+
+// buildAlertScale maps alert labels/annotations to a new AlertScale object
+func buildAlertScale(alert AlertmanagerAlert, fingerprint string) (*opsv1beta1.AlertScale, error) {
+	kind := alertValue(alert, constants.AlertKeyTargetKind)
+	name := alertValue(alert, constants.AlertKeyTargetName)
+	if kind == "" || name == "" {
+		return nil, fmt.Errorf("alert must define %s and %s", constants.AlertKeyTargetKind, constants.AlertKeyTargetName)
+	}
+
+	replicasValue := alertValue(alert, constants.AlertKeyReplicas)
+	if replicasValue == "" {
+		return nil, fmt.Errorf("alert must define %s", constants.AlertKeyReplicas)
+	}
+	replicas, err := strconv.ParseInt(replicasValue, 10, 32)
+	if err != nil || replicas < 0 {
+		return nil, fmt.Errorf("invalid %s: %q", constants.AlertKeyReplicas, replicasValue)
+	}
+
+	minReplicas, err := alertReplicasBound(alert, constants.AlertKeyMinReplicas)
+	if err != nil {
+		return nil, err
+	}
+	maxReplicas, err := alertReplicasBound(alert, constants.AlertKeyMaxReplicas)
+	if err != nil {
+		return nil, err
+	}
+
+	autoApproval := false
+	if value := alertValue(alert, constants.AlertKeyAutoApproval); value != "" {
+		if autoApproval, err = strconv.ParseBool(value); err != nil {
+			return nil, fmt.Errorf("invalid %s: %q", constants.AlertKeyAutoApproval, value)
+		}
+	}
+
+	namespace := alertTargetNamespace(alert)
+	alertName := alert.Labels["alertname"]
+
+	labels := map[string]string{
+		constants.AlertFingerprintLabel: fingerprint,
+	}
+	if alertName != "" && len(alertName) <= 63 {
+		labels[constants.AlertNameLabel] = alertName
+	}
+
+	return &opsv1beta1.AlertScale{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Labels:    labels,
+			Annotations: map[string]string{
+				constants.AlertSourceAnnotation: constants.AlertSourceAlertmanager,
+			},
+		},
+		Spec: opsv1beta1.AlertScaleSpec{
+			ScaleReason:   alertReason(alert),
+			ScaleDuration: alertValue(alert, constants.AlertKeyDuration),
+			ScaleTimeout:  alertValue(alert, constants.AlertKeyTimeout),
+			ScaleTarget: opsv1beta1.ScaleTarget{
+				Kind:       kind,
+				Name:       name,
+				Namespace:  namespace,
+				APIVersion: alertValue(alert, constants.AlertKeyTargetAPIVersion),
+			},
+			ScaleThreshold:         int32(replicas),
+			ScaleMode:              alertValue(alert, constants.AlertKeyMode),
+			MinReplicas:            minReplicas,
+			MaxReplicas:            maxReplicas,
+			ScaleNotificationType:  alertValue(alert, constants.AlertKeyNotificationType),
+			ScaleNotifyMsgTemplate: alertValue(alert, constants.AlertKeyNotifyTemplate),
+			ScaleAutoApproval:      autoApproval,
+		},
+	}, nil
+}
+
+// alertReplicasBound parses an optional replica bound from the alert
+func alertReplicasBound(alert AlertmanagerAlert, key string) (*int32, error) {
+	value := alertValue(alert, key)
+	if value == "" {
+		return nil, nil
+	}
+	replicas, err := strconv.ParseInt(value, 10, 32)
+	if err != nil || replicas < 0 {
+		return nil, fmt.Errorf("invalid %s: %q", key, value)
+	}
+	bound := int32(replicas)
+	return &bound, nil
+}
+
+// alertValue looks up a key in the alert annotations first, then in its labels
+func alertValue(alert AlertmanagerAlert, key string) string {
+	if value, ok := alert.Annotations[key]; ok && value != "" {
+		return value
+	}
+	return alert.Labels[key]
+}
+
+// alertTargetNamespace resolves the namespace of the scale target, falling back to the alert namespace label
+func alertTargetNamespace(alert AlertmanagerAlert) string {
+	if namespace := alertValue(alert, constants.AlertKeyTargetNamespace); namespace != "" {
+		return namespace
+	}
+	if namespace := alert.Labels["namespace"]; namespace != "" {
+		return namespace
+	}
+	return DefaultNamespace
+}
+
+// alertReason builds the scale reason from the alert summary, description or name
+func alertReason(alert AlertmanagerAlert) string {
+	reason := alert.Annotations["summary"]
+	if reason == "" {
+		reason = alert.Annotations["description"]
+	}
+	if reason == "" {
+		reason = fmt.Sprintf("Alertmanager alert %s firing", alert.Labels["alertname"])
+	}
+	if utf8.RuneCountInString(reason) > maxScaleReasonLength {
+		reason = string([]rune(reason)[:maxScaleReasonLength])
+	}
+	return reason
+}
+
+// alertFingerprint returns the Alertmanager fingerprint, or derives a stable one from the labels.
+// The result is used as a label value and in object names, so fingerprints that do not look like
+// the ones Alertmanager generates are hashed as well
+func alertFingerprint(alert AlertmanagerAlert) string {
+	if alert.Fingerprint != "" {
+		fingerprint := strings.ToLower(alert.Fingerprint)
+		if alertmanagerFingerprintPattern.MatchString(fingerprint) {
+			return fingerprint
+		}
+		sum := sha256.Sum256([]byte(alert.Fingerprint))
+		return hex.EncodeToString(sum[:])[:16]
+	}
+
+	keys := make([]string, 0, len(alert.Labels))
+	for key := range alert.Labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	hash := sha256.New()
+	for _, key := range keys {
+		hash.Write([]byte(key))
+		hash.Write([]byte{0})
+		hash.Write([]byte(alert.Labels[key]))
+		hash.Write([]byte{0})
+	}
+	return hex.EncodeToString(hash.Sum(nil))[:16]
+}
@@ -7,11 +7,14 @@ import (
 	"time"
 
 	"github.com/gorilla/mux"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	opsv1beta1 "udesk.cn/ops/api/v1beta1"
+	"udesk.cn/ops/constants"
+	scaletypes "udesk.cn/ops/internal/types"
 )
 
 // Constants for approval processing
@@ -19,6 +22,12 @@ const (
 	approvalProcessingPending = "pending"
 )
 
+// approvalInProgress reports whether a previous approval decision has not been processed by the
+// controller yet. Overwriting it would lose that decision when several approvers are required.
+func approvalInProgress(annotations map[string]string) bool {
+	return annotations["ops.udesk.cn/approval-processing"] == approvalProcessingPending
+}
+
 // init registers the AlertScale handler automatically
 func init() {
 	RegisterHandler("alertscale", func(k8sClient client.Client) Handler {
@@ -45,8 +54,11 @@ func (h *AlertScaleHandler) RegisterRoutes(router *mux.Router, responseWriter Re
 	// AlertScale API endpoints
 	api.HandleFunc("/alertscales", h.withResponseWriter(responseWriter, h.listAlertScales)).Methods("GET")
 	api.HandleFunc("/alertscales/{namespace}/{name}", h.withResponseWriter(responseWriter, h.getAlertScale)).Methods("GET")
+	api.HandleFunc("/alertscales/{namespace}/{name}/history", h.withResponseWriter(responseWriter, h.getAlertScaleHistory)).Methods("GET")
 	api.HandleFunc("/alertscales/{namespace}/{name}/approve", h.withResponseWriter(responseWriter, h.approveAlertScale)).Methods("POST")
 	api.HandleFunc("/alertscales/{namespace}/{name}/reject", h.withResponseWriter(responseWriter, h.rejectAlertScale)).Methods("POST")
+	api.HandleFunc("/alertscales/{namespace}/{name}/extend", h.withResponseWriter(responseWriter, h.extendAlertScale)).Methods("POST")
+	api.HandleFunc("/alertscales/{namespace}/{name}/cancel", h.withResponseWriter(responseWriter, h.cancelAlertScale)).Methods("POST")
 }
 
 // HandlerFuncWithWriter wrapper type
@@ -69,13 +81,29 @@ type AlertScaleInfo struct {
 	Template     string `json:"template"`
 	AutoApproval bool   `json:"autoApproval"`
 	CreatedAt    string `json:"createdAt,omitempty"`
+	// Approvals collected towards the quorum of ApprovalPolicies, only returned for a single AlertScale
+	Approvals []opsv1beta1.ApprovalRecord `json:"approvals,omitempty"`
+}
+
+// AlertScaleHistory represents the status transition history of an AlertScale
+type AlertScaleHistory struct {
+	Name      string                        `json:"name"`
+	Namespace string                        `json:"namespace"`
+	Status    string                        `json:"status"`
+	Items     []opsv1beta1.TransitionRecord `json:"items"`
+	Count     int                           `json:"count"`
 }
 
-// ApprovalRequest represents an approval/rejection request
+// ApprovalRequest represents an approval/rejection request, the approver is the authenticated caller
 type ApprovalRequest struct {
-	Approver string `json:"approver"`
+	Reason  string `json:"reason"`
+	Comment string `json:"comment,omitempty"`
+}
+
+// ScaleActionRequest represents an extend/cancel request for a running AlertScale, the operator is the authenticated caller
+type ScaleActionRequest struct {
 	Reason   string `json:"reason"`
-	Comment  string `json:"comment,omitempty"`
+	Duration string `json:"duration,omitempty"`
 }
 
 // listAlertScales handles GET /api/v1/alertscales
@@ -147,11 +175,57 @@ func (h *AlertScaleHandler) getAlertScale(responseWriter ResponseWriter, w http.
 		Template:     alertScale.Spec.ScaleNotifyMsgTemplate,
 		AutoApproval: alertScale.Spec.ScaleAutoApproval,
 		CreatedAt:    alertScale.CreationTimestamp.Format(time.RFC3339),
+		Approvals:    alertScale.Status.Approvals,
 	}
 
 	responseWriter.WriteSuccess(w, "AlertScale retrieved successfully", info)
 }
 
+// getAlertScaleHistory handles GET /api/v1/alertscales/{namespace}/{name}/history
+func (h *AlertScaleHandler) getAlertScaleHistory(responseWriter ResponseWriter, w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	namespace := vars["namespace"]
+	name := vars["name"]
+
+	ctx := context.Background()
+	log := logf.FromContext(ctx)
+
+	var alertScale opsv1beta1.AlertScale
+	key := types.NamespacedName{
+		Namespace: namespace,
+		Name:      name,
+	}
+
+	if err := h.client.Get(ctx, key, &alertScale); err != nil {
+		log.Error(err, "Failed to get AlertScale", "namespace", namespace, "name", name)
+		if client.IgnoreNotFound(err) == nil {
+			responseWriter.WriteError(w, http.StatusNotFound, "AlertScale not found", err)
+		} else {
+			responseWriter.WriteError(w, http.StatusInternalServerError, "Failed to get AlertScale", err)
+		}
+		return
+	}
+
+	items := alertScale.Status.History
+	if items == nil {
+		items = []opsv1beta1.TransitionRecord{}
+	}
+
+	history := AlertScaleHistory{
+		Name:      alertScale.Name,
+		Namespace: alertScale.Namespace,
+		Status:    alertScale.Status.ScaleStatus.Status,
+		Items:     items,
+		Count:     len(items),
+	}
+
+	responseWriter.WriteSuccess(w, "AlertScale history retrieved successfully", history)
+}
+
+// The API server authorizes the caller through the virtual approve verb and then writes the approval
+// annotations with the manager's service account, a delegate that the admission webhook also authorizes.
+// +kubebuilder:rbac:groups=ops.udesk.cn,resources=alertscales,verbs=approve
+
 // approveAlertScale handles POST /api/v1/alertscales/{namespace}/{name}/approve
 func (h *AlertScaleHandler) approveAlertScale(responseWriter ResponseWriter, w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
@@ -164,8 +238,8 @@ func (h *AlertScaleHandler) approveAlertScale(responseWriter ResponseWriter, w h
 		return
 	}
 
-	if req.Approver == "" {
-		responseWriter.WriteError(w, http.StatusBadRequest, "Approver is required", nil)
+	user, ok := requestUser(responseWriter, w, r)
+	if !ok {
 		return
 	}
 
@@ -188,6 +262,20 @@ func (h *AlertScaleHandler) approveAlertScale(responseWriter ResponseWriter, w h
 		return
 	}
 
+	if allowed, err := authorizeAlertScale(ctx, h.client, user, constants.ApprovalVerb, namespace, name); err != nil {
+		log.Error(err, "Failed to check approval permission", "namespace", namespace, "name", name, "user", user.Username)
+		responseWriter.WriteError(w, http.StatusInternalServerError, "Failed to check approval permission", err)
+		return
+	} else if !allowed {
+		responseWriter.WriteError(w, http.StatusForbidden, "User "+user.Username+" is not allowed to approve this AlertScale", nil)
+		return
+	}
+
+	if approvalInProgress(alertScale.Annotations) {
+		responseWriter.WriteError(w, http.StatusConflict, "A previous approval decision is still being processed, retry later", nil)
+		return
+	}
+
 	// Declarative approach: Only update annotations, let controller handle status transitions
 	if alertScale.Annotations == nil {
 		alertScale.Annotations = make(map[string]string)
@@ -198,7 +286,7 @@ func (h *AlertScaleHandler) approveAlertScale(responseWriter ResponseWriter, w h
 	// Set approval decision - controller will detect and process
 	alertScale.Annotations["ops.udesk.cn/approval-decision"] = "approve"
 	alertScale.Annotations["ops.udesk.cn/approval-timestamp"] = timestamp
-	alertScale.Annotations["ops.udesk.cn/approval-operator"] = req.Approver
+	alertScale.Annotations["ops.udesk.cn/approval-operator"] = user.Username
 	alertScale.Annotations["ops.udesk.cn/approval-reason"] = req.Reason
 	if req.Comment != "" {
 		alertScale.Annotations["ops.udesk.cn/approval-comment"] = req.Comment
@@ -208,6 +296,10 @@ func (h *AlertScaleHandler) approveAlertScale(responseWriter ResponseWriter, w h
 	// Single atomic update - no status changes, no retries needed
 	if err := h.client.Update(ctx, &alertScale); err != nil {
 		log.Error(err, "Failed to update AlertScale approval annotations", "namespace", namespace, "name", name)
+		if apierrors.IsConflict(err) {
+			responseWriter.WriteError(w, http.StatusConflict, "AlertScale was modified concurrently, retry later", err)
+			return
+		}
 		responseWriter.WriteError(w, http.StatusInternalServerError, "Failed to approve AlertScale", err)
 		return
 	}
@@ -216,7 +308,7 @@ func (h *AlertScaleHandler) approveAlertScale(responseWriter ResponseWriter, w h
 		"namespace": namespace,
 		"name":      name,
 		"status":    "Approved",
-		"approver":  req.Approver,
+		"approver":  user.Username,
 	}
 
 	responseWriter.WriteSuccess(w, "AlertScale approved successfully", responseData)
@@ -234,8 +326,8 @@ func (h *AlertScaleHandler) rejectAlertScale(responseWriter ResponseWriter, w ht
 		return
 	}
 
-	if req.Approver == "" {
-		responseWriter.WriteError(w, http.StatusBadRequest, "Approver is required", nil)
+	user, ok := requestUser(responseWriter, w, r)
+	if !ok {
 		return
 	}
 
@@ -258,6 +350,20 @@ func (h *AlertScaleHandler) rejectAlertScale(responseWriter ResponseWriter, w ht
 		return
 	}
 
+	if allowed, err := authorizeAlertScale(ctx, h.client, user, constants.ApprovalVerb, namespace, name); err != nil {
+		log.Error(err, "Failed to check approval permission", "namespace", namespace, "name", name, "user", user.Username)
+		responseWriter.WriteError(w, http.StatusInternalServerError, "Failed to check approval permission", err)
+		return
+	} else if !allowed {
+		responseWriter.WriteError(w, http.StatusForbidden, "User "+user.Username+" is not allowed to reject this AlertScale", nil)
+		return
+	}
+
+	if approvalInProgress(alertScale.Annotations) {
+		responseWriter.WriteError(w, http.StatusConflict, "A previous approval decision is still being processed, retry later", nil)
+		return
+	}
+
 	// Declarative approach: Only update annotations, let controller handle status transitions
 	if alertScale.Annotations == nil {
 		alertScale.Annotations = make(map[string]string)
@@ -268,7 +374,7 @@ func (h *AlertScaleHandler) rejectAlertScale(responseWriter ResponseWriter, w ht
 	// Set rejection decision - controller will detect and process
 	alertScale.Annotations["ops.udesk.cn/approval-decision"] = "reject"
 	alertScale.Annotations["ops.udesk.cn/approval-timestamp"] = timestamp
-	alertScale.Annotations["ops.udesk.cn/approval-operator"] = req.Approver
+	alertScale.Annotations["ops.udesk.cn/approval-operator"] = user.Username
 	alertScale.Annotations["ops.udesk.cn/approval-reason"] = req.Reason
 	if req.Comment != "" {
 		alertScale.Annotations["ops.udesk.cn/approval-comment"] = req.Comment
@@ -278,6 +384,10 @@ func (h *AlertScaleHandler) rejectAlertScale(responseWriter ResponseWriter, w ht
 	// Single atomic update - no status changes, no retries needed
 	if err := h.client.Update(ctx, &alertScale); err != nil {
 		log.Error(err, "Failed to update AlertScale rejection annotations", "namespace", namespace, "name", name)
+		if apierrors.IsConflict(err) {
+			responseWriter.WriteError(w, http.StatusConflict, "AlertScale was modified concurrently, retry later", err)
+			return
+		}
 		responseWriter.WriteError(w, http.StatusInternalServerError, "Failed to reject AlertScale", err)
 		return
 	}
@@ -286,8 +396,122 @@ func (h *AlertScaleHandler) rejectAlertScale(responseWriter ResponseWriter, w ht
 		"namespace": namespace,
 		"name":      name,
 		"status":    "Rejected",
-		"rejector":  req.Approver,
+		"rejector":  user.Username,
 	}
 
 	responseWriter.WriteSuccess(w, "AlertScale rejected successfully", responseData)
 }
+
+// extendAlertScale handles POST /api/v1/alertscales/{namespace}/{name}/extend
+func (h *AlertScaleHandler) extendAlertScale(responseWriter ResponseWriter, w http.ResponseWriter, r *http.Request) {
+	h.submitScaleAction(responseWriter, w, r, constants.ScaleActionExtend)
+}
+
+// cancelAlertScale handles POST /api/v1/alertscales/{namespace}/{name}/cancel
+func (h *AlertScaleHandler) cancelAlertScale(responseWriter ResponseWriter, w http.ResponseWriter, r *http.Request) {
+	h.submitScaleAction(responseWriter, w, r, constants.ScaleActionCancel)
+}
+
+// submitScaleAction records an extend/cancel action as annotations, the controller applies it on the next reconcile
+func (h *AlertScaleHandler) submitScaleAction(responseWriter ResponseWriter, w http.ResponseWriter, r *http.Request, action string) {
+	vars := mux.Vars(r)
+	namespace := vars["namespace"]
+	name := vars["name"]
+
+	var req ScaleActionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		responseWriter.WriteError(w, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+
+	user, ok := requestUser(responseWriter, w, r)
+	if !ok {
+		return
+	}
+
+	if action == constants.ScaleActionExtend {
+		if req.Duration == "" {
+			responseWriter.WriteError(w, http.StatusBadRequest, "Duration is required", nil)
+			return
+		}
+		duration, err := scaletypes.ParseDuration(req.Duration)
+		if err != nil {
+			responseWriter.WriteError(w, http.StatusBadRequest, "Invalid duration", err)
+			return
+		}
+		if duration <= 0 {
+			responseWriter.WriteError(w, http.StatusBadRequest, "Duration must be positive", nil)
+			return
+		}
+	}
+
+	ctx := context.Background()
+	log := logf.FromContext(ctx)
+
+	var alertScale opsv1beta1.AlertScale
+	key := types.NamespacedName{
+		Namespace: namespace,
+		Name:      name,
+	}
+
+	if err := h.client.Get(ctx, key, &alertScale); err != nil {
+		log.Error(err, "Failed to get AlertScale for scale action", "namespace", namespace, "name", name, "action", action)
+		if client.IgnoreNotFound(err) == nil {
+			responseWriter.WriteError(w, http.StatusNotFound, "AlertScale not found", err)
+		} else {
+			responseWriter.WriteError(w, http.StatusInternalServerError, "Failed to get AlertScale", err)
+		}
+		return
+	}
+
+	// Submitting an action through the API requires the same permission as annotating the AlertScale directly
+	if allowed, err := authorizeAlertScale(ctx, h.client, user, "update", namespace, name); err != nil {
+		log.Error(err, "Failed to check scale action permission", "namespace", namespace, "name", name, "user", user.Username)
+		responseWriter.WriteError(w, http.StatusInternalServerError, "Failed to check scale action permission", err)
+		return
+	} else if !allowed {
+		responseWriter.WriteError(w, http.StatusForbidden, "User "+user.Username+" is not allowed to update this AlertScale", nil)
+		return
+	}
+
+	// Only a running AlertScale can be extended or cancelled
+	status := alertScale.Status.ScaleStatus.Status
+	if status != scaletypes.ScaleStatusScaling && status != scaletypes.ScaleStatusScaled {
+		responseWriter.WriteError(w, http.StatusConflict, "AlertScale is not running, current status: "+status, nil)
+		return
+	}
+
+	// Declarative approach: Only update annotations, let controller handle status transitions
+	if alertScale.Annotations == nil {
+		alertScale.Annotations = make(map[string]string)
+	}
+
+	alertScale.Annotations[constants.ScaleActionAnnotation] = action
+	alertScale.Annotations[constants.ScaleActionTimestampAnnotation] = time.Now().UTC().Format(time.RFC3339)
+	alertScale.Annotations[constants.ScaleActionOperatorAnnotation] = user.Username
+	alertScale.Annotations[constants.ScaleActionReasonAnnotation] = req.Reason
+	if action == constants.ScaleActionExtend {
+		alertScale.Annotations[constants.ScaleActionDurationAnnotation] = req.Duration
+	} else {
+		delete(alertScale.Annotations, constants.ScaleActionDurationAnnotation)
+	}
+	alertScale.Annotations[constants.ScaleActionProcessingAnnotation] = constants.ApprovalProcessingPending
+
+	if err := h.client.Update(ctx, &alertScale); err != nil {
+		log.Error(err, "Failed to update AlertScale scale action annotations", "namespace", namespace, "name", name, "action", action)
+		responseWriter.WriteError(w, http.StatusInternalServerError, "Failed to submit "+action+" action", err)
+		return
+	}
+
+	responseData := map[string]interface{}{
+		"namespace": namespace,
+		"name":      name,
+		"action":    action,
+		"operator":  user.Username,
+	}
+	if action == constants.ScaleActionExtend {
+		responseData["duration"] = req.Duration
+	}
+
+	responseWriter.WriteSuccess(w, "AlertScale "+action+" action submitted successfully", responseData)
+}
@@ -7,10 +7,12 @@ import (
 	"time"
 
 	"github.com/gorilla/mux"
+	authenticationv1 "k8s.io/api/authentication/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	opsv1beta1 "udesk.cn/ops/api/v1beta1"
+	"udesk.cn/ops/constants"
 	scaletypes "udesk.cn/ops/internal/types"
 )
 
@@ -79,12 +81,11 @@ type PendingApprovalItem struct {
 	TargetName  string    `json:"targetName,omitempty"`
 }
 
-// BatchApprovalRequest represents a batch approval request
+// BatchApprovalRequest represents a batch approval request, the approver is the authenticated caller
 type BatchApprovalRequest struct {
-	Items    []ApprovalItem `json:"items"`
-	Approver string         `json:"approver"`
-	Reason   string         `json:"reason"`
-	Action   string         `json:"action"` // ApprovalActionApprove or ApprovalActionReject
+	Items  []ApprovalItem `json:"items"`
+	Reason string         `json:"reason"`
+	Action string         `json:"action"` // ApprovalActionApprove or ApprovalActionReject
 }
 
 // ApprovalItem represents an item to be approved/rejected
@@ -142,8 +143,8 @@ func (h *ApprovalHandler) listPendingApprovals(responseWriter ResponseWriter, w
 				item.Priority = priority
 			}
 
-			// Extract requester from annotations if available
-			if requester, exists := alertScale.Annotations["ops.udesk.cn/requested-by"]; exists {
+			// The requester is stamped by the mutating webhook from the authenticated user
+			if requester, exists := alertScale.Annotations[constants.RequestedByAnnotation]; exists {
 				item.RequestedBy = requester
 			}
 
@@ -167,8 +168,8 @@ func (h *ApprovalHandler) batchApproval(responseWriter ResponseWriter, w http.Re
 		return
 	}
 
-	if req.Approver == "" {
-		responseWriter.WriteError(w, http.StatusBadRequest, "Approver is required", nil)
+	user, ok := requestUser(responseWriter, w, r)
+	if !ok {
 		return
 	}
 
@@ -187,7 +188,7 @@ func (h *ApprovalHandler) batchApproval(responseWriter ResponseWriter, w http.Re
 	for _, item := range req.Items {
 		switch item.Type {
 		case "AlertScale":
-			success := h.processAlertScaleApproval(ctx, item, req.Approver, req.Reason, req.Action)
+			success := h.processAlertScaleApproval(ctx, item, user, req.Reason, req.Action)
 			result := map[string]interface{}{
 				"type":      item.Type,
 				"namespace": item.Namespace,
@@ -225,7 +226,7 @@ func (h *ApprovalHandler) batchApproval(responseWriter ResponseWriter, w http.Re
 		"successful": successCount,
 		"failed":     failureCount,
 		"action":     req.Action,
-		"approver":   req.Approver,
+		"approver":   user.Username,
 	}
 
 	if failureCount > 0 {
@@ -236,8 +237,9 @@ func (h *ApprovalHandler) batchApproval(responseWriter ResponseWriter, w http.Re
 }
 
 // processAlertScaleApproval processes approval for AlertScale resources using annotation-based declarative approach
-func (h *ApprovalHandler) processAlertScaleApproval(ctx context.Context, item ApprovalItem, approver, reason, action string) bool {
+func (h *ApprovalHandler) processAlertScaleApproval(ctx context.Context, item ApprovalItem, user authenticationv1.UserInfo, reason, action string) bool {
 	log := logf.FromContext(ctx)
+	approver := user.Username
 
 	var alertScale opsv1beta1.AlertScale
 	key := client.ObjectKey{
@@ -250,6 +252,20 @@ func (h *ApprovalHandler) processAlertScaleApproval(ctx context.Context, item Ap
 		return false
 	}
 
+	allowed, err := authorizeAlertScale(ctx, h.client, user, constants.ApprovalVerb, item.Namespace, item.Name)
+	if err != nil {
+		log.Error(err, "Failed to check approval permission", "namespace", item.Namespace, "name", item.Name, "user", approver)
+		return false
+	} else if !allowed {
+		log.Info("Skipping AlertScale the user is not allowed to approve", "namespace", item.Namespace, "name", item.Name, "user", approver)
+		return false
+	}
+
+	if approvalInProgress(alertScale.Annotations) {
+		log.Info("Skipping AlertScale with an approval decision still being processed", "namespace", item.Namespace, "name", item.Name)
+		return false
+	}
+
 	// Declarative approach: Only update annotations, controller will reconcile the desired state
 	if alertScale.Annotations == nil {
 		alertScale.Annotations = make(map[string]string)
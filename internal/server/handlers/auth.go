@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	opsv1beta1 "udesk.cn/ops/api/v1beta1"
+)
+
+// userContextKey is the request context key of the authenticated caller
+type userContextKey struct{}
+
+// WithUser returns a copy of ctx carrying the authenticated caller
+func WithUser(ctx context.Context, user authenticationv1.UserInfo) context.Context {
+	return context.WithValue(ctx, userContextKey{}, user)
+}
+
+// UserFromContext returns the authenticated caller stored by WithUser
+func UserFromContext(ctx context.Context) (authenticationv1.UserInfo, bool) {
+	user, ok := ctx.Value(userContextKey{}).(authenticationv1.UserInfo)
+	return user, ok && user.Username != ""
+}
+
+// requestUser returns the authenticated caller of the request, writing 401 when there is none
+func requestUser(responseWriter ResponseWriter, w http.ResponseWriter, r *http.Request) (authenticationv1.UserInfo, bool) {
+	user, ok := UserFromContext(r.Context())
+	if !ok {
+		responseWriter.WriteError(w, http.StatusUnauthorized, "Authentication required", nil)
+	}
+	return user, ok
+}
+
+// The API server checks the callers' permissions itself before writing with the manager's service account.
+// +kubebuilder:rbac:groups=authorization.k8s.io,resources=subjectaccessreviews,verbs=create
+
+// authorizeAlertScale checks through a SubjectAccessReview whether the caller may perform verb on the AlertScale
+func authorizeAlertScale(ctx context.Context, c client.Client, user authenticationv1.UserInfo, verb, namespace, name string) (bool, error) {
+	return authorizeResource(ctx, c, user, "alertscales", verb, namespace, name)
+}
+
+// authorizeResource checks through a SubjectAccessReview whether the caller may perform verb on an ops.udesk.cn resource
+func authorizeResource(ctx context.Context, c client.Client, user authenticationv1.UserInfo, resource, verb, namespace, name string) (bool, error) {
+	extra := make(map[string]authorizationv1.ExtraValue, len(user.Extra))
+	for key, value := range user.Extra {
+		extra[key] = authorizationv1.ExtraValue(value)
+	}
+
+	review := &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			User:   user.Username,
+			UID:    user.UID,
+			Groups: user.Groups,
+			Extra:  extra,
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace: namespace,
+				Verb:      verb,
+				Group:     opsv1beta1.GroupVersion.Group,
+				Version:   opsv1beta1.GroupVersion.Version,
+				Resource:  resource,
+				Name:      name,
+			},
+		},
+	}
+	if err := c.Create(ctx, review); err != nil {
+		return false, err
+	}
+	return review.Status.Allowed, nil
+}
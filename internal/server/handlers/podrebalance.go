@@ -2,15 +2,18 @@ package handlers
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"time"
 
 	"github.com/gorilla/mux"
+	authenticationv1 "k8s.io/api/authentication/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	opsv1beta1 "udesk.cn/ops/api/v1beta1"
+	"udesk.cn/ops/constants"
 	"udesk.cn/ops/internal/types"
 )
 
@@ -66,6 +69,7 @@ type PodRebalanceInfo struct {
 	BeginTime    *time.Time                      `json:"beginTime,omitempty"`
 	EndTime      *time.Time                      `json:"endTime,omitempty"`
 	Message      string                          `json:"message,omitempty"`
+	RequestedBy  string                          `json:"requestedBy,omitempty"`
 }
 
 // handleList lists PodRebalance resources
@@ -91,6 +95,7 @@ func (h *PodRebalanceHandler) handleList(w http.ResponseWriter, r *http.Request)
 			DryRun:       pr.Spec.DryRun,
 			CreatedAt:    pr.CreationTimestamp.Time,
 			Message:      pr.Status.Message,
+			RequestedBy:  pr.Annotations[constants.RequestedByAnnotation],
 		}
 
 		if !pr.Status.RebalanceBeginTime.IsZero() {
@@ -144,6 +149,7 @@ func (h *PodRebalanceHandler) handleGet(w http.ResponseWriter, r *http.Request)
 		DryRun:       podRebalance.Spec.DryRun,
 		CreatedAt:    podRebalance.CreationTimestamp.Time,
 		Message:      podRebalance.Status.Message,
+		RequestedBy:  podRebalance.Annotations[constants.RequestedByAnnotation],
 	}
 
 	if !podRebalance.Status.RebalanceBeginTime.IsZero() {
@@ -176,6 +182,9 @@ func (h *PodRebalanceHandler) handleCreate(w http.ResponseWriter, r *http.Reques
 		http.Error(w, "namespace and strategy.type are required", http.StatusBadRequest)
 		return
 	}
+	if _, ok := h.authorize(w, r, "create", spec.Namespace, ""); !ok {
+		return
+	}
 
 	podRebalance := &opsv1beta1.PodRebalance{
 		ObjectMeta: metav1.ObjectMeta{
@@ -229,6 +238,9 @@ func (h *PodRebalanceHandler) handleDelete(w http.ResponseWriter, r *http.Reques
 	if namespace == "" {
 		namespace = DefaultNamespace
 	}
+	if _, ok := h.authorize(w, r, "delete", namespace, name); !ok {
+		return
+	}
 
 	var podRebalance opsv1beta1.PodRebalance
 	if err := h.client.Get(ctx, client.ObjectKey{Name: name, Namespace: namespace}, &podRebalance); err != nil {
@@ -251,6 +263,27 @@ func (h *PodRebalanceHandler) handleDelete(w http.ResponseWriter, r *http.Reques
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// authorize checks that the caller may perform verb on the PodRebalance, writing 401 or 403 otherwise,
+// since PodRebalances are written with the manager's service account
+func (h *PodRebalanceHandler) authorize(w http.ResponseWriter, r *http.Request, verb, namespace, name string) (authenticationv1.UserInfo, bool) {
+	user, ok := UserFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Authentication required", http.StatusUnauthorized)
+		return user, false
+	}
+	allowed, err := authorizeResource(r.Context(), h.client, user, "podrebalances", verb, namespace, name)
+	if err != nil {
+		logf.FromContext(r.Context()).Error(err, "Failed to check PodRebalance permission", "verb", verb, "namespace", namespace, "name", name)
+		http.Error(w, "Failed to check permission", http.StatusInternalServerError)
+		return user, false
+	}
+	if !allowed {
+		http.Error(w, fmt.Sprintf("user %q is not allowed to %s podrebalances", user.Username, verb), http.StatusForbidden)
+		return user, false
+	}
+	return user, true
+}
+
 // handleApprove approves a PodRebalance resource
 func (h *PodRebalanceHandler) handleApprove(w http.ResponseWriter, r *http.Request) {
 	h.handleApprovalAction(w, r, "approve")
@@ -261,11 +294,10 @@ func (h *PodRebalanceHandler) handleReject(w http.ResponseWriter, r *http.Reques
 	h.handleApprovalAction(w, r, "reject")
 }
 
-// PodRebalanceApprovalRequest represents an approval/rejection request for PodRebalance
+// PodRebalanceApprovalRequest represents an approval/rejection request for PodRebalance, the approver is the authenticated caller
 type PodRebalanceApprovalRequest struct {
-	Approver string `json:"approver"`
-	Reason   string `json:"reason"`
-	Comment  string `json:"comment,omitempty"`
+	Reason  string `json:"reason"`
+	Comment string `json:"comment,omitempty"`
 }
 
 // handleApprovalAction handles approval/rejection actions
@@ -285,8 +317,13 @@ func (h *PodRebalanceHandler) handleApprovalAction(w http.ResponseWriter, r *htt
 		return
 	}
 
-	if req.Approver == "" || req.Reason == "" {
-		http.Error(w, "approver and reason are required", http.StatusBadRequest)
+	if req.Reason == "" {
+		http.Error(w, "reason is required", http.StatusBadRequest)
+		return
+	}
+
+	user, ok := h.authorize(w, r, constants.ApprovalVerb, namespace, name)
+	if !ok {
 		return
 	}
 
@@ -314,7 +351,7 @@ func (h *PodRebalanceHandler) handleApprovalAction(w http.ResponseWriter, r *htt
 	}
 	podRebalance.Annotations["ops.udesk.cn/approval-decision"] = action
 	podRebalance.Annotations["ops.udesk.cn/approval-timestamp"] = timestamp
-	podRebalance.Annotations["ops.udesk.cn/approval-operator"] = req.Approver
+	podRebalance.Annotations["ops.udesk.cn/approval-operator"] = user.Username
 	podRebalance.Annotations["ops.udesk.cn/approval-reason"] = req.Reason
 	if req.Comment != "" {
 		podRebalance.Annotations["ops.udesk.cn/approval-comment"] = req.Comment
@@ -327,12 +364,12 @@ func (h *PodRebalanceHandler) handleApprovalAction(w http.ResponseWriter, r *htt
 		return
 	}
 
-	log.Info("PodRebalance approval processed", "namespace", namespace, "name", name, "action", action, "approver", req.Approver)
+	log.Info("PodRebalance approval processed", "namespace", namespace, "name", name, "action", action, "approver", user.Username)
 
 	w.Header().Set("Content-Type", "application/json")
 	if err := json.NewEncoder(w).Encode(map[string]interface{}{
 		"action":    action,
-		"approver":  req.Approver,
+		"approver":  user.Username,
 		"timestamp": timestamp,
 		"message":   "Approval processed successfully",
 	}); err != nil {
@@ -4,10 +4,12 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/gorilla/mux"
+	authenticationv1 "k8s.io/api/authentication/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
@@ -80,6 +82,9 @@ func (s *APIServer) setupRoutes() {
 	// Add logging middleware
 	s.router.Use(loggingMiddleware(logger))
 
+	// Add authentication middleware
+	s.router.Use(authMiddleware(s.client, responseWriter, logger))
+
 	// Register all handlers automatically
 	s.registerHandlers(responseWriter)
 
@@ -117,6 +122,43 @@ func corsMiddleware(next http.Handler) http.Handler {
 	})
 }
 
+// The API server authenticates its callers by reviewing their bearer tokens.
+// +kubebuilder:rbac:groups=authentication.k8s.io,resources=tokenreviews,verbs=create
+
+// authMiddleware authenticates callers through a TokenReview of the bearer token in the Authorization header
+// and stores the user in the request context; only the health check is served anonymously
+func authMiddleware(k8sClient client.Client, responseWriter handlers.ResponseWriter, logger logr.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == handlers.APIPrefix+"/health" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if !ok || strings.TrimSpace(token) == "" {
+				responseWriter.WriteError(w, http.StatusUnauthorized, "Bearer token required", nil)
+				return
+			}
+
+			review := &authenticationv1.TokenReview{
+				Spec: authenticationv1.TokenReviewSpec{Token: strings.TrimSpace(token)},
+			}
+			if err := k8sClient.Create(r.Context(), review); err != nil {
+				logger.Error(err, "Failed to review API token")
+				responseWriter.WriteError(w, http.StatusInternalServerError, "Failed to authenticate request", err)
+				return
+			}
+			if !review.Status.Authenticated {
+				responseWriter.WriteError(w, http.StatusUnauthorized, "Invalid bearer token", nil)
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(handlers.WithUser(r.Context(), review.Status.User)))
+		})
+	}
+}
+
 // loggingMiddleware logs all HTTP requests
 func loggingMiddleware(logger logr.Logger) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
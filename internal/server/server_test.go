@@ -18,19 +18,56 @@ package server
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 	opsv1beta1 "udesk.cn/ops/api/v1beta1"
+	"udesk.cn/ops/constants"
+	"udesk.cn/ops/internal/types"
 )
 
+// fakeTokenReviews authenticates "<user>-token" as the user, grants the approve verb to alice and carol only
+// and denies everything to mallory
+var fakeTokenReviews = interceptor.Funcs{
+	Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+		switch review := obj.(type) {
+		case *authenticationv1.TokenReview:
+			if user, ok := strings.CutSuffix(review.Spec.Token, "-token"); ok {
+				review.Status.Authenticated = true
+				review.Status.User = authenticationv1.UserInfo{Username: user}
+			}
+			return nil
+		case *authorizationv1.SubjectAccessReview:
+			user := review.Spec.User
+			review.Status.Allowed = user != "mallory" &&
+				(review.Spec.ResourceAttributes.Verb != constants.ApprovalVerb || user == "alice" || user == "carol")
+			return nil
+		}
+		return c.Create(ctx, obj, opts...)
+	},
+}
+
+// serve sends the request to the router authenticated as alice
+func serve(server *APIServer, req *http.Request) *httptest.ResponseRecorder {
+	req.Header.Set("Authorization", "Bearer alice-token")
+	w := httptest.NewRecorder()
+	server.router.ServeHTTP(w, req)
+	return w
+}
+
 var _ = Describe("APIServer", func() {
 	var (
 		server     *APIServer
@@ -45,6 +82,7 @@ var _ = Describe("APIServer", func() {
 
 		fakeClient = fake.NewClientBuilder().
 			WithScheme(testScheme).
+			WithInterceptorFuncs(fakeTokenReviews).
 			Build()
 
 		server = NewAPIServer(fakeClient, ":8080")
@@ -111,4 +149,452 @@ var _ = Describe("APIServer", func() {
 			})
 		})
 	})
+
+	Describe("Authentication", func() {
+		BeforeEach(func() {
+			server.setupRoutes()
+		})
+
+		It("should reject requests without a valid bearer token", func() {
+			req := httptest.NewRequest("GET", "/api/v1/alertscales", nil)
+			w := httptest.NewRecorder()
+			server.router.ServeHTTP(w, req)
+			Expect(w.Code).To(Equal(http.StatusUnauthorized))
+
+			req = httptest.NewRequest("GET", "/api/v1/alertscales", nil)
+			req.Header.Set("Authorization", "Bearer forged")
+			w = httptest.NewRecorder()
+			server.router.ServeHTTP(w, req)
+			Expect(w.Code).To(Equal(http.StatusUnauthorized))
+		})
+
+		It("should serve authenticated requests", func() {
+			Expect(serve(server, httptest.NewRequest("GET", "/api/v1/alertscales", nil)).Code).To(Equal(http.StatusOK))
+		})
+
+		It("should serve the health check anonymously", func() {
+			req := httptest.NewRequest("GET", "/api/v1/health", nil)
+			w := httptest.NewRecorder()
+			server.router.ServeHTTP(w, req)
+			Expect(w.Code).To(Equal(http.StatusOK))
+		})
+	})
+
+	Describe("Alertmanager Webhook", func() {
+		const firingPayload = `{
+			"version": "4",
+			"status": "firing",
+			"receiver": "udesk-ops",
+			"alerts": [{
+				"status": "firing",
+				"fingerprint": "ABCDEF0123456789",
+				"labels": {
+					"alertname": "HighCPU",
+					"namespace": "default",
+					"scale_target_kind": "Deployment",
+					"scale_target_name": "web-app"
+				},
+				"annotations": {
+					"summary": "CPU usage above 90%",
+					"scale_replicas": "6",
+					"scale_duration": "30m"
+				}
+			}]
+		}`
+
+		postWebhook := func(payload string) *httptest.ResponseRecorder {
+			req := httptest.NewRequest("POST", "/api/v1/alertmanager/webhook", strings.NewReader(payload))
+			req.Header.Set("Content-Type", "application/json")
+			return serve(server, req)
+		}
+
+		postWebhookAs := func(user, payload string) *httptest.ResponseRecorder {
+			req := httptest.NewRequest("POST", "/api/v1/alertmanager/webhook", strings.NewReader(payload))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("Authorization", "Bearer "+user+"-token")
+			w := httptest.NewRecorder()
+			server.router.ServeHTTP(w, req)
+			return w
+		}
+
+		listAlertScales := func(ctx context.Context) []opsv1beta1.AlertScale {
+			var list opsv1beta1.AlertScaleList
+			Expect(fakeClient.List(ctx, &list)).To(Succeed())
+			return list.Items
+		}
+
+		BeforeEach(func() {
+			server.setupRoutes()
+		})
+
+		It("should create an AlertScale from a firing alert", func(ctx SpecContext) {
+			w := postWebhook(firingPayload)
+			Expect(w.Code).To(Equal(http.StatusOK))
+
+			items := listAlertScales(ctx)
+			Expect(items).To(HaveLen(1))
+			Expect(items[0].Namespace).To(Equal("default"))
+			Expect(items[0].Labels).To(HaveKeyWithValue(constants.AlertFingerprintLabel, "abcdef0123456789"))
+			Expect(items[0].Spec.ScaleReason).To(Equal("CPU usage above 90%"))
+			Expect(items[0].Spec.ScaleDuration).To(Equal("30m"))
+			Expect(items[0].Spec.ScaleThreshold).To(Equal(int32(6)))
+			Expect(items[0].Spec.ScaleTarget.Kind).To(Equal("Deployment"))
+			Expect(items[0].Spec.ScaleTarget.Name).To(Equal("web-app"))
+		})
+
+		It("should map scale mode and replica bounds from the alert", func(ctx SpecContext) {
+			payload := strings.Replace(firingPayload, `"scale_duration": "30m"`,
+				`"scale_duration": "30m", "scale_mode": "Percentage", "scale_min_replicas": "2", "scale_max_replicas": "10"`, 1)
+			Expect(postWebhook(payload).Code).To(Equal(http.StatusOK))
+
+			items := listAlertScales(ctx)
+			Expect(items).To(HaveLen(1))
+			Expect(items[0].Spec.ScaleMode).To(Equal("Percentage"))
+			Expect(*items[0].Spec.MinReplicas).To(Equal(int32(2)))
+			Expect(*items[0].Spec.MaxReplicas).To(Equal(int32(10)))
+		})
+
+		It("should not create duplicates for repeated firings", func(ctx SpecContext) {
+			Expect(postWebhook(firingPayload).Code).To(Equal(http.StatusOK))
+			Expect(postWebhook(firingPayload).Code).To(Equal(http.StatusOK))
+
+			Expect(listAlertScales(ctx)).To(HaveLen(1))
+		})
+
+		It("should name AlertScales after the fingerprint", func(ctx SpecContext) {
+			Expect(postWebhook(firingPayload).Code).To(Equal(http.StatusOK))
+			items := listAlertScales(ctx)
+			Expect(items).To(HaveLen(1))
+			Expect(items[0].Name).To(Equal("alert-abcdef0123456789-1"))
+
+			// 上一个 AlertScale 结束后再次触发使用下一个序号
+			items[0].Status.ScaleStatus.Status = "Completed"
+			Expect(fakeClient.Update(ctx, &items[0])).To(Succeed())
+			Expect(postWebhook(firingPayload).Code).To(Equal(http.StatusOK))
+
+			names := []string{}
+			for _, item := range listAlertScales(ctx) {
+				names = append(names, item.Name)
+			}
+			Expect(names).To(ConsistOf("alert-abcdef0123456789-1", "alert-abcdef0123456789-2"))
+		})
+
+		It("should treat an AlertScale created concurrently as a duplicate", func(ctx SpecContext) {
+			// 另一个并发请求已创建同名对象，但本次列表中还看不到它
+			Expect(fakeClient.Create(ctx, &opsv1beta1.AlertScale{
+				ObjectMeta: metav1.ObjectMeta{Name: "alert-abcdef0123456789-1", Namespace: "default"},
+			})).To(Succeed())
+
+			w := postWebhook(firingPayload)
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(w.Body.String()).To(ContainSubstring(`"action":"skipped"`))
+			Expect(listAlertScales(ctx)).To(HaveLen(1))
+		})
+
+		It("should hash fingerprints that are not valid label values", func(ctx SpecContext) {
+			payload := strings.Replace(firingPayload, `"ABCDEF0123456789"`, `"not a/valid fingerprint"`, 1)
+			Expect(postWebhook(payload).Code).To(Equal(http.StatusOK))
+
+			items := listAlertScales(ctx)
+			Expect(items).To(HaveLen(1))
+			Expect(items[0].Labels[constants.AlertFingerprintLabel]).To(MatchRegexp(`^[0-9a-f]{16}$`))
+		})
+
+		It("should truncate long reasons without splitting characters", func(ctx SpecContext) {
+			payload := strings.Replace(firingPayload, `"CPU usage above 90%"`, `"`+strings.Repeat("扩", 1500)+`"`, 1)
+			Expect(postWebhook(payload).Code).To(Equal(http.StatusOK))
+
+			items := listAlertScales(ctx)
+			Expect(items).To(HaveLen(1))
+			Expect(items[0].Spec.ScaleReason).To(Equal(strings.Repeat("扩", 1024)))
+		})
+
+		It("should mark the AlertScale as resolved", func(ctx SpecContext) {
+			Expect(postWebhook(firingPayload).Code).To(Equal(http.StatusOK))
+
+			resolvedPayload := strings.ReplaceAll(firingPayload, `"firing"`, `"resolved"`)
+			Expect(postWebhook(resolvedPayload).Code).To(Equal(http.StatusOK))
+
+			items := listAlertScales(ctx)
+			Expect(items).To(HaveLen(1))
+			Expect(items[0].Annotations).To(HaveKey(constants.AlertResolvedAnnotation))
+		})
+
+		It("should record the caller as the requester", func(ctx SpecContext) {
+			Expect(postWebhook(firingPayload).Code).To(Equal(http.StatusOK))
+
+			items := listAlertScales(ctx)
+			Expect(items).To(HaveLen(1))
+			Expect(items[0].Annotations).To(HaveKeyWithValue(constants.RequestedByAnnotation, "alice"))
+		})
+
+		It("should not create AlertScales for callers without the create permission", func(ctx SpecContext) {
+			w := postWebhookAs("mallory", firingPayload)
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(w.Body.String()).To(ContainSubstring(`user \"mallory\" is not allowed to create alertscales in namespace \"default\"`))
+			Expect(listAlertScales(ctx)).To(BeEmpty())
+		})
+
+		It("should require the approve permission to request auto-approval", func(ctx SpecContext) {
+			payload := strings.Replace(firingPayload, `"scale_duration": "30m"`, `"scale_duration": "30m", "scale_auto_approval": "true"`, 1)
+			w := postWebhookAs("bob", payload)
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(w.Body.String()).To(ContainSubstring("not allowed to approve alertscales"))
+			Expect(listAlertScales(ctx)).To(BeEmpty())
+
+			Expect(postWebhook(payload).Code).To(Equal(http.StatusOK))
+			items := listAlertScales(ctx)
+			Expect(items).To(HaveLen(1))
+			Expect(items[0].Spec.ScaleAutoApproval).To(BeTrue())
+		})
+
+		It("should require the approve permission to create AlertScales an ApprovalPolicy excludes by labels", func(ctx SpecContext) {
+			Expect(fakeClient.Create(ctx, &opsv1beta1.ApprovalPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "prod"},
+				Spec: opsv1beta1.ApprovalPolicySpec{
+					Namespaces:     []string{"default"},
+					Selector:       &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "critical"}},
+					ApproverGroups: []opsv1beta1.ApproverGroup{{Name: "sre", Users: []string{"carol"}}},
+				},
+			})).To(Succeed())
+
+			w := postWebhookAs("bob", firingPayload)
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(w.Body.String()).To(ContainSubstring("not allowed to approve alertscales"))
+			Expect(listAlertScales(ctx)).To(BeEmpty())
+
+			Expect(postWebhook(firingPayload).Code).To(Equal(http.StatusOK))
+			Expect(listAlertScales(ctx)).To(HaveLen(1))
+		})
+
+		It("should not resolve AlertScales for callers without the update permission", func(ctx SpecContext) {
+			Expect(postWebhook(firingPayload).Code).To(Equal(http.StatusOK))
+
+			resolvedPayload := strings.ReplaceAll(firingPayload, `"firing"`, `"resolved"`)
+			Expect(postWebhookAs("mallory", resolvedPayload).Code).To(Equal(http.StatusOK))
+			Expect(listAlertScales(ctx)[0].Annotations).NotTo(HaveKey(constants.AlertResolvedAnnotation))
+		})
+
+		It("should reject an invalid payload", func() {
+			w := postWebhook("not-json")
+			Expect(w.Code).To(Equal(http.StatusBadRequest))
+		})
+	})
+
+	Describe("AlertScale Actions", func() {
+		postActionAs := func(user, name, action, payload string) *httptest.ResponseRecorder {
+			req := httptest.NewRequest("POST", "/api/v1/alertscales/default/"+name+"/"+action, strings.NewReader(payload))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("Authorization", "Bearer "+user+"-token")
+			w := httptest.NewRecorder()
+			server.router.ServeHTTP(w, req)
+			return w
+		}
+
+		postAction := func(name, action, payload string) *httptest.ResponseRecorder {
+			return postActionAs("alice", name, action, payload)
+		}
+
+		getAlertScale := func(ctx context.Context, name string) *opsv1beta1.AlertScale {
+			alertScale := &opsv1beta1.AlertScale{}
+			Expect(fakeClient.Get(ctx, client.ObjectKey{Namespace: "default", Name: name}, alertScale)).To(Succeed())
+			return alertScale
+		}
+
+		BeforeEach(func(ctx SpecContext) {
+			for name, status := range map[string]string{"running": "Scaled", "pending": "Pending"} {
+				alertScale := &opsv1beta1.AlertScale{
+					ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+					Spec: opsv1beta1.AlertScaleSpec{
+						ScaleDuration: "30m",
+						ScaleTarget:   opsv1beta1.ScaleTarget{Kind: "Deployment", Name: "web-app", Namespace: "default"},
+					},
+					Status: opsv1beta1.AlertScaleStatus{
+						ScaleStatus: opsv1beta1.ScaleStatus{Status: status},
+					},
+				}
+				Expect(fakeClient.Create(ctx, alertScale)).To(Succeed())
+			}
+			server.setupRoutes()
+		})
+
+		It("should record an extend action as annotations", func(ctx SpecContext) {
+			w := postAction("running", "extend", `{"reason": "traffic still high", "duration": "1h"}`)
+			Expect(w.Code).To(Equal(http.StatusOK))
+
+			alertScale := getAlertScale(ctx, "running")
+			Expect(alertScale.Annotations).To(HaveKeyWithValue(constants.ScaleActionAnnotation, constants.ScaleActionExtend))
+			Expect(alertScale.Annotations).To(HaveKeyWithValue(constants.ScaleActionDurationAnnotation, "1h"))
+			Expect(alertScale.Annotations).To(HaveKeyWithValue(constants.ScaleActionOperatorAnnotation, "alice"))
+			Expect(alertScale.Annotations).To(HaveKeyWithValue(constants.ScaleActionProcessingAnnotation, constants.ApprovalProcessingPending))
+		})
+
+		It("should record a cancel action as annotations", func(ctx SpecContext) {
+			w := postAction("running", "cancel", `{"reason": "incident resolved"}`)
+			Expect(w.Code).To(Equal(http.StatusOK))
+
+			alertScale := getAlertScale(ctx, "running")
+			Expect(alertScale.Annotations).To(HaveKeyWithValue(constants.ScaleActionAnnotation, constants.ScaleActionCancel))
+			Expect(alertScale.Annotations).To(HaveKeyWithValue(constants.ScaleActionReasonAnnotation, "incident resolved"))
+			Expect(alertScale.Annotations).NotTo(HaveKey(constants.ScaleActionDurationAnnotation))
+		})
+
+		It("should reject an extend without a valid duration", func() {
+			Expect(postAction("running", "extend", `{}`).Code).To(Equal(http.StatusBadRequest))
+			Expect(postAction("running", "extend", `{"duration": "soon"}`).Code).To(Equal(http.StatusBadRequest))
+			Expect(postAction("running", "extend", `{"duration": "-5m"}`).Code).To(Equal(http.StatusBadRequest))
+		})
+
+		It("should record the authenticated caller as the operator", func(ctx SpecContext) {
+			Expect(postActionAs("bob", "running", "cancel", `{"operator": "alice", "reason": "done"}`).Code).To(Equal(http.StatusOK))
+			Expect(getAlertScale(ctx, "running").Annotations).To(HaveKeyWithValue(constants.ScaleActionOperatorAnnotation, "bob"))
+		})
+
+		It("should forbid callers without the update permission", func(ctx SpecContext) {
+			Expect(postActionAs("mallory", "running", "cancel", `{"reason": "done"}`).Code).To(Equal(http.StatusForbidden))
+			Expect(getAlertScale(ctx, "running").Annotations).NotTo(HaveKey(constants.ScaleActionAnnotation))
+		})
+
+		It("should reject actions on AlertScales that are not running", func() {
+			Expect(postAction("pending", "cancel", `{}`).Code).To(Equal(http.StatusConflict))
+		})
+
+		It("should return 404 for unknown AlertScales", func() {
+			Expect(postAction("missing", "cancel", `{}`).Code).To(Equal(http.StatusNotFound))
+		})
+
+		It("should not overwrite an approval decision that is still being processed", func(ctx SpecContext) {
+			Expect(postAction("pending", "approve", `{"reason": "owner sign-off"}`).Code).To(Equal(http.StatusOK))
+			alertScale := getAlertScale(ctx, "pending")
+			Expect(alertScale.Annotations).To(HaveKeyWithValue(constants.ApprovalOperatorAnnotation, "alice"))
+			Expect(alertScale.Annotations).To(HaveKeyWithValue(constants.ApprovalProcessingAnnotation, constants.ApprovalProcessingPending))
+
+			Expect(postActionAs("carol", "pending", "approve", `{}`).Code).To(Equal(http.StatusConflict))
+			Expect(postActionAs("carol", "pending", "reject", `{}`).Code).To(Equal(http.StatusConflict))
+			Expect(getAlertScale(ctx, "pending").Annotations).To(HaveKeyWithValue(constants.ApprovalOperatorAnnotation, "alice"))
+
+			alertScale.Annotations[constants.ApprovalProcessingAnnotation] = constants.ApprovalProcessingCompleted
+			Expect(fakeClient.Update(ctx, alertScale)).To(Succeed())
+			Expect(postActionAs("carol", "pending", "approve", `{}`).Code).To(Equal(http.StatusOK))
+			Expect(getAlertScale(ctx, "pending").Annotations).To(HaveKeyWithValue(constants.ApprovalOperatorAnnotation, "carol"))
+		})
+
+		It("should record the authenticated caller instead of the approver in the body", func(ctx SpecContext) {
+			Expect(postActionAs("carol", "pending", "approve", `{"approver": "alice"}`).Code).To(Equal(http.StatusOK))
+			Expect(getAlertScale(ctx, "pending").Annotations).To(HaveKeyWithValue(constants.ApprovalOperatorAnnotation, "carol"))
+		})
+
+		It("should forbid callers without the approve permission", func(ctx SpecContext) {
+			Expect(postActionAs("bob", "pending", "approve", `{}`).Code).To(Equal(http.StatusForbidden))
+			Expect(postActionAs("bob", "pending", "reject", `{}`).Code).To(Equal(http.StatusForbidden))
+			Expect(getAlertScale(ctx, "pending").Annotations).NotTo(HaveKey(constants.ApprovalDecisionAnnotation))
+		})
+
+		It("should only approve the batch items the caller is allowed to approve", func(ctx SpecContext) {
+			payload := `{"items": [{"type": "AlertScale", "namespace": "default", "name": "pending"}], "action": "approve"}`
+			req := httptest.NewRequest("POST", "/api/v1/approvals/batch", strings.NewReader(payload))
+			req.Header.Set("Authorization", "Bearer bob-token")
+			w := httptest.NewRecorder()
+			server.router.ServeHTTP(w, req)
+			Expect(w.Code).To(Equal(http.StatusPartialContent))
+			Expect(getAlertScale(ctx, "pending").Annotations).NotTo(HaveKey(constants.ApprovalDecisionAnnotation))
+
+			req = httptest.NewRequest("POST", "/api/v1/approvals/batch", strings.NewReader(payload))
+			Expect(serve(server, req).Code).To(Equal(http.StatusOK))
+			Expect(getAlertScale(ctx, "pending").Annotations).To(HaveKeyWithValue(constants.ApprovalOperatorAnnotation, "alice"))
+		})
+	})
+
+	Describe("AlertScale History", func() {
+		getHistory := func(name string) *httptest.ResponseRecorder {
+			req := httptest.NewRequest("GET", "/api/v1/alertscales/default/"+name+"/history", nil)
+			return serve(server, req)
+		}
+
+		BeforeEach(func(ctx SpecContext) {
+			alertScale := &opsv1beta1.AlertScale{
+				ObjectMeta: metav1.ObjectMeta{Name: "scaled", Namespace: "default"},
+				Spec: opsv1beta1.AlertScaleSpec{
+					ScaleDuration: "30m",
+					ScaleTarget:   opsv1beta1.ScaleTarget{Kind: "Deployment", Name: "web-app", Namespace: "default"},
+				},
+				Status: opsv1beta1.AlertScaleStatus{
+					ScaleStatus: opsv1beta1.ScaleStatus{Status: "Scaled"},
+					History: []opsv1beta1.TransitionRecord{
+						{To: "Pending", Actor: "system", Reason: "Created", Time: metav1.Now()},
+						{From: "Pending", To: "Approvaling", Actor: "system", Reason: "AwaitingApproval", Time: metav1.Now()},
+						{From: "Approvaling", To: "Approved", Actor: "alice", Reason: "Approved", Time: metav1.Now()},
+					},
+				},
+			}
+			Expect(fakeClient.Create(ctx, alertScale)).To(Succeed())
+			server.setupRoutes()
+		})
+
+		It("should return the transition history", func() {
+			w := getHistory("scaled")
+			Expect(w.Code).To(Equal(http.StatusOK))
+
+			var response struct {
+				Success bool `json:"success"`
+				Data    struct {
+					Status string                        `json:"status"`
+					Items  []opsv1beta1.TransitionRecord `json:"items"`
+					Count  int                           `json:"count"`
+				} `json:"data"`
+			}
+			Expect(json.Unmarshal(w.Body.Bytes(), &response)).To(Succeed())
+			Expect(response.Data.Status).To(Equal("Scaled"))
+			Expect(response.Data.Count).To(Equal(3))
+			Expect(response.Data.Items[2].Actor).To(Equal("alice"))
+			Expect(response.Data.Items[2].From).To(Equal("Approvaling"))
+		})
+
+		It("should return 404 for unknown AlertScales", func() {
+			Expect(getHistory("missing").Code).To(Equal(http.StatusNotFound))
+		})
+	})
+
+	Describe("PodRebalance Approvals", func() {
+		postAs := func(user, path, payload string) *httptest.ResponseRecorder {
+			req := httptest.NewRequest("POST", "/api/v1/podrebalances"+path, strings.NewReader(payload))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("Authorization", "Bearer "+user+"-token")
+			w := httptest.NewRecorder()
+			server.router.ServeHTTP(w, req)
+			return w
+		}
+
+		getPodRebalance := func(ctx context.Context) *opsv1beta1.PodRebalance {
+			podRebalance := &opsv1beta1.PodRebalance{}
+			Expect(fakeClient.Get(ctx, client.ObjectKey{Namespace: "default", Name: "rebalance"}, podRebalance)).To(Succeed())
+			return podRebalance
+		}
+
+		BeforeEach(func(ctx SpecContext) {
+			Expect(fakeClient.Create(ctx, &opsv1beta1.PodRebalance{
+				ObjectMeta: metav1.ObjectMeta{Name: "rebalance", Namespace: "default"},
+				Status:     opsv1beta1.PodRebalanceStatus{Status: types.RebalanceStatusApprovaling},
+			})).To(Succeed())
+			server.setupRoutes()
+		})
+
+		It("should forbid callers without the approve permission", func(ctx SpecContext) {
+			Expect(postAs("bob", "/rebalance/approve", `{"reason": "looks fine"}`).Code).To(Equal(http.StatusForbidden))
+			Expect(postAs("mallory", "/rebalance/reject", `{"reason": "no"}`).Code).To(Equal(http.StatusForbidden))
+			Expect(getPodRebalance(ctx).Annotations).NotTo(HaveKey(constants.ApprovalDecisionAnnotation))
+		})
+
+		It("should record the authorized caller as the approver", func(ctx SpecContext) {
+			Expect(postAs("carol", "/rebalance/approve", `{"reason": "looks fine"}`).Code).To(Equal(http.StatusOK))
+			annotations := getPodRebalance(ctx).Annotations
+			Expect(annotations).To(HaveKeyWithValue(constants.ApprovalDecisionAnnotation, "approve"))
+			Expect(annotations).To(HaveKeyWithValue(constants.ApprovalOperatorAnnotation, "carol"))
+		})
+
+		It("should forbid creating PodRebalances without the create permission", func() {
+			w := postAs("mallory", "", `{"namespace": "default", "strategy": {"type": "Balance"}}`)
+			Expect(w.Code).To(Equal(http.StatusForbidden))
+		})
+	})
 })
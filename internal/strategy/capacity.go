@@ -0,0 +1,287 @@
+package strategy
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	opsv1beta1 "udesk.cn/ops/api/v1beta1"
+	"udesk.cn/ops/internal/types"
+)
+
+// quotaRequestsPrefix 和 quotaLimitsPrefix ResourceQuota 中按 requests 和 limits 计算的资源名前缀
+const (
+	quotaRequestsPrefix = "requests."
+	quotaLimitsPrefix   = "limits."
+)
+
+// CheckCapacity 检查集群和目标命名空间的 ResourceQuota 能否容纳新增的副本
+// 节点容量为满足 Pod 模板 nodeSelector 和污点容忍的可调度节点上 allocatable 减去已调度 Pod requests 的总和，
+// 不考虑节点亲和性和资源碎片，只用于提前发现明显的容量不足。
+// 节点和 Pod 通过 reader 直接从 API server 读取，避免为偶尔的预检缓存集群中所有的 Pod
+func CheckCapacity(ctx context.Context, c client.Client, reader client.Reader, target *opsv1beta1.ScaleTarget, additionalReplicas int32) (*opsv1beta1.CapacityCheck, error) {
+	check := &opsv1beta1.CapacityCheck{
+		Time:               metav1.Now(),
+		Result:             types.CapacityResultSufficient,
+		AdditionalReplicas: additionalReplicas,
+	}
+
+	template, err := GetPodTemplate(ctx, c, target)
+	if err != nil {
+		return nil, err
+	}
+	if template == nil {
+		check.Result = types.CapacityResultUnknown
+		check.Message = fmt.Sprintf("%s %s/%s has no pod template, capacity not checked", target.Kind, target.Namespace, target.Name)
+		return check, nil
+	}
+
+	requests := podResources(&template.Spec, func(r corev1.ResourceRequirements) corev1.ResourceList { return r.Requests })
+	limits := podResources(&template.Spec, func(r corev1.ResourceRequirements) corev1.ResourceList { return r.Limits })
+	check.Required = multiplyResources(requests, additionalReplicas)
+
+	available, err := schedulableCapacity(ctx, reader, &template.Spec)
+	if err != nil {
+		return nil, err
+	}
+	check.Available = available
+
+	var shortfalls []string
+	for _, name := range sortedResourceNames(check.Required) {
+		required := check.Required[name]
+		free := available[name]
+		if required.Cmp(free) > 0 {
+			shortfalls = append(shortfalls, fmt.Sprintf("%s: requires %s, schedulable nodes have %s", name, required.String(), free.String()))
+		}
+	}
+
+	remaining, err := quotaRemaining(ctx, c, target.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	check.QuotaRemaining = remaining
+	for _, name := range sortedResourceNames(remaining) {
+		required, ok := quotaUsage(name, check.Required, multiplyResources(limits, additionalReplicas), additionalReplicas)
+		if !ok {
+			continue
+		}
+		left := remaining[name]
+		if required.Cmp(left) > 0 {
+			shortfalls = append(shortfalls, fmt.Sprintf("%s: requires %s, ResourceQuota has %s left", name, required.String(), left.String()))
+		}
+	}
+
+	if len(shortfalls) > 0 {
+		check.Result = types.CapacityResultInsufficient
+		check.Message = fmt.Sprintf("Insufficient capacity for %d additional replicas: %s", additionalReplicas, strings.Join(shortfalls, "; "))
+	} else {
+		check.Message = fmt.Sprintf("Sufficient capacity for %d additional replicas", additionalReplicas)
+	}
+	return check, nil
+}
+
+// GetPodTemplate 读取目标工作负载的 spec.template，目标没有 Pod 模板时返回 nil
+func GetPodTemplate(ctx context.Context, c client.Client, target *opsv1beta1.ScaleTarget) (*corev1.PodTemplateSpec, error) {
+	// Deployment、StatefulSet、ReplicaSet 和大多数 CRD 的模板都位于 spec.template
+	obj, err := GetTargetObject(ctx, c, target)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, found, err := unstructured.NestedMap(obj.Object, "spec", "template")
+	if err != nil || !found {
+		return nil, err
+	}
+	template := &corev1.PodTemplateSpec{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(raw, template); err != nil {
+		return nil, fmt.Errorf("failed to decode pod template of %s %s/%s: %v", target.Kind, target.Namespace, target.Name, err)
+	}
+	return template, nil
+}
+
+// podResources 计算单个 Pod 的资源量：容器之和与每个 init 容器取较大值，再加上 Pod overhead
+func podResources(spec *corev1.PodSpec, get func(corev1.ResourceRequirements) corev1.ResourceList) corev1.ResourceList {
+	total := corev1.ResourceList{}
+	for _, container := range spec.Containers {
+		addResources(total, get(container.Resources))
+	}
+	for _, container := range spec.InitContainers {
+		for name, quantity := range get(container.Resources) {
+			if current, ok := total[name]; !ok || quantity.Cmp(current) > 0 {
+				total[name] = quantity.DeepCopy()
+			}
+		}
+	}
+	addResources(total, spec.Overhead)
+	return total
+}
+
+// schedulableCapacity 汇总可以调度该 Pod 的节点上剩余的可分配资源，只读取这些节点上的 Pod
+func schedulableCapacity(ctx context.Context, reader client.Reader, spec *corev1.PodSpec) (corev1.ResourceList, error) {
+	nodeList := &corev1.NodeList{}
+	if err := reader.List(ctx, nodeList); err != nil {
+		return nil, err
+	}
+
+	available := corev1.ResourceList{}
+	for i := range nodeList.Items {
+		node := &nodeList.Items[i]
+		if !nodeFitsPod(node, spec) {
+			continue
+		}
+		requested, err := nodeRequests(ctx, reader, node.Name)
+		if err != nil {
+			return nil, err
+		}
+		for name, allocatable := range node.Status.Allocatable {
+			free := allocatable.DeepCopy()
+			if used, ok := requested[name]; ok {
+				free.Sub(used)
+			}
+			if free.Sign() <= 0 {
+				continue
+			}
+			if current, ok := available[name]; ok {
+				free.Add(current)
+			}
+			available[name] = free
+		}
+	}
+	return available, nil
+}
+
+// nodeRequests 汇总调度到节点上且未结束的 Pod 的 requests
+func nodeRequests(ctx context.Context, reader client.Reader, nodeName string) (corev1.ResourceList, error) {
+	podList := &corev1.PodList{}
+	if err := reader.List(ctx, podList, client.MatchingFields{"spec.nodeName": nodeName}); err != nil {
+		return nil, err
+	}
+
+	requested := corev1.ResourceList{}
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		addResources(requested, podResources(&pod.Spec, func(r corev1.ResourceRequirements) corev1.ResourceList { return r.Requests }))
+	}
+	return requested, nil
+}
+
+// nodeFitsPod 判断节点可调度、已就绪、匹配 nodeSelector 且 Pod 容忍其所有 NoSchedule 和 NoExecute 污点
+func nodeFitsPod(node *corev1.Node, spec *corev1.PodSpec) bool {
+	if node.Spec.Unschedulable {
+		return false
+	}
+	ready := false
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady {
+			ready = condition.Status == corev1.ConditionTrue
+		}
+	}
+	if !ready {
+		return false
+	}
+	if !labels.SelectorFromSet(spec.NodeSelector).Matches(labels.Set(node.Labels)) {
+		return false
+	}
+
+	for i := range node.Spec.Taints {
+		taint := &node.Spec.Taints[i]
+		if taint.Effect == corev1.TaintEffectPreferNoSchedule {
+			continue
+		}
+		tolerated := false
+		for j := range spec.Tolerations {
+			if spec.Tolerations[j].ToleratesTaint(taint) {
+				tolerated = true
+				break
+			}
+		}
+		if !tolerated {
+			return false
+		}
+	}
+	return true
+}
+
+// quotaRemaining 返回命名空间内所有 ResourceQuota 中每种资源最小的剩余量
+func quotaRemaining(ctx context.Context, c client.Client, namespace string) (corev1.ResourceList, error) {
+	quotaList := &corev1.ResourceQuotaList{}
+	if err := c.List(ctx, quotaList, client.InNamespace(namespace)); err != nil {
+		return nil, err
+	}
+
+	remaining := corev1.ResourceList{}
+	for _, quota := range quotaList.Items {
+		for name, hard := range quota.Status.Hard {
+			left := hard.DeepCopy()
+			if used, ok := quota.Status.Used[name]; ok {
+				left.Sub(used)
+			}
+			if current, ok := remaining[name]; !ok || left.Cmp(current) < 0 {
+				remaining[name] = left
+			}
+		}
+	}
+	return remaining, nil
+}
+
+// quotaUsage 返回新增副本在 ResourceQuota 资源名下的用量，不统计的资源返回 false
+func quotaUsage(name corev1.ResourceName, requests, limits corev1.ResourceList, replicas int32) (resource.Quantity, bool) {
+	key := string(name)
+	switch {
+	case name == corev1.ResourcePods || key == "count/pods":
+		return *resource.NewQuantity(int64(replicas), resource.DecimalSI), true
+	case strings.HasPrefix(key, quotaRequestsPrefix):
+		quantity, ok := requests[corev1.ResourceName(strings.TrimPrefix(key, quotaRequestsPrefix))]
+		return quantity, ok
+	case strings.HasPrefix(key, quotaLimitsPrefix):
+		quantity, ok := limits[corev1.ResourceName(strings.TrimPrefix(key, quotaLimitsPrefix))]
+		return quantity, ok
+	case name == corev1.ResourceCPU || name == corev1.ResourceMemory || name == corev1.ResourceEphemeralStorage:
+		// 不带前缀的计算资源等同于 requests
+		quantity, ok := requests[name]
+		return quantity, ok
+	}
+	return resource.Quantity{}, false
+}
+
+func addResources(total, add corev1.ResourceList) {
+	for name, quantity := range add {
+		if current, ok := total[name]; ok {
+			current.Add(quantity)
+			total[name] = current
+		} else {
+			total[name] = quantity.DeepCopy()
+		}
+	}
+}
+
+func multiplyResources(list corev1.ResourceList, n int32) corev1.ResourceList {
+	result := corev1.ResourceList{}
+	for name, quantity := range list {
+		if quantity.IsZero() {
+			continue
+		}
+		result[name] = *resource.NewMilliQuantity(quantity.MilliValue()*int64(n), quantity.Format)
+	}
+	return result
+}
+
+func sortedResourceNames(list corev1.ResourceList) []corev1.ResourceName {
+	names := make([]corev1.ResourceName, 0, len(list))
+	for name := range list {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool { return names[i] < names[j] })
+	return names
+}
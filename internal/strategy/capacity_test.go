@@ -0,0 +1,177 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package strategy
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	opsv1beta1 "udesk.cn/ops/api/v1beta1"
+	scaletypes "udesk.cn/ops/internal/types"
+)
+
+var _ = Describe("CheckCapacity", func() {
+	var (
+		ctx        context.Context
+		deployment *appv1.Deployment
+		target     *opsv1beta1.ScaleTarget
+		objects    []client.Object
+	)
+
+	node := func(name string, cpu string, modify func(*corev1.Node)) *corev1.Node {
+		n := &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{"pool": "web"}},
+			Status: corev1.NodeStatus{
+				Allocatable: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse(cpu),
+					corev1.ResourceMemory: resource.MustParse("8Gi"),
+				},
+				Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}},
+			},
+		}
+		if modify != nil {
+			modify(n)
+		}
+		return n
+	}
+
+	check := func(additionalReplicas int32) *opsv1beta1.CapacityCheck {
+		scheme := runtime.NewScheme()
+		Expect(appv1.AddToScheme(scheme)).To(Succeed())
+		Expect(corev1.AddToScheme(scheme)).To(Succeed())
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(append(objects, deployment)...).
+			WithIndex(&corev1.Pod{}, "spec.nodeName", func(obj client.Object) []string {
+				return []string{obj.(*corev1.Pod).Spec.NodeName}
+			}).Build()
+
+		result, err := CheckCapacity(ctx, fakeClient, fakeClient, target, additionalReplicas)
+		Expect(err).NotTo(HaveOccurred())
+		return result
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		deployment = &appv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+			Spec: appv1.DeploymentSpec{
+				Replicas: int32Ptr(2),
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						NodeSelector: map[string]string{"pool": "web"},
+						Containers: []corev1.Container{{
+							Name: "web",
+							Resources: corev1.ResourceRequirements{
+								Requests: corev1.ResourceList{
+									corev1.ResourceCPU:    resource.MustParse("500m"),
+									corev1.ResourceMemory: resource.MustParse("1Gi"),
+								},
+							},
+						}},
+					},
+				},
+			},
+		}
+		target = &opsv1beta1.ScaleTarget{Kind: KindDeployment, Name: "web", Namespace: "default"}
+		objects = nil
+	})
+
+	It("should pass when the matching nodes have enough free resources", func() {
+		objects = []client.Object{node("node-a", "2", nil), node("node-b", "2", nil)}
+
+		result := check(6)
+		Expect(result.Result).To(Equal(scaletypes.CapacityResultSufficient))
+		Expect(result.Required.Cpu().String()).To(Equal("3"))
+		Expect(result.Available.Cpu().String()).To(Equal("4"))
+	})
+
+	It("should subtract the requests of scheduled pods", func() {
+		objects = []client.Object{
+			node("node-a", "2", nil),
+			&corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "busy", Namespace: "other"},
+				Spec: corev1.PodSpec{
+					NodeName: "node-a",
+					Containers: []corev1.Container{{
+						Name: "busy",
+						Resources: corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1500m")},
+						},
+					}},
+				},
+				Status: corev1.PodStatus{Phase: corev1.PodRunning},
+			},
+		}
+
+		result := check(2)
+		Expect(result.Result).To(Equal(scaletypes.CapacityResultInsufficient))
+		Expect(result.Available.Cpu().String()).To(Equal("500m"))
+		Expect(result.Message).To(ContainSubstring("cpu: requires 1, schedulable nodes have 500m"))
+	})
+
+	It("should only count nodes matching the nodeSelector and tolerations", func() {
+		objects = []client.Object{
+			node("node-a", "2", nil),
+			node("node-other-pool", "16", func(n *corev1.Node) { n.Labels["pool"] = "batch" }),
+			node("node-tainted", "16", func(n *corev1.Node) {
+				n.Spec.Taints = []corev1.Taint{{Key: "dedicated", Value: "gpu", Effect: corev1.TaintEffectNoSchedule}}
+			}),
+			node("node-cordoned", "16", func(n *corev1.Node) { n.Spec.Unschedulable = true }),
+		}
+
+		Expect(check(6).Result).To(Equal(scaletypes.CapacityResultInsufficient))
+
+		deployment.Spec.Template.Spec.Tolerations = []corev1.Toleration{{
+			Key: "dedicated", Operator: corev1.TolerationOpEqual, Value: "gpu", Effect: corev1.TaintEffectNoSchedule,
+		}}
+		result := check(6)
+		Expect(result.Result).To(Equal(scaletypes.CapacityResultSufficient))
+		Expect(result.Available.Cpu().String()).To(Equal("18"))
+	})
+
+	It("should check the remaining ResourceQuota of the namespace", func() {
+		objects = []client.Object{
+			node("node-a", "16", nil),
+			&corev1.ResourceQuota{
+				ObjectMeta: metav1.ObjectMeta{Name: "quota", Namespace: "default"},
+				Status: corev1.ResourceQuotaStatus{
+					Hard: corev1.ResourceList{
+						corev1.ResourceRequestsCPU: resource.MustParse("4"),
+						corev1.ResourcePods:        resource.MustParse("10"),
+					},
+					Used: corev1.ResourceList{
+						corev1.ResourceRequestsCPU: resource.MustParse("3"),
+						corev1.ResourcePods:        resource.MustParse("6"),
+					},
+				},
+			},
+		}
+
+		result := check(4)
+		Expect(result.Result).To(Equal(scaletypes.CapacityResultInsufficient))
+		Expect(result.QuotaRemaining.Name(corev1.ResourceRequestsCPU, resource.DecimalSI).String()).To(Equal("1"))
+		Expect(result.Message).To(ContainSubstring("requests.cpu: requires 2, ResourceQuota has 1 left"))
+		Expect(result.Message).NotTo(ContainSubstring("pods:"))
+	})
+})
@@ -0,0 +1,91 @@
+package strategy
+
+import (
+	"context"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	opsv1beta1 "udesk.cn/ops/api/v1beta1"
+	"udesk.cn/ops/internal/types"
+)
+
+// DryRunStrategy 试运行策略，包装实际的扩缩容策略：
+// Scale 只将副本数记录到 AlertScale 的试运行计划，不修改工作负载；
+// 读取副本数时以计划中该工作负载最近一次调整为准，使状态机按已完成扩缩容继续流转
+type DryRunStrategy struct {
+	inner      types.ScaleStrategy
+	alertScale *opsv1beta1.AlertScale
+}
+
+// NewDryRunStrategy 创建试运行策略，计划记录到 alertScale 的状态中，由调用方提交状态更新
+func NewDryRunStrategy(inner types.ScaleStrategy, alertScale *opsv1beta1.AlertScale) *DryRunStrategy {
+	return &DryRunStrategy{inner: inner, alertScale: alertScale}
+}
+
+func (s *DryRunStrategy) Scale(ctx context.Context, c client.Client, target *opsv1beta1.ScaleTarget, replicas int32) error {
+	ref := types.TargetRef(target)
+	types.RecordPlannedTargetAction(s.alertScale, ref, types.PlannedActionScale, replicas,
+		fmt.Sprintf("Scale %s to %d replicas", ref, replicas))
+	return nil
+}
+
+func (s *DryRunStrategy) GetCurrentReplicas(ctx context.Context, c client.Client, target *opsv1beta1.ScaleTarget) (int32, error) {
+	if replicas, planned := types.PlannedTargetReplicas(s.alertScale, types.TargetRef(target)); planned {
+		return replicas, nil
+	}
+	return s.inner.GetCurrentReplicas(ctx, c, target)
+}
+
+func (s *DryRunStrategy) GetAvailableReplicas(ctx context.Context, c client.Client, target *opsv1beta1.ScaleTarget) (int32, error) {
+	if replicas, planned := types.PlannedTargetReplicas(s.alertScale, types.TargetRef(target)); planned {
+		return replicas, nil
+	}
+	return s.inner.GetAvailableReplicas(ctx, c, target)
+}
+
+// DryRunResourceStrategy 纵向扩缩容的试运行策略：
+// SetResources 只将容器资源记录到试运行计划，读取资源时以计划中最近一次调整为准，滚动更新视为立即完成
+type DryRunResourceStrategy struct {
+	inner      types.ResourceStrategy
+	alertScale *opsv1beta1.AlertScale
+}
+
+// NewDryRunResourceStrategy 创建纵向扩缩容的试运行策略，计划记录到 alertScale 的状态中，由调用方提交状态更新
+func NewDryRunResourceStrategy(inner types.ResourceStrategy, alertScale *opsv1beta1.AlertScale) *DryRunResourceStrategy {
+	return &DryRunResourceStrategy{inner: inner, alertScale: alertScale}
+}
+
+func (s *DryRunResourceStrategy) GetResources(ctx context.Context, c client.Client, target *opsv1beta1.ScaleTarget) ([]opsv1beta1.ContainerResources, error) {
+	current, err := s.inner.GetResources(ctx, c, target)
+	if err != nil {
+		return nil, err
+	}
+	planned, ok := types.PlannedResources(s.alertScale)
+	if !ok {
+		return current, nil
+	}
+
+	// 计划只包含调整过的容器，其余容器仍取实际值
+	for i := range current {
+		for _, resources := range planned {
+			if resources.Name == current[i].Name {
+				current[i].Resources = *resources.Resources.DeepCopy()
+			}
+		}
+	}
+	return current, nil
+}
+
+func (s *DryRunResourceStrategy) SetResources(ctx context.Context, c client.Client, target *opsv1beta1.ScaleTarget, resources []opsv1beta1.ContainerResources) error {
+	names := make([]string, 0, len(resources))
+	for _, container := range resources {
+		names = append(names, container.Name)
+	}
+	types.RecordPlannedResize(s.alertScale, resources,
+		fmt.Sprintf("Set resources of containers %v in %s %s/%s", names, target.Kind, target.Namespace, target.Name))
+	return nil
+}
+
+func (s *DryRunResourceStrategy) IsRolledOut(ctx context.Context, c client.Client, target *opsv1beta1.ScaleTarget) (bool, error) {
+	return true, nil
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package strategy
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	opsv1beta1 "udesk.cn/ops/api/v1beta1"
+	scaletypes "udesk.cn/ops/internal/types"
+)
+
+var _ = Describe("DryRunStrategy", func() {
+	var (
+		ctx        context.Context
+		fakeClient client.Client
+		deployment *appv1.Deployment
+		target     *opsv1beta1.ScaleTarget
+		alertScale *opsv1beta1.AlertScale
+		dryRun     *DryRunStrategy
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		scheme := runtime.NewScheme()
+		Expect(appv1.AddToScheme(scheme)).To(Succeed())
+
+		deployment = &appv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+			Spec:       appv1.DeploymentSpec{Replicas: int32Ptr(3)},
+			Status:     appv1.DeploymentStatus{AvailableReplicas: 3},
+		}
+		fakeClient = fake.NewClientBuilder().WithScheme(scheme).WithObjects(deployment).Build()
+
+		target = &opsv1beta1.ScaleTarget{Kind: KindDeployment, Name: "web", Namespace: "default"}
+		alertScale = &opsv1beta1.AlertScale{
+			Spec: opsv1beta1.AlertScaleSpec{ScaleTarget: *target, DryRun: true},
+			Status: opsv1beta1.AlertScaleStatus{
+				ScaleStatus: opsv1beta1.ScaleStatus{Status: scaletypes.ScaleStatusScaling},
+			},
+		}
+		dryRun = NewDryRunStrategy(&DeploymentStrategy{}, alertScale)
+	})
+
+	It("should read the real replicas before anything is planned", func() {
+		replicas, err := dryRun.GetCurrentReplicas(ctx, fakeClient, target)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(replicas).To(Equal(int32(3)))
+	})
+
+	It("should record the plan without scaling the workload", func() {
+		Expect(dryRun.Scale(ctx, fakeClient, target, 6)).To(Succeed())
+
+		current := &appv1.Deployment{}
+		Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(deployment), current)).To(Succeed())
+		Expect(*current.Spec.Replicas).To(Equal(int32(3)))
+
+		Expect(alertScale.Status.Plan).To(HaveLen(1))
+		Expect(alertScale.Status.Plan[0].Action).To(Equal(scaletypes.PlannedActionScale))
+		Expect(alertScale.Status.Plan[0].Replicas).To(Equal(int32(6)))
+		Expect(alertScale.Status.Plan[0].Status).To(Equal(scaletypes.ScaleStatusScaling))
+
+		replicas, err := dryRun.GetCurrentReplicas(ctx, fakeClient, target)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(replicas).To(Equal(int32(6)))
+		available, err := dryRun.GetAvailableReplicas(ctx, fakeClient, target)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(available).To(Equal(int32(6)))
+	})
+
+	It("should not record the same scale twice in a row", func() {
+		Expect(dryRun.Scale(ctx, fakeClient, target, 6)).To(Succeed())
+		Expect(dryRun.Scale(ctx, fakeClient, target, 6)).To(Succeed())
+		Expect(alertScale.Status.Plan).To(HaveLen(1))
+	})
+})
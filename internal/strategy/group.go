@@ -0,0 +1,118 @@
+package strategy
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	opsv1beta1 "udesk.cn/ops/api/v1beta1"
+)
+
+// GroupStrategy 多目标 AlertScale 的扩缩容策略，每次调用时按目标类型选择实际的策略
+type GroupStrategy struct{}
+
+// NewGroupStrategy 创建多目标扩缩容策略
+func NewGroupStrategy() *GroupStrategy {
+	return &GroupStrategy{}
+}
+
+func (s *GroupStrategy) Scale(ctx context.Context, c client.Client, target *opsv1beta1.ScaleTarget, replicas int32) error {
+	inner, err := NewScaleStrategy(c, target)
+	if err != nil {
+		return err
+	}
+	return inner.Scale(ctx, c, target, replicas)
+}
+
+func (s *GroupStrategy) GetCurrentReplicas(ctx context.Context, c client.Client, target *opsv1beta1.ScaleTarget) (int32, error) {
+	inner, err := NewScaleStrategy(c, target)
+	if err != nil {
+		return 0, err
+	}
+	return inner.GetCurrentReplicas(ctx, c, target)
+}
+
+func (s *GroupStrategy) GetAvailableReplicas(ctx context.Context, c client.Client, target *opsv1beta1.ScaleTarget) (int32, error) {
+	inner, err := NewScaleStrategy(c, target)
+	if err != nil {
+		return 0, err
+	}
+	return inner.GetAvailableReplicas(ctx, c, target)
+}
+
+// ResolveGroupTargets 返回多目标 AlertScale 的成员：先是 spec.scaleTargets，再按名称顺序追加
+// spec.scaleTargetSelector 选中的其他工作负载。未指定命名空间的目标默认使用 AlertScale 的命名空间
+func ResolveGroupTargets(ctx context.Context, c client.Client, alertScale *opsv1beta1.AlertScale) ([]opsv1beta1.GroupTarget, error) {
+	var members []opsv1beta1.GroupTarget
+	for _, member := range alertScale.Spec.ScaleTargets {
+		member := *member.DeepCopy()
+		if member.Namespace == "" {
+			member.Namespace = alertScale.Namespace
+		}
+		members = append(members, member)
+	}
+
+	selector := alertScale.Spec.ScaleTargetSelector
+	if selector == nil {
+		return members, nil
+	}
+	selected, err := SelectScaleTargets(ctx, c, selector, alertScale.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	for i := range selected {
+		duplicate := false
+		for j := range members {
+			if SameScaleTarget(&members[j].ScaleTarget, &selected[i]) {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			members = append(members, opsv1beta1.GroupTarget{ScaleTarget: selected[i]})
+		}
+	}
+	return members, nil
+}
+
+// SelectScaleTargets 列出命名空间内匹配标签选择器的工作负载，空选择器视为错误以免选中命名空间内的所有工作负载
+func SelectScaleTargets(ctx context.Context, c client.Client, selector *opsv1beta1.ScaleTargetSelector, defaultNamespace string) ([]opsv1beta1.ScaleTarget, error) {
+	if len(selector.Selector.MatchLabels) == 0 && len(selector.Selector.MatchExpressions) == 0 {
+		return nil, fmt.Errorf("%w: label selector must not be empty", ErrUnsupportedScaleTarget)
+	}
+	labelSelector, err := metav1.LabelSelectorAsSelector(&selector.Selector)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid label selector: %v", ErrUnsupportedScaleTarget, err)
+	}
+
+	namespace := selector.Namespace
+	if namespace == "" {
+		namespace = defaultNamespace
+	}
+	gvk, err := resolveTargetGVK(c, &opsv1beta1.ScaleTarget{Kind: selector.Kind, APIVersion: selector.APIVersion})
+	if err != nil {
+		return nil, err
+	}
+
+	// manager 不缓存 unstructured 对象，这里直接从 API server 列出，需要该类型的 list 权限
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+	if err := c.List(ctx, list, client.InNamespace(namespace), client.MatchingLabelsSelector{Selector: labelSelector}); err != nil {
+		return nil, err
+	}
+
+	targets := make([]opsv1beta1.ScaleTarget, 0, len(list.Items))
+	for _, item := range list.Items {
+		targets = append(targets, opsv1beta1.ScaleTarget{
+			Kind:       selector.Kind,
+			APIVersion: selector.APIVersion,
+			Name:       item.GetName(),
+			Namespace:  item.GetNamespace(),
+		})
+	}
+	sort.Slice(targets, func(i, j int) bool { return targets[i].Name < targets[j].Name })
+	return targets, nil
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package strategy
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	opsv1beta1 "udesk.cn/ops/api/v1beta1"
+)
+
+var _ = Describe("ResolveGroupTargets", func() {
+	var (
+		ctx        context.Context
+		fakeClient client.Client
+		alertScale *opsv1beta1.AlertScale
+	)
+
+	deployment := func(name, namespace string, labels map[string]string) *appv1.Deployment {
+		return &appv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: labels},
+			Spec:       appv1.DeploymentSpec{Replicas: int32Ptr(2)},
+		}
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		scheme := runtime.NewScheme()
+		Expect(appv1.AddToScheme(scheme)).To(Succeed())
+		fakeClient = fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			deployment("web", "default", map[string]string{"tier": "api"}),
+			deployment("api", "default", map[string]string{"tier": "api"}),
+			deployment("worker", "default", map[string]string{"tier": "worker"}),
+			deployment("api", "other", map[string]string{"tier": "api"}),
+		).Build()
+
+		alertScale = &opsv1beta1.AlertScale{
+			ObjectMeta: metav1.ObjectMeta{Name: "group", Namespace: "default"},
+			Spec: opsv1beta1.AlertScaleSpec{
+				ScaleTargets: []opsv1beta1.GroupTarget{
+					{ScaleTarget: opsv1beta1.ScaleTarget{Kind: KindDeployment, Name: "web"}, Replicas: int32Ptr(5)},
+				},
+				ScaleTargetSelector: &opsv1beta1.ScaleTargetSelector{
+					Kind:     KindDeployment,
+					Selector: metav1.LabelSelector{MatchLabels: map[string]string{"tier": "api"}},
+				},
+			},
+		}
+	})
+
+	It("should list the explicit members first and skip duplicates from the selector", func() {
+		members, err := ResolveGroupTargets(ctx, fakeClient, alertScale)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(members).To(HaveLen(2))
+		Expect(members[0].Name).To(Equal("web"))
+		Expect(members[0].Namespace).To(Equal("default"))
+		Expect(*members[0].Replicas).To(Equal(int32(5)))
+		Expect(members[1].Name).To(Equal("api"))
+		Expect(members[1].Namespace).To(Equal("default"))
+		Expect(members[1].Replicas).To(BeNil())
+	})
+
+	It("should select workloads in the namespace of the selector", func() {
+		alertScale.Spec.ScaleTargets = nil
+		alertScale.Spec.ScaleTargetSelector.Namespace = "other"
+
+		members, err := ResolveGroupTargets(ctx, fakeClient, alertScale)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(members).To(HaveLen(1))
+		Expect(members[0].Namespace).To(Equal("other"))
+	})
+
+	It("should reject an empty selector", func() {
+		alertScale.Spec.ScaleTargetSelector.Selector = metav1.LabelSelector{}
+
+		_, err := ResolveGroupTargets(ctx, fakeClient, alertScale)
+		Expect(err).To(MatchError(ErrUnsupportedScaleTarget))
+	})
+})
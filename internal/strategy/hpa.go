@@ -0,0 +1,113 @@
+package strategy
+
+import (
+	"context"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	opsv1beta1 "udesk.cn/ops/api/v1beta1"
+)
+
+// FindHPA 查找以目标工作负载为 scaleTargetRef 的 HPA，不存在时返回 nil
+func FindHPA(ctx context.Context, c client.Client, target *opsv1beta1.ScaleTarget) (*autoscalingv2.HorizontalPodAutoscaler, error) {
+	hpaList := &autoscalingv2.HorizontalPodAutoscalerList{}
+	if err := c.List(ctx, hpaList, client.InNamespace(target.Namespace)); err != nil {
+		return nil, err
+	}
+
+	targetGroup := apiGroupOf(target.APIVersion, target.Kind)
+	for i := range hpaList.Items {
+		ref := hpaList.Items[i].Spec.ScaleTargetRef
+		if ref.Kind == target.Kind && ref.Name == target.Name && apiGroupOf(ref.APIVersion, ref.Kind) == targetGroup {
+			return &hpaList.Items[i], nil
+		}
+	}
+
+	return nil, nil
+}
+
+// SnapshotHPA 记录 HPA 的原始副本数边界
+func SnapshotHPA(hpa *autoscalingv2.HorizontalPodAutoscaler) *opsv1beta1.HPASnapshot {
+	snapshot := &opsv1beta1.HPASnapshot{
+		Name:        hpa.Name,
+		MaxReplicas: hpa.Spec.MaxReplicas,
+	}
+	if hpa.Spec.MinReplicas != nil {
+		minReplicas := *hpa.Spec.MinReplicas
+		snapshot.MinReplicas = &minReplicas
+	}
+	return snapshot
+}
+
+// RaiseHPABounds 将 HPA 的 minReplicas 提升到目标副本数，必要时同时提升 maxReplicas
+func RaiseHPABounds(ctx context.Context, c client.Client, hpa *autoscalingv2.HorizontalPodAutoscaler, replicas int32) error {
+	minReplicas := int32(1)
+	if hpa.Spec.MinReplicas != nil {
+		minReplicas = *hpa.Spec.MinReplicas
+	}
+	if minReplicas >= replicas && hpa.Spec.MaxReplicas >= replicas {
+		return nil
+	}
+
+	patch := client.MergeFrom(hpa.DeepCopy())
+	if minReplicas < replicas {
+		hpa.Spec.MinReplicas = &replicas
+	}
+	if hpa.Spec.MaxReplicas < replicas {
+		hpa.Spec.MaxReplicas = replicas
+	}
+
+	return c.Patch(ctx, hpa, patch)
+}
+
+// GetHPA 按名称获取 HPA，不存在时返回 nil
+func GetHPA(ctx context.Context, c client.Client, namespace, name string) (*autoscalingv2.HorizontalPodAutoscaler, error) {
+	hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+	if err := c.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, hpa); err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return hpa, nil
+}
+
+// SetHPAMinReplicas 修改 HPA 的 minReplicas，maxReplicas 保持不变
+func SetHPAMinReplicas(ctx context.Context, c client.Client, hpa *autoscalingv2.HorizontalPodAutoscaler, replicas int32) error {
+	patch := client.MergeFrom(hpa.DeepCopy())
+	hpa.Spec.MinReplicas = &replicas
+	return c.Patch(ctx, hpa, patch)
+}
+
+// RestoreHPABounds 按快照恢复 HPA 的副本数边界，HPA 已被删除时直接忽略
+func RestoreHPABounds(ctx context.Context, c client.Client, namespace string, snapshot *opsv1beta1.HPASnapshot) error {
+	hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+	key := types.NamespacedName{Name: snapshot.Name, Namespace: namespace}
+	if err := c.Get(ctx, key, hpa); err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	patch := client.MergeFrom(hpa.DeepCopy())
+	hpa.Spec.MinReplicas = snapshot.MinReplicas
+	hpa.Spec.MaxReplicas = snapshot.MaxReplicas
+
+	return c.Patch(ctx, hpa, patch)
+}
+
+// apiGroupOf 解析 apiVersion 中的 API 组，未指定时使用内置类型的默认版本
+func apiGroupOf(apiVersion, kind string) string {
+	if apiVersion == "" {
+		apiVersion = defaultAPIVersions[kind]
+	}
+	gv, err := schema.ParseGroupVersion(apiVersion)
+	if err != nil {
+		return ""
+	}
+	return gv.Group
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package strategy
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	opsv1beta1 "udesk.cn/ops/api/v1beta1"
+)
+
+var _ = Describe("HPA helpers", func() {
+	var (
+		fakeClient client.Client
+		ctx        context.Context
+		target     *opsv1beta1.ScaleTarget
+		hpaKey     types.NamespacedName
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		scheme := runtime.NewScheme()
+		_ = autoscalingv2.AddToScheme(scheme)
+		_ = opsv1beta1.AddToScheme(scheme)
+
+		hpa := &autoscalingv2.HorizontalPodAutoscaler{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "web-app-hpa",
+				Namespace: "default",
+			},
+			Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+				ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+					APIVersion: "apps/v1",
+					Kind:       KindDeployment,
+					Name:       "web-app",
+				},
+				MinReplicas: int32Ptr(2),
+				MaxReplicas: 5,
+			},
+		}
+		otherHPA := hpa.DeepCopy()
+		otherHPA.Name = "other-hpa"
+		otherHPA.Spec.ScaleTargetRef.Name = "other-app"
+
+		fakeClient = fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(hpa, otherHPA).
+			Build()
+
+		target = &opsv1beta1.ScaleTarget{
+			Kind:      KindDeployment,
+			Name:      "web-app",
+			Namespace: "default",
+		}
+		hpaKey = types.NamespacedName{Name: "web-app-hpa", Namespace: "default"}
+	})
+
+	It("should find the HPA targeting the workload", func() {
+		hpa, err := FindHPA(ctx, fakeClient, target)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(hpa).NotTo(BeNil())
+		Expect(hpa.Name).To(Equal("web-app-hpa"))
+	})
+
+	It("should return nil when no HPA targets the workload", func() {
+		target.Name = "standalone-app"
+		hpa, err := FindHPA(ctx, fakeClient, target)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(hpa).To(BeNil())
+	})
+
+	It("should raise and restore HPA bounds", func() {
+		hpa, err := FindHPA(ctx, fakeClient, target)
+		Expect(err).NotTo(HaveOccurred())
+		snapshot := SnapshotHPA(hpa)
+
+		Expect(RaiseHPABounds(ctx, fakeClient, hpa, 8)).To(Succeed())
+
+		raised := &autoscalingv2.HorizontalPodAutoscaler{}
+		Expect(fakeClient.Get(ctx, hpaKey, raised)).To(Succeed())
+		Expect(*raised.Spec.MinReplicas).To(Equal(int32(8)))
+		Expect(raised.Spec.MaxReplicas).To(Equal(int32(8)))
+
+		Expect(RestoreHPABounds(ctx, fakeClient, "default", snapshot)).To(Succeed())
+
+		restored := &autoscalingv2.HorizontalPodAutoscaler{}
+		Expect(fakeClient.Get(ctx, hpaKey, restored)).To(Succeed())
+		Expect(*restored.Spec.MinReplicas).To(Equal(int32(2)))
+		Expect(restored.Spec.MaxReplicas).To(Equal(int32(5)))
+	})
+
+	It("should only raise minReplicas when maxReplicas is sufficient", func() {
+		hpa, err := FindHPA(ctx, fakeClient, target)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(RaiseHPABounds(ctx, fakeClient, hpa, 4)).To(Succeed())
+
+		raised := &autoscalingv2.HorizontalPodAutoscaler{}
+		Expect(fakeClient.Get(ctx, hpaKey, raised)).To(Succeed())
+		Expect(*raised.Spec.MinReplicas).To(Equal(int32(4)))
+		Expect(raised.Spec.MaxReplicas).To(Equal(int32(5)))
+	})
+
+	It("should ignore a deleted HPA on restore", func() {
+		snapshot := &opsv1beta1.HPASnapshot{Name: "deleted-hpa", MaxReplicas: 3}
+		Expect(RestoreHPABounds(ctx, fakeClient, "default", snapshot)).To(Succeed())
+	})
+})
@@ -0,0 +1,239 @@
+package strategy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	opsv1beta1 "udesk.cn/ops/api/v1beta1"
+	"udesk.cn/ops/internal/types"
+)
+
+// 内置工作负载类型
+const (
+	KindDeployment            = "Deployment"
+	KindStatefulSet           = "StatefulSet"
+	KindReplicaSet            = "ReplicaSet"
+	KindReplicationController = "ReplicationController"
+)
+
+// subResourceScale scale 子资源名称
+const subResourceScale = "scale"
+
+// ErrUnsupportedScaleTarget 表示目标类型无法被扩缩容
+var ErrUnsupportedScaleTarget = errors.New("unsupported scale target")
+
+// defaultAPIVersions 未指定 APIVersion 时内置类型使用的默认版本
+var defaultAPIVersions = map[string]string{
+	KindDeployment:            "apps/v1",
+	KindStatefulSet:           "apps/v1",
+	KindReplicaSet:            "apps/v1",
+	KindReplicationController: "v1",
+}
+
+// scaleDiscovery 用于确认目标资源提供 scale 子资源，由 SetDiscoveryClient 在启动时设置，未设置时不做检查
+var scaleDiscovery discovery.CachedDiscoveryInterface
+
+// SetDiscoveryClient 设置检查 scale 子资源使用的 discovery 客户端，查询结果缓存在内存中
+func SetDiscoveryClient(client discovery.DiscoveryInterface) {
+	scaleDiscovery = memory.NewMemCacheClient(client)
+}
+
+// NewScaleStrategy 根据 ScaleTarget 选择扩缩容策略
+// Deployment 和 StatefulSet 使用专用策略，其他类型通过 REST mapper 解析后使用 scale 子资源
+func NewScaleStrategy(c client.Client, target *opsv1beta1.ScaleTarget) (types.ScaleStrategy, error) {
+	gvk, err := resolveTargetGVK(c, target)
+	if err != nil {
+		return nil, err
+	}
+
+	if gvk.Group == "apps" && gvk.Version == "v1" {
+		switch gvk.Kind {
+		case KindDeployment:
+			return &DeploymentStrategy{}, nil
+		case KindStatefulSet:
+			return &StatefulSetStrategy{}, nil
+		}
+	}
+
+	return &ScaleSubresourceStrategy{}, nil
+}
+
+// resolveTargetGVK 解析目标的 GroupVersionKind 并确认集群中存在该类型
+func resolveTargetGVK(c client.Client, target *opsv1beta1.ScaleTarget) (schema.GroupVersionKind, error) {
+	if target.Kind == "" {
+		return schema.GroupVersionKind{}, fmt.Errorf("%w: kind is required", ErrUnsupportedScaleTarget)
+	}
+
+	defaultAPIVersion, builtin := defaultAPIVersions[target.Kind]
+	apiVersion := target.APIVersion
+	if apiVersion == "" {
+		apiVersion = defaultAPIVersion
+	}
+	if apiVersion == "" {
+		return schema.GroupVersionKind{}, fmt.Errorf("%w: apiVersion is required for kind %s", ErrUnsupportedScaleTarget, target.Kind)
+	}
+
+	gv, err := schema.ParseGroupVersion(apiVersion)
+	if err != nil {
+		return schema.GroupVersionKind{}, fmt.Errorf("%w: invalid apiVersion %q: %v", ErrUnsupportedScaleTarget, apiVersion, err)
+	}
+	gvk := gv.WithKind(target.Kind)
+
+	// 内置类型总是由集群提供，无需查询 REST mapper
+	if builtin && apiVersion == defaultAPIVersion {
+		return gvk, nil
+	}
+
+	mapping, err := c.RESTMapper().RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		if meta.IsNoMatchError(err) {
+			return schema.GroupVersionKind{}, fmt.Errorf("%w: kind %s is not served by the cluster", ErrUnsupportedScaleTarget, gvk.String())
+		}
+		return schema.GroupVersionKind{}, err
+	}
+
+	scalable, err := hasScaleSubresource(mapping.Resource)
+	if err != nil {
+		return schema.GroupVersionKind{}, fmt.Errorf("failed to discover the subresources of %s: %w", mapping.Resource.String(), err)
+	}
+	if !scalable {
+		return schema.GroupVersionKind{}, fmt.Errorf("%w: %s has no scale subresource", ErrUnsupportedScaleTarget, gvk.String())
+	}
+
+	return gvk, nil
+}
+
+// hasScaleSubresource 通过 discovery 检查资源是否提供 scale 子资源；
+// 缓存中没有时刷新一次，以便发现启动后才安装的 CRD
+func hasScaleSubresource(gvr schema.GroupVersionResource) (bool, error) {
+	if scaleDiscovery == nil {
+		return true, nil
+	}
+
+	found, err := findScaleSubresource(gvr)
+	if err != nil || found {
+		return found, err
+	}
+	scaleDiscovery.Invalidate()
+	return findScaleSubresource(gvr)
+}
+
+// findScaleSubresource 在 discovery 缓存的资源列表中查找 <resource>/scale
+func findScaleSubresource(gvr schema.GroupVersionResource) (bool, error) {
+	resources, err := scaleDiscovery.ServerResourcesForGroupVersion(gvr.GroupVersion().String())
+	if errors.Is(err, memory.ErrCacheNotFound) || apierrors.IsNotFound(err) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	for _, resource := range resources.APIResources {
+		if resource.Name == gvr.Resource+"/"+subResourceScale {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// ScaleSubresourceStrategy 通过 /scale 子资源扩缩容任意可扩缩容的工作负载
+// 适用于 ReplicaSet、Argo Rollouts 以及声明了 scale 子资源的 CRD
+type ScaleSubresourceStrategy struct{}
+
+func (s *ScaleSubresourceStrategy) Scale(ctx context.Context, c client.Client, target *opsv1beta1.ScaleTarget, replicas int32) error {
+	obj, scale, err := s.getScale(ctx, c, target)
+	if err != nil {
+		return err
+	}
+
+	scale.Spec.Replicas = replicas
+	return c.SubResource(subResourceScale).Update(ctx, obj, client.WithSubResourceBody(scale))
+}
+
+func (s *ScaleSubresourceStrategy) GetCurrentReplicas(ctx context.Context, c client.Client, target *opsv1beta1.ScaleTarget) (int32, error) {
+	_, scale, err := s.getScale(ctx, c, target)
+	if err != nil {
+		return 0, err
+	}
+
+	return scale.Spec.Replicas, nil
+}
+
+// GetAvailableReplicas scale 子资源只暴露实际副本数，以 status.replicas 作为可用副本数
+func (s *ScaleSubresourceStrategy) GetAvailableReplicas(ctx context.Context, c client.Client, target *opsv1beta1.ScaleTarget) (int32, error) {
+	_, scale, err := s.getScale(ctx, c, target)
+	if err != nil {
+		return 0, err
+	}
+
+	return scale.Status.Replicas, nil
+}
+
+func (s *ScaleSubresourceStrategy) getScale(ctx context.Context, c client.Client, target *opsv1beta1.ScaleTarget) (client.Object, *autoscalingv1.Scale, error) {
+	obj, err := newTargetObject(c, target)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	scale := &autoscalingv1.Scale{}
+	if err := c.SubResource(subResourceScale).Get(ctx, obj, scale); err != nil {
+		return nil, nil, err
+	}
+
+	return obj, scale, nil
+}
+
+// newTargetObject 构造目标对象，scheme 中已注册的类型使用结构化对象，其余使用 unstructured
+func newTargetObject(c client.Client, target *opsv1beta1.ScaleTarget) (client.Object, error) {
+	gvk, err := resolveTargetGVK(c, target)
+	if err != nil {
+		return nil, err
+	}
+
+	var obj client.Object
+	if runtimeObj, err := c.Scheme().New(gvk); err == nil {
+		if typed, ok := runtimeObj.(client.Object); ok {
+			obj = typed
+		}
+	}
+	if obj == nil {
+		u := &unstructured.Unstructured{}
+		u.SetGroupVersionKind(gvk)
+		obj = u
+	}
+
+	obj.SetName(target.Name)
+	obj.SetNamespace(target.Namespace)
+	return obj, nil
+}
+
+// GetTargetObject 以 unstructured 形式读取目标工作负载
+func GetTargetObject(ctx context.Context, c client.Client, target *opsv1beta1.ScaleTarget) (*unstructured.Unstructured, error) {
+	gvk, err := resolveTargetGVK(c, target)
+	if err != nil {
+		return nil, err
+	}
+
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(gvk)
+	if err := c.Get(ctx, client.ObjectKey{Namespace: target.Namespace, Name: target.Name}, obj); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+// SameScaleTarget 判断两个 ScaleTarget 是否指向同一个工作负载
+func SameScaleTarget(a, b *opsv1beta1.ScaleTarget) bool {
+	return a.Kind == b.Kind &&
+		a.Name == b.Name &&
+		a.Namespace == b.Namespace &&
+		apiGroupOf(a.APIVersion, a.Kind) == apiGroupOf(b.APIVersion, b.Kind)
+}
@@ -0,0 +1,172 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package strategy
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	clienttesting "k8s.io/client-go/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	opsv1beta1 "udesk.cn/ops/api/v1beta1"
+)
+
+var _ = Describe("Scale Subresource Strategy", func() {
+	var (
+		strategy   *ScaleSubresourceStrategy
+		fakeClient client.Client
+		ctx        context.Context
+		target     *opsv1beta1.ScaleTarget
+	)
+
+	BeforeEach(func() {
+		strategy = &ScaleSubresourceStrategy{}
+		ctx = context.Background()
+
+		scheme := runtime.NewScheme()
+		_ = appv1.AddToScheme(scheme)
+		_ = opsv1beta1.AddToScheme(scheme)
+
+		replicaSet := &appv1.ReplicaSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-replicaset",
+				Namespace: "default",
+			},
+			Spec: appv1.ReplicaSetSpec{
+				Replicas: int32Ptr(2),
+				Selector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"app": "test"},
+				},
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{
+						Labels: map[string]string{"app": "test"},
+					},
+				},
+			},
+			Status: appv1.ReplicaSetStatus{
+				Replicas: 2,
+			},
+		}
+
+		fakeClient = fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(replicaSet).
+			Build()
+
+		target = &opsv1beta1.ScaleTarget{
+			Kind:       KindReplicaSet,
+			APIVersion: "apps/v1",
+			Name:       "test-replicaset",
+			Namespace:  "default",
+		}
+	})
+
+	Describe("ScaleSubresourceStrategy", func() {
+		It("should read replicas through the scale subresource", func() {
+			replicas, err := strategy.GetCurrentReplicas(ctx, fakeClient, target)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(replicas).To(Equal(int32(2)))
+
+			available, err := strategy.GetAvailableReplicas(ctx, fakeClient, target)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(available).To(Equal(int32(2)))
+		})
+
+		It("should scale through the scale subresource", func() {
+			Expect(strategy.Scale(ctx, fakeClient, target, 6)).To(Succeed())
+
+			updated := &appv1.ReplicaSet{}
+			key := types.NamespacedName{Name: target.Name, Namespace: target.Namespace}
+			Expect(fakeClient.Get(ctx, key, updated)).To(Succeed())
+			Expect(*updated.Spec.Replicas).To(Equal(int32(6)))
+		})
+
+		It("should return error when target not found", func() {
+			target.Name = "non-existent-replicaset"
+			_, err := strategy.GetCurrentReplicas(ctx, fakeClient, target)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("NewScaleStrategy", func() {
+		It("should default apiVersion for built-in kinds", func() {
+			selected, err := NewScaleStrategy(fakeClient, &opsv1beta1.ScaleTarget{Kind: KindDeployment})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(selected).To(BeAssignableToTypeOf(&DeploymentStrategy{}))
+		})
+
+		It("should use the scale subresource for other kinds", func() {
+			selected, err := NewScaleStrategy(fakeClient, target)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(selected).To(BeAssignableToTypeOf(&ScaleSubresourceStrategy{}))
+		})
+
+		It("should reject custom kinds without apiVersion", func() {
+			_, err := NewScaleStrategy(fakeClient, &opsv1beta1.ScaleTarget{Kind: "Rollout"})
+			Expect(err).To(MatchError(ErrUnsupportedScaleTarget))
+		})
+
+		It("should resolve custom kinds through the REST mapper", func() {
+			rolloutGVK := schema.GroupVersionKind{Group: "argoproj.io", Version: "v1alpha1", Kind: "Rollout"}
+			mapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{rolloutGVK.GroupVersion()})
+			mapper.Add(rolloutGVK, meta.RESTScopeNamespace)
+			mappedClient := fake.NewClientBuilder().WithRESTMapper(mapper).Build()
+
+			selected, err := NewScaleStrategy(mappedClient, &opsv1beta1.ScaleTarget{Kind: "Rollout", APIVersion: "argoproj.io/v1alpha1"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(selected).To(BeAssignableToTypeOf(&ScaleSubresourceStrategy{}))
+		})
+
+		It("should reject kinds without the scale subresource", func() {
+			rolloutGVK := schema.GroupVersionKind{Group: "argoproj.io", Version: "v1alpha1", Kind: "Rollout"}
+			daemonSetGVK := schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "DaemonSet"}
+			mapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{rolloutGVK.GroupVersion(), daemonSetGVK.GroupVersion()})
+			mapper.Add(rolloutGVK, meta.RESTScopeNamespace)
+			mapper.Add(daemonSetGVK, meta.RESTScopeNamespace)
+			mappedClient := fake.NewClientBuilder().WithRESTMapper(mapper).Build()
+
+			SetDiscoveryClient(&fakediscovery.FakeDiscovery{Fake: &clienttesting.Fake{Resources: []*metav1.APIResourceList{
+				{GroupVersion: "argoproj.io/v1alpha1", APIResources: []metav1.APIResource{{Name: "rollouts"}, {Name: "rollouts/scale"}}},
+				{GroupVersion: "apps/v1", APIResources: []metav1.APIResource{{Name: "daemonsets"}, {Name: "deployments/scale"}}},
+			}}})
+			DeferCleanup(func() { scaleDiscovery = nil })
+
+			selected, err := NewScaleStrategy(mappedClient, &opsv1beta1.ScaleTarget{Kind: "Rollout", APIVersion: "argoproj.io/v1alpha1"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(selected).To(BeAssignableToTypeOf(&ScaleSubresourceStrategy{}))
+
+			_, err = NewScaleStrategy(mappedClient, &opsv1beta1.ScaleTarget{Kind: "DaemonSet", APIVersion: "apps/v1"})
+			Expect(err).To(MatchError(ErrUnsupportedScaleTarget))
+			Expect(err.Error()).To(ContainSubstring("has no scale subresource"))
+		})
+
+		It("should reject kinds unknown to the cluster", func() {
+			_, err := NewScaleStrategy(fakeClient, &opsv1beta1.ScaleTarget{Kind: "Rollout", APIVersion: "argoproj.io/v1alpha1"})
+			Expect(err).To(MatchError(ErrUnsupportedScaleTarget))
+		})
+	})
+})
@@ -0,0 +1,152 @@
+package strategy
+
+import (
+	"context"
+	"fmt"
+
+	appv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	opsv1beta1 "udesk.cn/ops/api/v1beta1"
+	scaletypes "udesk.cn/ops/internal/types"
+)
+
+// VerticalStrategy 纵向扩缩容策略，修改 Deployment 和 StatefulSet Pod 模板中容器的 requests 和 limits
+type VerticalStrategy struct{}
+
+// NewVerticalStrategy 根据 ScaleTarget 创建纵向扩缩容策略，只支持 Deployment 和 StatefulSet
+func NewVerticalStrategy(c client.Client, target *opsv1beta1.ScaleTarget) (scaletypes.ResourceStrategy, error) {
+	gvk, err := resolveTargetGVK(c, target)
+	if err != nil {
+		return nil, err
+	}
+	if gvk.Group != "apps" || gvk.Version != "v1" || (gvk.Kind != KindDeployment && gvk.Kind != KindStatefulSet) {
+		return nil, fmt.Errorf("%w: vertical scaling only supports apps/v1 Deployment and StatefulSet, got %s", ErrUnsupportedScaleTarget, gvk.String())
+	}
+	return &VerticalStrategy{}, nil
+}
+
+func (s *VerticalStrategy) GetResources(ctx context.Context, c client.Client, target *opsv1beta1.ScaleTarget) ([]opsv1beta1.ContainerResources, error) {
+	_, template, err := s.getWorkload(ctx, c, target)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]opsv1beta1.ContainerResources, 0, len(template.Spec.Containers))
+	for _, container := range template.Spec.Containers {
+		resources = append(resources, opsv1beta1.ContainerResources{
+			Name:      container.Name,
+			Resources: *container.Resources.DeepCopy(),
+		})
+	}
+	return resources, nil
+}
+
+// SetResources 将指定容器的 requests 和 limits 整体替换为给定值，未列出的容器保持不变
+func (s *VerticalStrategy) SetResources(ctx context.Context, c client.Client, target *opsv1beta1.ScaleTarget, resources []opsv1beta1.ContainerResources) error {
+	obj, template, err := s.getWorkload(ctx, c, target)
+	if err != nil {
+		return err
+	}
+
+	patch := client.MergeFrom(obj.DeepCopyObject().(client.Object))
+	for _, desired := range resources {
+		container := findContainer(template.Spec.Containers, desired.Name)
+		if container == nil {
+			return fmt.Errorf("container %s not found in %s %s/%s", desired.Name, target.Kind, target.Namespace, target.Name)
+		}
+		container.Resources = *desired.Resources.DeepCopy()
+	}
+	return c.Patch(ctx, obj, patch)
+}
+
+// IsRolledOut 判断修改后的 Pod 模板是否已滚动到所有副本且副本均已就绪
+func (s *VerticalStrategy) IsRolledOut(ctx context.Context, c client.Client, target *opsv1beta1.ScaleTarget) (bool, error) {
+	obj, _, err := s.getWorkload(ctx, c, target)
+	if err != nil {
+		return false, err
+	}
+
+	switch workload := obj.(type) {
+	case *appv1.Deployment:
+		replicas := replicasOrDefault(workload.Spec.Replicas)
+		status := workload.Status
+		return status.ObservedGeneration >= workload.Generation &&
+			status.UpdatedReplicas == replicas &&
+			status.Replicas == replicas &&
+			status.AvailableReplicas == replicas, nil
+	case *appv1.StatefulSet:
+		replicas := replicasOrDefault(workload.Spec.Replicas)
+		status := workload.Status
+		return status.ObservedGeneration >= workload.Generation &&
+			status.UpdatedReplicas == replicas &&
+			status.ReadyReplicas == replicas &&
+			status.CurrentRevision == status.UpdateRevision, nil
+	}
+	return false, nil
+}
+
+func (s *VerticalStrategy) getWorkload(ctx context.Context, c client.Client, target *opsv1beta1.ScaleTarget) (client.Object, *corev1.PodTemplateSpec, error) {
+	key := types.NamespacedName{Name: target.Name, Namespace: target.Namespace}
+	switch target.Kind {
+	case KindDeployment:
+		deployment := &appv1.Deployment{}
+		if err := c.Get(ctx, key, deployment); err != nil {
+			return nil, nil, err
+		}
+		return deployment, &deployment.Spec.Template, nil
+	case KindStatefulSet:
+		statefulSet := &appv1.StatefulSet{}
+		if err := c.Get(ctx, key, statefulSet); err != nil {
+			return nil, nil, err
+		}
+		return statefulSet, &statefulSet.Spec.Template, nil
+	}
+	return nil, nil, fmt.Errorf("%w: vertical scaling does not support kind %s", ErrUnsupportedScaleTarget, target.Kind)
+}
+
+// OverrideResources 在 base 中同名容器的资源上应用覆盖值：覆盖值中出现的 requests 和 limits 替换原值，
+// 其余资源保持不变，base 中没有的容器被忽略
+func OverrideResources(base, overrides []opsv1beta1.ContainerResources) []opsv1beta1.ContainerResources {
+	result := make([]opsv1beta1.ContainerResources, len(base))
+	for i := range base {
+		result[i] = *base[i].DeepCopy()
+		for _, override := range overrides {
+			if override.Name == result[i].Name {
+				result[i].Resources.Requests = overrideResourceList(result[i].Resources.Requests, override.Resources.Requests)
+				result[i].Resources.Limits = overrideResourceList(result[i].Resources.Limits, override.Resources.Limits)
+			}
+		}
+	}
+	return result
+}
+
+func overrideResourceList(base, override corev1.ResourceList) corev1.ResourceList {
+	if len(override) == 0 {
+		return base
+	}
+	if base == nil {
+		base = corev1.ResourceList{}
+	}
+	for name, quantity := range override {
+		base[name] = quantity.DeepCopy()
+	}
+	return base
+}
+
+func findContainer(containers []corev1.Container, name string) *corev1.Container {
+	for i := range containers {
+		if containers[i].Name == name {
+			return &containers[i]
+		}
+	}
+	return nil
+}
+
+func replicasOrDefault(replicas *int32) int32 {
+	if replicas == nil {
+		return 1
+	}
+	return *replicas
+}
@@ -0,0 +1,149 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package strategy
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	opsv1beta1 "udesk.cn/ops/api/v1beta1"
+	scaletypes "udesk.cn/ops/internal/types"
+)
+
+var _ = Describe("VerticalStrategy", func() {
+	var (
+		ctx        context.Context
+		fakeClient client.Client
+		deployment *appv1.Deployment
+		target     *opsv1beta1.ScaleTarget
+	)
+
+	memory := func(request, limit string) corev1.ResourceRequirements {
+		return corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse(request)},
+			Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse(limit)},
+		}
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		scheme := runtime.NewScheme()
+		Expect(appv1.AddToScheme(scheme)).To(Succeed())
+
+		deployment = &appv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+			Spec: appv1.DeploymentSpec{
+				Replicas: int32Ptr(2),
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{
+							{Name: "app", Resources: memory("1Gi", "2Gi")},
+							{Name: "sidecar", Resources: memory("64Mi", "128Mi")},
+						},
+					},
+				},
+			},
+			Status: appv1.DeploymentStatus{Replicas: 2, UpdatedReplicas: 2, AvailableReplicas: 2},
+		}
+		fakeClient = fake.NewClientBuilder().WithScheme(scheme).WithObjects(deployment).Build()
+		target = &opsv1beta1.ScaleTarget{Kind: KindDeployment, Name: "web", Namespace: "default"}
+	})
+
+	It("should only support Deployment and StatefulSet", func() {
+		_, err := NewVerticalStrategy(fakeClient, target)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = NewVerticalStrategy(fakeClient, &opsv1beta1.ScaleTarget{Kind: KindReplicaSet, Name: "web", Namespace: "default"})
+		Expect(err).To(MatchError(ErrUnsupportedScaleTarget))
+	})
+
+	It("should replace the resources of the given containers only", func() {
+		vertical := &VerticalStrategy{}
+		Expect(vertical.SetResources(ctx, fakeClient, target, []opsv1beta1.ContainerResources{
+			{Name: "app", Resources: memory("2Gi", "4Gi")},
+		})).To(Succeed())
+
+		resources, err := vertical.GetResources(ctx, fakeClient, target)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resources).To(HaveLen(2))
+		Expect(resources[0].Resources.Limits.Memory().String()).To(Equal("4Gi"))
+		Expect(resources[1].Resources.Limits.Memory().String()).To(Equal("128Mi"))
+
+		err = vertical.SetResources(ctx, fakeClient, target, []opsv1beta1.ContainerResources{{Name: "missing"}})
+		Expect(err).To(MatchError(ContainSubstring("container missing not found")))
+	})
+
+	It("should report the rollout once all replicas are updated and available", func() {
+		vertical := &VerticalStrategy{}
+		rolledOut, err := vertical.IsRolledOut(ctx, fakeClient, target)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(rolledOut).To(BeTrue())
+
+		deployment.Status.UpdatedReplicas = 1
+		Expect(fakeClient.Status().Update(ctx, deployment)).To(Succeed())
+		rolledOut, err = vertical.IsRolledOut(ctx, fakeClient, target)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(rolledOut).To(BeFalse())
+	})
+
+	It("should override only the given resources", func() {
+		base := []opsv1beta1.ContainerResources{
+			{Name: "app", Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("500m"),
+					corev1.ResourceMemory: resource.MustParse("1Gi"),
+				},
+			}},
+			{Name: "sidecar", Resources: memory("64Mi", "128Mi")},
+		}
+		overrides := []opsv1beta1.ContainerResources{{Name: "app", Resources: memory("2Gi", "4Gi")}}
+
+		result := OverrideResources(base, overrides)
+		Expect(result[0].Resources.Requests.Cpu().String()).To(Equal("500m"))
+		Expect(result[0].Resources.Requests.Memory().String()).To(Equal("2Gi"))
+		Expect(result[0].Resources.Limits.Memory().String()).To(Equal("4Gi"))
+		Expect(result[1]).To(Equal(base[1]))
+		Expect(base[0].Resources.Requests.Memory().String()).To(Equal("1Gi"))
+	})
+
+	It("should record resizes in the dry-run plan instead of patching", func() {
+		alertScale := &opsv1beta1.AlertScale{}
+		dryRun := NewDryRunResourceStrategy(&VerticalStrategy{}, alertScale)
+
+		Expect(dryRun.SetResources(ctx, fakeClient, target, []opsv1beta1.ContainerResources{
+			{Name: "app", Resources: memory("2Gi", "4Gi")},
+		})).To(Succeed())
+		Expect(alertScale.Status.Plan).To(HaveLen(1))
+		Expect(alertScale.Status.Plan[0].Action).To(Equal(scaletypes.PlannedActionResize))
+
+		resources, err := dryRun.GetResources(ctx, fakeClient, target)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resources[0].Resources.Limits.Memory().String()).To(Equal("4Gi"))
+
+		actual, err := (&VerticalStrategy{}).GetResources(ctx, fakeClient, target)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(actual[0].Resources.Limits.Memory().String()).To(Equal("2Gi"))
+	})
+})
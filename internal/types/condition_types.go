@@ -0,0 +1,118 @@
+package types
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	opsv1beta1 "udesk.cn/ops/api/v1beta1"
+)
+
+// AlertScale Condition 类型常量
+const (
+	// ConditionApproved 扩容请求是否已通过审批
+	ConditionApproved = "Approved"
+	// ConditionProgressing 是否正在调整副本数（扩容或恢复）
+	ConditionProgressing = "Progressing"
+	// ConditionAvailable 目标副本数是否已就绪并处于扩容持续期内
+	ConditionAvailable = "Available"
+	// ConditionFailed 扩容是否失败
+	ConditionFailed = "Failed"
+)
+
+// 状态切换原因常量，同时用作 Condition 和 Event 的 reason
+const (
+	ReasonCreated           = "Created"
+	ReasonAwaitingApproval  = "AwaitingApproval"
+	ReasonApproved          = "Approved"
+	ReasonAutoApproved      = "AutoApproved"
+	ReasonRejected          = "Rejected"
+	ReasonApprovalRecorded  = "ApprovalRecorded"
+	ReasonApprovalIgnored   = "ApprovalIgnored"
+	ReasonApprovalTimeout   = "ApprovalTimeout"
+	ReasonAlertResolved     = "AlertResolved"
+	ReasonScalingStarted    = "ScalingStarted"
+	ReasonScaleUpComplete   = "ScaleUpComplete"
+	ReasonScaleTimeout      = "ScaleTimeout"
+	ReasonScaleRetry        = "ScaleRetry"
+	ReasonCapacityShortfall = "CapacityShortfall"
+	ReasonDurationElapsed   = "DurationElapsed"
+	ReasonCancelled         = "Cancelled"
+	ReasonExtended          = "Extended"
+	ReasonReplicaDrift      = "ReplicaDrift"
+	ReasonRestored          = "Restored"
+	ReasonRestoreHandedOff  = "RestoreHandedOff"
+	ReasonUnsupportedTarget = "UnsupportedTarget"
+	ReasonTargetsUnresolved = "TargetsUnresolved"
+	ReasonGroupRollback     = "GroupRollback"
+	ReasonGuardrailViolated = "GuardrailViolated"
+	ReasonDeleted           = "Deleted"
+)
+
+// ActorSystem 由控制器自动触发的状态切换的操作者
+const ActorSystem = "system"
+
+// RuleActor 由自动审批规则批准时记录的操作者
+func RuleActor(rule string) string {
+	return "rule:" + rule
+}
+
+// MaxTransitionHistory 状态中保留的状态切换记录数量
+const MaxTransitionHistory = 20
+
+// RecordTransition 追加状态切换记录，只保留最近的 MaxTransitionHistory 条
+func RecordTransition(alertScale *opsv1beta1.AlertScale, from, to, actor, reason, message string) {
+	history := append(alertScale.Status.History, opsv1beta1.TransitionRecord{
+		From:    from,
+		To:      to,
+		Time:    metav1.Now(),
+		Actor:   actor,
+		Reason:  reason,
+		Message: message,
+	})
+	if len(history) > MaxTransitionHistory {
+		history = history[len(history)-MaxTransitionHistory:]
+	}
+	alertScale.Status.History = history
+}
+
+// SetTransitionConditions 根据切换后的状态更新 Conditions
+func SetTransitionConditions(alertScale *opsv1beta1.AlertScale, toStatus, reason, message string) {
+	set := func(conditionType string, status metav1.ConditionStatus) {
+		meta.SetStatusCondition(&alertScale.Status.Conditions, metav1.Condition{
+			Type:               conditionType,
+			Status:             status,
+			Reason:             reason,
+			Message:            message,
+			ObservedGeneration: alertScale.Generation,
+		})
+	}
+
+	switch toStatus {
+	case ScaleStatusPending, ScaleStatusApprovaling:
+		set(ConditionApproved, metav1.ConditionFalse)
+		set(ConditionProgressing, metav1.ConditionFalse)
+		set(ConditionAvailable, metav1.ConditionFalse)
+		set(ConditionFailed, metav1.ConditionFalse)
+	case ScaleStatusApproved:
+		set(ConditionApproved, metav1.ConditionTrue)
+	case ScaleStatusRejected:
+		set(ConditionApproved, metav1.ConditionFalse)
+		set(ConditionProgressing, metav1.ConditionFalse)
+	case ScaleStatusScaling:
+		set(ConditionProgressing, metav1.ConditionTrue)
+		set(ConditionAvailable, metav1.ConditionFalse)
+	case ScaleStatusScaled:
+		set(ConditionProgressing, metav1.ConditionFalse)
+		set(ConditionAvailable, metav1.ConditionTrue)
+	case ScaleStatusCompleted:
+		// 恢复原始副本数的过程
+		set(ConditionProgressing, metav1.ConditionTrue)
+		set(ConditionAvailable, metav1.ConditionFalse)
+	case ScaleStatusArchived:
+		set(ConditionProgressing, metav1.ConditionFalse)
+		set(ConditionAvailable, metav1.ConditionFalse)
+	case ScaleStatusFailed:
+		set(ConditionProgressing, metav1.ConditionFalse)
+		set(ConditionAvailable, metav1.ConditionFalse)
+		set(ConditionFailed, metav1.ConditionTrue)
+	}
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package types
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	opsv1beta1 "udesk.cn/ops/api/v1beta1"
+)
+
+var _ = Describe("SetTransitionConditions", func() {
+	var alertScale *opsv1beta1.AlertScale
+
+	BeforeEach(func() {
+		alertScale = &opsv1beta1.AlertScale{ObjectMeta: metav1.ObjectMeta{Generation: 4}}
+	})
+
+	conditionStatus := func(conditionType string) metav1.ConditionStatus {
+		condition := meta.FindStatusCondition(alertScale.Status.Conditions, conditionType)
+		Expect(condition).NotTo(BeNil(), conditionType)
+		return condition.Status
+	}
+
+	It("should initialize all conditions to False when pending", func() {
+		SetTransitionConditions(alertScale, ScaleStatusPending, ReasonCreated, "created")
+
+		Expect(alertScale.Status.Conditions).To(HaveLen(4))
+		for _, condition := range alertScale.Status.Conditions {
+			Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(condition.ObservedGeneration).To(Equal(int64(4)))
+		}
+	})
+
+	It("should follow the AlertScale lifecycle", func() {
+		SetTransitionConditions(alertScale, ScaleStatusPending, ReasonCreated, "created")
+		SetTransitionConditions(alertScale, ScaleStatusApproved, ReasonApproved, "approved")
+		Expect(conditionStatus(ConditionApproved)).To(Equal(metav1.ConditionTrue))
+
+		SetTransitionConditions(alertScale, ScaleStatusScaling, ReasonScalingStarted, "scaling")
+		Expect(conditionStatus(ConditionProgressing)).To(Equal(metav1.ConditionTrue))
+		Expect(conditionStatus(ConditionAvailable)).To(Equal(metav1.ConditionFalse))
+
+		SetTransitionConditions(alertScale, ScaleStatusScaled, ReasonScaleUpComplete, "scaled")
+		Expect(conditionStatus(ConditionProgressing)).To(Equal(metav1.ConditionFalse))
+		Expect(conditionStatus(ConditionAvailable)).To(Equal(metav1.ConditionTrue))
+
+		SetTransitionConditions(alertScale, ScaleStatusCompleted, ReasonDurationElapsed, "restoring")
+		Expect(conditionStatus(ConditionProgressing)).To(Equal(metav1.ConditionTrue))
+		Expect(conditionStatus(ConditionAvailable)).To(Equal(metav1.ConditionFalse))
+
+		SetTransitionConditions(alertScale, ScaleStatusArchived, ReasonRestored, "restored")
+		Expect(conditionStatus(ConditionProgressing)).To(Equal(metav1.ConditionFalse))
+		Expect(conditionStatus(ConditionApproved)).To(Equal(metav1.ConditionTrue))
+		Expect(conditionStatus(ConditionFailed)).To(Equal(metav1.ConditionFalse))
+	})
+
+	It("should mark failures", func() {
+		SetTransitionConditions(alertScale, ScaleStatusFailed, ReasonScaleTimeout, "timed out")
+
+		Expect(conditionStatus(ConditionFailed)).To(Equal(metav1.ConditionTrue))
+		Expect(conditionStatus(ConditionAvailable)).To(Equal(metav1.ConditionFalse))
+		Expect(meta.FindStatusCondition(alertScale.Status.Conditions, ConditionFailed).Reason).To(Equal(ReasonScaleTimeout))
+	})
+})
+
+var _ = Describe("RecordTransition", func() {
+	It("should keep only the most recent transitions", func() {
+		alertScale := &opsv1beta1.AlertScale{}
+		for i := 0; i < MaxTransitionHistory+5; i++ {
+			RecordTransition(alertScale, ScaleStatusScaled, ScaleStatusScaled, ActorSystem, ReasonExtended, fmt.Sprintf("extend %d", i))
+		}
+
+		Expect(alertScale.Status.History).To(HaveLen(MaxTransitionHistory))
+		Expect(alertScale.Status.History[0].Message).To(Equal("extend 5"))
+		Expect(alertScale.Status.History[MaxTransitionHistory-1].Message).To(Equal(fmt.Sprintf("extend %d", MaxTransitionHistory+4)))
+	})
+})
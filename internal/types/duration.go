@@ -0,0 +1,27 @@
+package types
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// durationUnits time.ParseDuration 不支持、但 CRD 校验允许的时长单位
+var durationUnits = map[byte]time.Duration{
+	'd': 24 * time.Hour,
+	'w': 7 * 24 * time.Hour,
+}
+
+// ParseDuration 解析 AlertScale 中的时长字段，在 time.ParseDuration 的基础上支持 d(天)、w(周) 单位
+func ParseDuration(duration string) (time.Duration, error) {
+	if n := len(duration); n > 1 {
+		if unit, ok := durationUnits[duration[n-1]]; ok {
+			value, err := strconv.ParseInt(duration[:n-1], 10, 64)
+			if err != nil {
+				return 0, fmt.Errorf("invalid duration %q", duration)
+			}
+			return time.Duration(value) * unit, nil
+		}
+	}
+	return time.ParseDuration(duration)
+}
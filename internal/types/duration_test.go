@@ -0,0 +1,52 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package types
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ParseDuration", func() {
+	DescribeTable("valid durations",
+		func(duration string, expected time.Duration) {
+			parsed, err := ParseDuration(duration)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(parsed).To(Equal(expected))
+		},
+		Entry("seconds", "30s", 30*time.Second),
+		Entry("minutes", "5m", 5*time.Minute),
+		Entry("hours", "2h", 2*time.Hour),
+		Entry("days", "3d", 72*time.Hour),
+		Entry("weeks", "1w", 7*24*time.Hour),
+		Entry("go duration", "1h30m", 90*time.Minute),
+	)
+
+	DescribeTable("invalid durations",
+		func(duration string) {
+			_, err := ParseDuration(duration)
+			Expect(err).To(HaveOccurred())
+		},
+		Entry("empty", ""),
+		Entry("unit only", "d"),
+		Entry("no unit", "10"),
+		Entry("unknown unit", "10y"),
+		Entry("fraction of days", "1.5d"),
+	)
+})
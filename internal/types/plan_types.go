@@ -0,0 +1,97 @@
+package types
+
+import (
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	opsv1beta1 "udesk.cn/ops/api/v1beta1"
+)
+
+// 试运行计划动作类型
+const (
+	// PlannedActionScale 调整工作负载副本数
+	PlannedActionScale = "Scale"
+	// PlannedActionRaiseHPA 提升 HPA 副本数边界
+	PlannedActionRaiseHPA = "RaiseHPA"
+	// PlannedActionResize 调整 Pod 模板中容器的资源
+	PlannedActionResize = "Resize"
+)
+
+// MaxPlannedActions 状态中保留的试运行计划动作数量
+const MaxPlannedActions = 50
+
+// RecordPlannedAction 追加试运行计划动作，与最近一条相同的动作不重复记录，只保留最近的 MaxPlannedActions 条
+func RecordPlannedAction(alertScale *opsv1beta1.AlertScale, action string, replicas int32, message string) {
+	appendPlannedAction(alertScale, opsv1beta1.PlannedAction{
+		Action:   action,
+		Replicas: replicas,
+		Message:  message,
+	})
+}
+
+// RecordPlannedTargetAction 追加作用于指定工作负载的试运行计划动作，target 为 TargetRef 的返回值
+func RecordPlannedTargetAction(alertScale *opsv1beta1.AlertScale, target, action string, replicas int32, message string) {
+	appendPlannedAction(alertScale, opsv1beta1.PlannedAction{
+		Action:   action,
+		Target:   target,
+		Replicas: replicas,
+		Message:  message,
+	})
+}
+
+// RecordPlannedResize 追加试运行计划中的容器资源调整
+func RecordPlannedResize(alertScale *opsv1beta1.AlertScale, resources []opsv1beta1.ContainerResources, message string) {
+	appendPlannedAction(alertScale, opsv1beta1.PlannedAction{
+		Action:    PlannedActionResize,
+		Resources: resources,
+		Message:   message,
+	})
+}
+
+func appendPlannedAction(alertScale *opsv1beta1.AlertScale, action opsv1beta1.PlannedAction) {
+	plan := alertScale.Status.Plan
+	if n := len(plan); n > 0 && plan[n-1].Action == action.Action && plan[n-1].Target == action.Target && plan[n-1].Replicas == action.Replicas &&
+		equality.Semantic.DeepEqual(plan[n-1].Resources, action.Resources) {
+		return
+	}
+
+	action.Time = metav1.Now()
+	action.Status = alertScale.Status.ScaleStatus.Status
+	plan = append(plan, action)
+	if len(plan) > MaxPlannedActions {
+		plan = plan[len(plan)-MaxPlannedActions:]
+	}
+	alertScale.Status.Plan = plan
+}
+
+// PlannedReplicas 返回试运行计划中最近一次调整的副本数，尚未计划调整时返回 false
+func PlannedReplicas(alertScale *opsv1beta1.AlertScale) (int32, bool) {
+	plan := alertScale.Status.Plan
+	for i := len(plan) - 1; i >= 0; i-- {
+		if plan[i].Action == PlannedActionScale {
+			return plan[i].Replicas, true
+		}
+	}
+	return 0, false
+}
+
+// PlannedTargetReplicas 返回试运行计划中指定工作负载最近一次调整的副本数，未记录工作负载的动作视为作用于任意工作负载
+func PlannedTargetReplicas(alertScale *opsv1beta1.AlertScale, target string) (int32, bool) {
+	plan := alertScale.Status.Plan
+	for i := len(plan) - 1; i >= 0; i-- {
+		if plan[i].Action == PlannedActionScale && (plan[i].Target == "" || plan[i].Target == target) {
+			return plan[i].Replicas, true
+		}
+	}
+	return 0, false
+}
+
+// PlannedResources 返回试运行计划中最近一次调整的容器资源，尚未计划调整时返回 false
+func PlannedResources(alertScale *opsv1beta1.AlertScale) ([]opsv1beta1.ContainerResources, bool) {
+	plan := alertScale.Status.Plan
+	for i := len(plan) - 1; i >= 0; i-- {
+		if plan[i].Action == PlannedActionResize {
+			return plan[i].Resources, true
+		}
+	}
+	return nil, false
+}
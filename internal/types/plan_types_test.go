@@ -0,0 +1,83 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package types
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	opsv1beta1 "udesk.cn/ops/api/v1beta1"
+)
+
+var _ = Describe("Dry-run plan", func() {
+	It("should keep only the most recent planned actions", func() {
+		alertScale := &opsv1beta1.AlertScale{}
+		for i := int32(0); i < MaxPlannedActions+5; i++ {
+			RecordPlannedAction(alertScale, PlannedActionScale, i, "")
+		}
+
+		Expect(alertScale.Status.Plan).To(HaveLen(MaxPlannedActions))
+		Expect(alertScale.Status.Plan[0].Replicas).To(Equal(int32(5)))
+	})
+
+	It("should return the replicas of the latest planned scale", func() {
+		alertScale := &opsv1beta1.AlertScale{}
+		_, planned := PlannedReplicas(alertScale)
+		Expect(planned).To(BeFalse())
+
+		RecordPlannedAction(alertScale, PlannedActionScale, 6, "")
+		RecordPlannedAction(alertScale, PlannedActionRaiseHPA, 8, "")
+		replicas, planned := PlannedReplicas(alertScale)
+		Expect(planned).To(BeTrue())
+		Expect(replicas).To(Equal(int32(6)))
+	})
+
+	It("should return the planned replicas of each group member", func() {
+		alertScale := &opsv1beta1.AlertScale{}
+		RecordPlannedTargetAction(alertScale, "Deployment default/api", PlannedActionScale, 6, "")
+		RecordPlannedTargetAction(alertScale, "Deployment default/api", PlannedActionScale, 6, "")
+		RecordPlannedTargetAction(alertScale, "Deployment default/worker", PlannedActionScale, 4, "")
+		Expect(alertScale.Status.Plan).To(HaveLen(2))
+
+		replicas, planned := PlannedTargetReplicas(alertScale, "Deployment default/api")
+		Expect(planned).To(BeTrue())
+		Expect(replicas).To(Equal(int32(6)))
+		_, planned = PlannedTargetReplicas(alertScale, "Deployment default/gateway")
+		Expect(planned).To(BeFalse())
+	})
+
+	It("should return the resources of the latest planned resize", func() {
+		alertScale := &opsv1beta1.AlertScale{}
+		raised := []opsv1beta1.ContainerResources{{
+			Name: "web",
+			Resources: corev1.ResourceRequirements{
+				Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("2Gi")},
+			},
+		}}
+		RecordPlannedResize(alertScale, raised, "raise")
+		RecordPlannedResize(alertScale, raised, "raise")
+		Expect(alertScale.Status.Plan).To(HaveLen(1))
+
+		restored := []opsv1beta1.ContainerResources{{Name: "web"}}
+		RecordPlannedResize(alertScale, restored, "restore")
+		resources, planned := PlannedResources(alertScale)
+		Expect(planned).To(BeTrue())
+		Expect(resources).To(Equal(restored))
+		Expect(alertScale.Status.Plan).To(HaveLen(2))
+	})
+})
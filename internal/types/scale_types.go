@@ -2,7 +2,9 @@ package types
 
 import (
 	"context"
+	"fmt"
 
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	opsv1beta1 "udesk.cn/ops/api/v1beta1"
@@ -15,13 +17,23 @@ type ScaleStrategy interface {
 	GetAvailableReplicas(ctx context.Context, c client.Client, target *opsv1beta1.ScaleTarget) (int32, error)
 }
 
+// ResourceStrategy 定义纵向扩缩容策略接口，读写 Pod 模板中容器的 requests 和 limits
+type ResourceStrategy interface {
+	GetResources(ctx context.Context, c client.Client, target *opsv1beta1.ScaleTarget) ([]opsv1beta1.ContainerResources, error)
+	SetResources(ctx context.Context, c client.Client, target *opsv1beta1.ScaleTarget, resources []opsv1beta1.ContainerResources) error
+	IsRolledOut(ctx context.Context, c client.Client, target *opsv1beta1.ScaleTarget) (bool, error)
+}
+
 // ScaleContext 包含所有状态处理所需的上下文
 type ScaleContext struct {
-	AlertScale    *opsv1beta1.AlertScale
-	Client        client.Client // 使用接口而不是具体类型
-	Request       ctrl.Request
-	Context       context.Context
-	ScaleStrategy ScaleStrategy
+	AlertScale       *opsv1beta1.AlertScale
+	Client           client.Client // 使用接口而不是具体类型
+	APIReader        client.Reader // 绕过缓存直接读取 API server，为空时使用 Client
+	Request          ctrl.Request
+	Context          context.Context
+	ScaleStrategy    ScaleStrategy
+	ResourceStrategy ResourceStrategy     // 仅纵向扩缩容时设置
+	Recorder         record.EventRecorder // 为空时不记录 Event
 }
 
 // StateHandler 定义状态处理接口
@@ -43,6 +55,93 @@ const (
 	ScaleStatusArchived    = "Archived"
 )
 
+// 扩缩容类型常量
+const (
+	ScaleTypeHorizontal = "Horizontal"
+	ScaleTypeVertical   = "Vertical"
+)
+
+// IsVertical 判断 AlertScale 是否为纵向扩缩容
+func IsVertical(alertScale *opsv1beta1.AlertScale) bool {
+	return alertScale.Spec.ScaleType == ScaleTypeVertical
+}
+
+// IsGroup 判断 AlertScale 是否为同时扩缩容一组工作负载的多目标 AlertScale
+func IsGroup(alertScale *opsv1beta1.AlertScale) bool {
+	return len(alertScale.Spec.ScaleTargets) > 0 || alertScale.Spec.ScaleTargetSelector != nil
+}
+
+// TargetRef 返回工作负载的 "Kind namespace/name" 形式，用于状态说明和试运行计划
+func TargetRef(target *opsv1beta1.ScaleTarget) string {
+	return fmt.Sprintf("%s %s/%s", target.Kind, target.Namespace, target.Name)
+}
+
+// 多目标 AlertScale 成员状态常量
+const (
+	TargetPhasePending   = "Pending"
+	TargetPhaseScaling   = "Scaling"
+	TargetPhaseScaled    = "Scaled"
+	TargetPhaseRestoring = "Restoring"
+	TargetPhaseRestored  = "Restored"
+	TargetPhaseFailed    = "Failed"
+)
+
+// 扩缩容模式常量
+const (
+	ScaleModeAbsolute   = "Absolute"
+	ScaleModeDelta      = "Delta"
+	ScaleModePercentage = "Percentage"
+)
+
+// 副本数漂移处理策略常量
+const (
+	DriftPolicyReassert = "Reassert"
+	DriftPolicyAdopt    = "Adopt"
+	DriftPolicyAbort    = "Abort"
+)
+
+// 扩容前容量预检策略常量
+const (
+	CapacityCheckPolicyBlock   = "Block"
+	CapacityCheckPolicyWarn    = "Warn"
+	CapacityCheckPolicyProceed = "Proceed"
+)
+
+// 容量预检结果常量
+const (
+	CapacityResultSufficient   = "Sufficient"
+	CapacityResultInsufficient = "Insufficient"
+	CapacityResultUnknown      = "Unknown"
+)
+
+// ScaleSchedule 错过触发时间的补偿策略常量
+const (
+	CatchUpPolicySkip      = "Skip"
+	CatchUpPolicyRunLatest = "RunLatest"
+)
+
+// CalculateTargetReplicas 根据扩缩容模式和原始副本数计算目标副本数，并应用 min/max 限制
+func CalculateTargetReplicas(spec *opsv1beta1.AlertScaleSpec, originReplicas int32) int32 {
+	var target int32
+	switch spec.ScaleMode {
+	case ScaleModeDelta:
+		target = originReplicas + spec.ScaleThreshold
+	case ScaleModePercentage:
+		// 向上取整，保证百分比扩容至少增加到不小于期望值
+		target = int32((int64(originReplicas)*int64(spec.ScaleThreshold) + 99) / 100)
+	default:
+		target = spec.ScaleThreshold
+	}
+
+	if spec.MinReplicas != nil && target < *spec.MinReplicas {
+		target = *spec.MinReplicas
+	}
+	if spec.MaxReplicas != nil && target > *spec.MaxReplicas {
+		target = *spec.MaxReplicas
+	}
+	return target
+}
+
 // ScaleNotifyClient 定义通知客户端接口
 type ScaleNotifyClient interface {
 	SendNotify(ctx context.Context, message string) error
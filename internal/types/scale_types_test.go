@@ -147,6 +147,43 @@ var _ = Describe("Scale Types", func() {
 		})
 	})
 
+	Describe("CalculateTargetReplicas", func() {
+		int32Ptr := func(i int32) *int32 { return &i }
+
+		It("should use the threshold as replica count in Absolute mode", func() {
+			spec := &opsv1beta1.AlertScaleSpec{ScaleThreshold: 8}
+			Expect(CalculateTargetReplicas(spec, 3)).To(Equal(int32(8)))
+
+			spec.ScaleMode = ScaleModeAbsolute
+			Expect(CalculateTargetReplicas(spec, 3)).To(Equal(int32(8)))
+		})
+
+		It("should add the threshold to the origin replicas in Delta mode", func() {
+			spec := &opsv1beta1.AlertScaleSpec{ScaleMode: ScaleModeDelta, ScaleThreshold: 3}
+			Expect(CalculateTargetReplicas(spec, 4)).To(Equal(int32(7)))
+		})
+
+		It("should round up the percentage of origin replicas in Percentage mode", func() {
+			spec := &opsv1beta1.AlertScaleSpec{ScaleMode: ScaleModePercentage, ScaleThreshold: 150}
+			Expect(CalculateTargetReplicas(spec, 4)).To(Equal(int32(6)))
+			Expect(CalculateTargetReplicas(spec, 3)).To(Equal(int32(5)))
+		})
+
+		It("should clamp the target to min/max replicas", func() {
+			spec := &opsv1beta1.AlertScaleSpec{
+				ScaleMode:      ScaleModeDelta,
+				ScaleThreshold: 10,
+				MinReplicas:    int32Ptr(2),
+				MaxReplicas:    int32Ptr(12),
+			}
+			Expect(CalculateTargetReplicas(spec, 5)).To(Equal(int32(12)))
+
+			spec.ScaleMode = ScaleModePercentage
+			spec.ScaleThreshold = 50
+			Expect(CalculateTargetReplicas(spec, 1)).To(Equal(int32(2)))
+		})
+	})
+
 	Describe("StateHandler Interface", func() {
 		Context("when implementing StateHandler", func() {
 			It("should implement all required methods", func() {
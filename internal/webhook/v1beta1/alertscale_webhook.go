@@ -0,0 +1,489 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	opsv1beta1 "udesk.cn/ops/api/v1beta1"
+	"udesk.cn/ops/internal/policy"
+	"udesk.cn/ops/internal/strategy"
+	"udesk.cn/ops/internal/types"
+)
+
+// nolint:unused
+// log is for logging in this package.
+var alertscalelog = logf.Log.WithName("alertscale-resource")
+
+// SetupAlertScaleWebhookWithManager registers the webhook for AlertScale in the manager.
+func SetupAlertScaleWebhookWithManager(mgr ctrl.Manager, approval ApprovalOptions) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&opsv1beta1.AlertScale{}).
+		WithValidator(&AlertScaleCustomValidator{Client: mgr.GetClient(), Approval: approval}).
+		WithDefaulter(&AlertScaleCustomDefaulter{Approval: approval}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-ops-udesk-cn-v1beta1-alertscale,mutating=true,failurePolicy=fail,sideEffects=None,groups=ops.udesk.cn,resources=alertscales,verbs=create;update,versions=v1beta1,name=malertscale-v1beta1.kb.io,admissionReviewVersions=v1
+
+// AlertScaleCustomDefaulter struct is responsible for setting default values on the custom resource of the
+// Kind AlertScale when those are created or updated.
+type AlertScaleCustomDefaulter struct {
+	Approval ApprovalOptions
+}
+
+var _ webhook.CustomDefaulter = &AlertScaleCustomDefaulter{}
+
+// Default implements webhook.CustomDefaulter so a webhook will be registered for the Kind AlertScale.
+func (d *AlertScaleCustomDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	alertscale, ok := obj.(*opsv1beta1.AlertScale)
+	if !ok {
+		return fmt.Errorf("expected an AlertScale object but got %T", obj)
+	}
+	alertscalelog.Info("Defaulting for AlertScale", "name", alertscale.GetName())
+
+	// 未指定目标命名空间时默认与 AlertScale 相同
+	if alertscale.Spec.ScaleTarget.Namespace == "" {
+		alertscale.Spec.ScaleTarget.Namespace = alertscale.Namespace
+	}
+	for i := range alertscale.Spec.ScaleTargets {
+		if alertscale.Spec.ScaleTargets[i].Namespace == "" {
+			alertscale.Spec.ScaleTargets[i].Namespace = alertscale.Namespace
+		}
+	}
+	if selector := alertscale.Spec.ScaleTargetSelector; selector != nil && selector.Namespace == "" {
+		selector.Namespace = alertscale.Namespace
+	}
+
+	if err := stampRequestedBy(ctx, alertscale, d.Approval.Delegates); err != nil {
+		return err
+	}
+	if err := stampScaleActionOperator(ctx, alertscale, d.Approval); err != nil {
+		return err
+	}
+	return stampApprovalOperator(ctx, alertscale, d.Approval)
+}
+
+// NOTE: The 'path' attribute must follow a specific pattern and should not be modified directly here.
+// Modifying the path for an invalid path can cause API server errors; failing to locate the webhook.
+// +kubebuilder:webhook:path=/validate-ops-udesk-cn-v1beta1-alertscale,mutating=false,failurePolicy=fail,sideEffects=None,groups=ops.udesk.cn,resources=alertscales,verbs=create;update,versions=v1beta1,name=valertscale-v1beta1.kb.io,admissionReviewVersions=v1
+
+// AlertScaleCustomValidator struct is responsible for validating the AlertScale resource
+// when it is created or updated.
+type AlertScaleCustomValidator struct {
+	Client   client.Client
+	Approval ApprovalOptions
+}
+
+var _ webhook.CustomValidator = &AlertScaleCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type AlertScale.
+func (v *AlertScaleCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	alertscale, ok := obj.(*opsv1beta1.AlertScale)
+	if !ok {
+		return nil, fmt.Errorf("expected an AlertScale object but got %T", obj)
+	}
+	alertscalelog.Info("Validation for AlertScale upon creation", "name", alertscale.GetName())
+
+	if err := validateApproval(ctx, v.Client, v.Approval, nil, alertscale); err != nil {
+		return nil, err
+	}
+	if err := validateApprovalPolicyLabels(ctx, v.Client, nil, alertscale); err != nil {
+		return nil, err
+	}
+	if err := v.validateSpec(ctx, alertscale); err != nil {
+		return nil, err
+	}
+	if err := v.validateGuardrails(ctx, alertscale); err != nil {
+		return nil, err
+	}
+	return nil, validateAutoApprovalRulesChange(ctx, v.Client, nil, alertscale)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type AlertScale.
+func (v *AlertScaleCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	alertscale, ok := newObj.(*opsv1beta1.AlertScale)
+	if !ok {
+		return nil, fmt.Errorf("expected an AlertScale object for the newObj but got %T", newObj)
+	}
+
+	oldAlertScale, ok := oldObj.(*opsv1beta1.AlertScale)
+	if !ok {
+		return nil, fmt.Errorf("expected an AlertScale object for the oldObj but got %T", oldObj)
+	}
+
+	if err := validateApproval(ctx, v.Client, v.Approval, oldAlertScale.Annotations, alertscale); err != nil {
+		return nil, err
+	}
+	if err := validateScaleActionOperator(ctx, v.Approval, oldAlertScale.Annotations, alertscale); err != nil {
+		return nil, err
+	}
+	if err := validateApprovalPolicyLabels(ctx, v.Client, oldAlertScale, alertscale); err != nil {
+		return nil, err
+	}
+
+	// 控制器更新注解、finalizer 时 spec 不变，删除中的对象也不再校验，
+	// 避免目标或模板被删除后阻塞审批处理和 finalizer 移除
+	if alertscale.DeletionTimestamp != nil || equality.Semantic.DeepEqual(oldAlertScale.Spec, alertscale.Spec) {
+		return nil, nil
+	}
+	alertscalelog.Info("Validation for AlertScale upon update", "name", alertscale.GetName())
+
+	// 中途切换试运行会使计划与工作负载的实际副本数不一致
+	if oldAlertScale.Spec.DryRun != alertscale.Spec.DryRun {
+		return nil, fmt.Errorf("spec.dryRun is immutable")
+	}
+	// 纵向扩缩容记录的原始资源与横向的原始副本数不能互相恢复
+	if scaleType(oldAlertScale) != scaleType(alertscale) {
+		return nil, fmt.Errorf("spec.scaleType is immutable")
+	}
+	// 多目标 AlertScale 按 status.targets 恢复，与单目标之间不能互相切换
+	if types.IsGroup(oldAlertScale) != types.IsGroup(alertscale) {
+		return nil, fmt.Errorf("spec.scaleTargets and spec.scaleTargetSelector cannot be added or removed after creation")
+	}
+
+	if err := v.validateSpec(ctx, alertscale); err != nil {
+		return nil, err
+	}
+	// scaleDuration、自动审批等限制随 spec 生效，修改后需要重新检查
+	if err := v.validateGuardrails(ctx, alertscale); err != nil {
+		return nil, err
+	}
+	return nil, validateAutoApprovalRulesChange(ctx, v.Client, oldAlertScale.Spec.AutoApprovalRules, alertscale)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type AlertScale.
+func (v *AlertScaleCustomValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validateSpec 依次校验时长字段、扩缩容目标、纵向扩缩容配置、自动审批规则和通知配置
+func (v *AlertScaleCustomValidator) validateSpec(ctx context.Context, alertscale *opsv1beta1.AlertScale) error {
+	if err := v.validateDurations(alertscale); err != nil {
+		return err
+	}
+	if err := v.validateScaleTarget(ctx, alertscale); err != nil {
+		return err
+	}
+	if err := v.validateVerticalScale(ctx, alertscale); err != nil {
+		return err
+	}
+	if err := v.validateAutoApprovalRules(alertscale); err != nil {
+		return err
+	}
+	return v.validateNotification(ctx, alertscale)
+}
+
+// validateDurations 校验所有时长字段可以解析且大于 0
+func (v *AlertScaleCustomValidator) validateDurations(alertscale *opsv1beta1.AlertScale) error {
+	spec := &alertscale.Spec
+	type durationField struct{ field, value string }
+	durations := []durationField{
+		{"spec.scaleDuration", spec.ScaleDuration},
+		{"spec.scaleTimeout", spec.ScaleTimeout},
+	}
+	if spec.ScaleUpPolicy != nil {
+		durations = append(durations, durationField{"spec.scaleUpPolicy.stepInterval", spec.ScaleUpPolicy.StepInterval})
+	}
+	if spec.ScaleDownPolicy != nil {
+		durations = append(durations, durationField{"spec.scaleDownPolicy.stepInterval", spec.ScaleDownPolicy.StepInterval})
+	}
+	if spec.RetryPolicy != nil {
+		durations = append(durations, durationField{"spec.retryPolicy.backoff", spec.RetryPolicy.Backoff})
+	}
+
+	for _, d := range durations {
+		if d.value == "" {
+			continue
+		}
+		duration, err := types.ParseDuration(d.value)
+		if err != nil {
+			return fmt.Errorf("%s: %v", d.field, err)
+		}
+		if duration <= 0 {
+			return fmt.Errorf("%s must be greater than 0, got %s", d.field, d.value)
+		}
+	}
+	return nil
+}
+
+// validateScaleTarget 校验目标为支持扩缩容的类型且已存在
+func (v *AlertScaleCustomValidator) validateScaleTarget(ctx context.Context, alertscale *opsv1beta1.AlertScale) error {
+	if types.IsGroup(alertscale) {
+		return v.validateGroupTargets(ctx, alertscale)
+	}
+
+	target := alertscale.Spec.ScaleTarget.DeepCopy()
+	if target.Namespace == "" {
+		target.Namespace = alertscale.Namespace
+	}
+	if target.Name == "" {
+		return fmt.Errorf("spec.scaleTarget.name is required")
+	}
+	return v.validateTargetExists(ctx, "spec.scaleTarget", target)
+}
+
+// validateGroupTargets 校验多目标 AlertScale 不同时指定单个目标、不使用不支持的功能，
+// 且每个成员都支持扩缩容、已存在且不重复
+func (v *AlertScaleCustomValidator) validateGroupTargets(ctx context.Context, alertscale *opsv1beta1.AlertScale) error {
+	spec := &alertscale.Spec
+	switch {
+	case spec.ScaleTarget.Name != "" || spec.ScaleTarget.Kind != "":
+		return fmt.Errorf("spec.scaleTarget must not be set together with spec.scaleTargets or spec.scaleTargetSelector")
+	case types.IsVertical(alertscale):
+		return fmt.Errorf("spec.scaleType %s does not support multiple targets", types.ScaleTypeVertical)
+	case spec.ScaleUpPolicy != nil || spec.ScaleDownPolicy != nil:
+		return fmt.Errorf("spec.scaleUpPolicy and spec.scaleDownPolicy are not supported with multiple targets")
+	}
+
+	for i, member := range spec.ScaleTargets {
+		field := fmt.Sprintf("spec.scaleTargets[%d]", i)
+		target := member.ScaleTarget.DeepCopy()
+		if target.Namespace == "" {
+			target.Namespace = alertscale.Namespace
+		}
+		if target.Name == "" {
+			return fmt.Errorf("%s.name is required", field)
+		}
+		for j := range i {
+			other := spec.ScaleTargets[j].ScaleTarget.DeepCopy()
+			if other.Namespace == "" {
+				other.Namespace = alertscale.Namespace
+			}
+			if strategy.SameScaleTarget(target, other) {
+				return fmt.Errorf("%s: duplicate target %s", field, types.TargetRef(target))
+			}
+		}
+		if err := v.validateTargetExists(ctx, field, target); err != nil {
+			return err
+		}
+	}
+
+	members, err := strategy.ResolveGroupTargets(ctx, v.Client, alertscale)
+	if err != nil {
+		if errors.Is(err, strategy.ErrUnsupportedScaleTarget) {
+			return fmt.Errorf("spec.scaleTargetSelector: %v", err)
+		}
+		return fmt.Errorf("failed to resolve spec.scaleTargetSelector: %v", err)
+	}
+	if len(members) == 0 {
+		return fmt.Errorf("spec.scaleTargetSelector: no %s matches the selector", spec.ScaleTargetSelector.Kind)
+	}
+	return nil
+}
+
+// validateTargetExists 校验目标为支持扩缩容的类型且已存在，field 为错误信息中的字段路径
+func (v *AlertScaleCustomValidator) validateTargetExists(ctx context.Context, field string, target *opsv1beta1.ScaleTarget) error {
+	scaleStrategy, err := strategy.NewScaleStrategy(v.Client, target)
+	if err != nil {
+		if errors.Is(err, strategy.ErrUnsupportedScaleTarget) {
+			return fmt.Errorf("%s: %v", field, err)
+		}
+		return fmt.Errorf("failed to resolve %s: %v", field, err)
+	}
+
+	if _, err := scaleStrategy.GetCurrentReplicas(ctx, v.Client, target); err != nil {
+		if apierrors.IsNotFound(err) {
+			return fmt.Errorf("%s: %s %s/%s not found", field, target.Kind, target.Namespace, target.Name)
+		}
+		return fmt.Errorf("failed to get %s %s %s/%s: %v", field, target.Kind, target.Namespace, target.Name, err)
+	}
+	return nil
+}
+
+// validateAutoApprovalRules 校验自动审批规则可以编译且返回 bool
+func (v *AlertScaleCustomValidator) validateAutoApprovalRules(alertscale *opsv1beta1.AlertScale) error {
+	for i, rule := range alertscale.Spec.AutoApprovalRules {
+		if _, err := policy.CompileApprovalRule(rule.Expression); err != nil {
+			return fmt.Errorf("spec.autoApprovalRules[%d] %s: %v", i, rule.Name, err)
+		}
+	}
+	return nil
+}
+
+// validateGuardrails 检查 AlertScale 是否超出命名空间中 ScaleGuardrail 的限制，
+// 目标副本数已在进入 Pending 时确定的使用 status 中的记录，否则按目标当前的副本数估算
+func (v *AlertScaleCustomValidator) validateGuardrails(ctx context.Context, alertscale *opsv1beta1.AlertScale) error {
+	guardrails := &opsv1beta1.ScaleGuardrailList{}
+	if err := v.Client.List(ctx, guardrails, client.InNamespace(alertscale.Namespace)); err != nil {
+		return fmt.Errorf("failed to list ScaleGuardrails: %v", err)
+	}
+	if len(guardrails.Items) == 0 {
+		return nil
+	}
+
+	targets, err := v.guardrailTargets(ctx, alertscale)
+	if err != nil {
+		return err
+	}
+	if violations := policy.Evaluate(guardrails.Items, alertscale, targets); len(violations) > 0 {
+		return fmt.Errorf("rejected by %s", policy.Summarize(violations))
+	}
+	return nil
+}
+
+// guardrailTargets 返回受 ScaleGuardrail 约束的目标及其原始、目标副本数
+func (v *AlertScaleCustomValidator) guardrailTargets(ctx context.Context, alertscale *opsv1beta1.AlertScale) ([]policy.Target, error) {
+	status := &alertscale.Status
+	switch {
+	case types.IsGroup(alertscale) && len(status.Targets) > 0:
+		targets := make([]policy.Target, 0, len(status.Targets))
+		for _, member := range status.Targets {
+			targets = append(targets, policy.Target{ScaleTarget: member.Target, OriginReplicas: member.OriginReplicas, TargetReplicas: member.TargetReplicas})
+		}
+		return targets, nil
+	case !types.IsGroup(alertscale) && status.ScaleStatus.TargetReplicas != nil:
+		target := alertscale.Spec.ScaleTarget
+		if target.Namespace == "" {
+			target.Namespace = alertscale.Namespace
+		}
+		return []policy.Target{{
+			ScaleTarget:    target,
+			OriginReplicas: status.ScaleStatus.OriginReplicas,
+			TargetReplicas: *status.ScaleStatus.TargetReplicas,
+		}}, nil
+	}
+
+	members := []opsv1beta1.GroupTarget{{ScaleTarget: alertscale.Spec.ScaleTarget}}
+	if types.IsGroup(alertscale) {
+		var err error
+		if members, err = strategy.ResolveGroupTargets(ctx, v.Client, alertscale); err != nil {
+			return nil, fmt.Errorf("failed to resolve scale targets: %v", err)
+		}
+	}
+
+	targets := make([]policy.Target, 0, len(members))
+	for _, member := range members {
+		target := member.ScaleTarget
+		if target.Namespace == "" {
+			target.Namespace = alertscale.Namespace
+		}
+		scaleStrategy, err := strategy.NewScaleStrategy(v.Client, &target)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve %s: %v", types.TargetRef(&target), err)
+		}
+		originReplicas, err := scaleStrategy.GetCurrentReplicas(ctx, v.Client, &target)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get replicas of %s: %v", types.TargetRef(&target), err)
+		}
+		targetReplicas := types.CalculateTargetReplicas(&alertscale.Spec, originReplicas)
+		switch {
+		case types.IsVertical(alertscale):
+			targetReplicas = originReplicas
+		case member.Replicas != nil:
+			targetReplicas = *member.Replicas
+		}
+		targets = append(targets, policy.Target{ScaleTarget: target, OriginReplicas: originReplicas, TargetReplicas: targetReplicas})
+	}
+	return targets, nil
+}
+
+// validateVerticalScale 校验纵向扩缩容的目标类型和容器存在，且调整后 requests 不超过 limits
+func (v *AlertScaleCustomValidator) validateVerticalScale(ctx context.Context, alertscale *opsv1beta1.AlertScale) error {
+	if !types.IsVertical(alertscale) {
+		return nil
+	}
+	verticalScale := alertscale.Spec.VerticalScale
+	if verticalScale == nil || len(verticalScale.Containers) == 0 {
+		return fmt.Errorf("spec.verticalScale.containers is required when spec.scaleType is %s", types.ScaleTypeVertical)
+	}
+
+	target := alertscale.Spec.ScaleTarget.DeepCopy()
+	if target.Namespace == "" {
+		target.Namespace = alertscale.Namespace
+	}
+	resourceStrategy, err := strategy.NewVerticalStrategy(v.Client, target)
+	if err != nil {
+		return fmt.Errorf("spec.scaleTarget: %v", err)
+	}
+	current, err := resourceStrategy.GetResources(ctx, v.Client, target)
+	if err != nil {
+		return fmt.Errorf("failed to get containers of spec.scaleTarget %s %s/%s: %v", target.Kind, target.Namespace, target.Name, err)
+	}
+
+	desired := strategy.OverrideResources(current, verticalScale.Containers)
+	for i, container := range verticalScale.Containers {
+		field := fmt.Sprintf("spec.verticalScale.containers[%d]", i)
+		if len(container.Resources.Requests) == 0 && len(container.Resources.Limits) == 0 {
+			return fmt.Errorf("%s: requests or limits is required", field)
+		}
+
+		var resources *corev1.ResourceRequirements
+		for j := range desired {
+			if desired[j].Name == container.Name {
+				resources = &desired[j].Resources
+				break
+			}
+		}
+		if resources == nil {
+			return fmt.Errorf("%s: container %s not found in %s %s/%s", field, container.Name, target.Kind, target.Namespace, target.Name)
+		}
+		for name, request := range resources.Requests {
+			if limit, ok := resources.Limits[name]; ok && request.Cmp(limit) > 0 {
+				return fmt.Errorf("%s: requests.%s %s must not exceed limits.%s %s", field, name, request.String(), name, limit.String())
+			}
+		}
+	}
+	return nil
+}
+
+// validateNotification 校验引用的消息模板存在，且通知类型有可用的默认 ScaleNotifyConfig
+func (v *AlertScaleCustomValidator) validateNotification(ctx context.Context, alertscale *opsv1beta1.AlertScale) error {
+	if name := alertscale.Spec.ScaleNotifyMsgTemplate; name != "" {
+		msgTemplate := &opsv1beta1.ScaleNotifyMsgTemplate{}
+		if err := v.Client.Get(ctx, client.ObjectKey{Namespace: alertscale.Namespace, Name: name}, msgTemplate); err != nil {
+			if apierrors.IsNotFound(err) {
+				return fmt.Errorf("spec.scaleNotifyMsgTemplate: ScaleNotifyMsgTemplate %s/%s not found", alertscale.Namespace, name)
+			}
+			return fmt.Errorf("failed to get ScaleNotifyMsgTemplate %s/%s: %v", alertscale.Namespace, name, err)
+		}
+	}
+
+	// 通知通过默认的 ScaleNotifyConfig 发送，见 ScaleNotifyConfigReconciler
+	if notificationType := alertscale.Spec.ScaleNotificationType; notificationType != "" {
+		var configList opsv1beta1.ScaleNotifyConfigList
+		if err := v.Client.List(ctx, &configList); err != nil {
+			return fmt.Errorf("failed to list ScaleNotifyConfig: %v", err)
+		}
+		for _, config := range configList.Items {
+			if config.Spec.Type == notificationType && config.Spec.Default {
+				return nil
+			}
+		}
+		return fmt.Errorf("spec.scaleNotificationType: no default ScaleNotifyConfig of type %s found", notificationType)
+	}
+	return nil
+}
+
+// scaleType 返回扩缩容类型，未设置时为 Horizontal
+func scaleType(alertscale *opsv1beta1.AlertScale) string {
+	if alertscale.Spec.ScaleType == "" {
+		return types.ScaleTypeHorizontal
+	}
+	return alertscale.Spec.ScaleType
+}
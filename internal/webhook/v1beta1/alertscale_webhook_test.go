@@ -0,0 +1,711 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	admissionv1 "k8s.io/api/admission/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	opsv1beta1 "udesk.cn/ops/api/v1beta1"
+	"udesk.cn/ops/constants"
+	"udesk.cn/ops/internal/types"
+)
+
+// admissionContext returns a context carrying an AdmissionRequest issued by the given user
+func admissionContext(operation admissionv1.Operation, username string, oldObj runtime.Object) context.Context {
+	req := admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+		Operation: operation,
+		UserInfo:  authenticationv1.UserInfo{Username: username},
+	}}
+	if oldObj != nil {
+		req.OldObject = runtime.RawExtension{Object: oldObj}
+		bytes, err := json.Marshal(oldObj)
+		Expect(err).NotTo(HaveOccurred())
+		req.OldObject.Raw = bytes
+	}
+	return admission.NewContextWithRequest(context.Background(), req)
+}
+
+var _ = Describe("AlertScale Webhook", func() {
+	var (
+		ctx        context.Context
+		scheme     *runtime.Scheme
+		deployment *appsv1.Deployment
+		template   *opsv1beta1.ScaleNotifyMsgTemplate
+		config     *opsv1beta1.ScaleNotifyConfig
+		alertScale *opsv1beta1.AlertScale
+	)
+
+	newValidator := func(objects ...client.Object) *AlertScaleCustomValidator {
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+		return &AlertScaleCustomValidator{Client: fakeClient}
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		scheme = runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(opsv1beta1.AddToScheme(scheme)).To(Succeed())
+
+		deployment = &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+			Spec:       appsv1.DeploymentSpec{Replicas: ptr.To(int32(2))},
+		}
+		template = &opsv1beta1.ScaleNotifyMsgTemplate{
+			ObjectMeta: metav1.ObjectMeta{Name: "scale-template", Namespace: "default"},
+		}
+		config = &opsv1beta1.ScaleNotifyConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "lark", Namespace: "default"},
+			Spec:       opsv1beta1.ScaleNotifyConfigSpec{Type: "Lark", Default: true},
+		}
+		alertScale = &opsv1beta1.AlertScale{
+			ObjectMeta: metav1.ObjectMeta{Name: "web-scale", Namespace: "default"},
+			Spec: opsv1beta1.AlertScaleSpec{
+				ScaleTarget: opsv1beta1.ScaleTarget{
+					Name: "web",
+					Kind: "Deployment",
+				},
+				ScaleThreshold:         4,
+				ScaleDuration:          "1d",
+				ScaleTimeout:           "10m",
+				ScaleNotificationType:  "Lark",
+				ScaleNotifyMsgTemplate: "scale-template",
+			},
+		}
+	})
+
+	Context("Default", func() {
+		It("should default the target namespace to the object's namespace", func() {
+			defaulter := &AlertScaleCustomDefaulter{}
+			Expect(defaulter.Default(admissionContext(admissionv1.Create, "alice", nil), alertScale)).To(Succeed())
+			Expect(alertScale.Spec.ScaleTarget.Namespace).To(Equal("default"))
+		})
+
+		It("should keep an explicit target namespace", func() {
+			alertScale.Spec.ScaleTarget.Namespace = "other"
+			defaulter := &AlertScaleCustomDefaulter{}
+			Expect(defaulter.Default(admissionContext(admissionv1.Create, "alice", nil), alertScale)).To(Succeed())
+			Expect(alertScale.Spec.ScaleTarget.Namespace).To(Equal("other"))
+		})
+
+		It("should stamp the authenticated user over a forged requester on create", func() {
+			alertScale.Annotations = map[string]string{constants.RequestedByAnnotation: "mallory"}
+			defaulter := &AlertScaleCustomDefaulter{}
+			Expect(defaulter.Default(admissionContext(admissionv1.Create, "alice", nil), alertScale)).To(Succeed())
+			Expect(alertScale.Annotations).To(HaveKeyWithValue(constants.RequestedByAnnotation, "alice"))
+		})
+
+		It("should keep the requester claimed by a delegate on create", func() {
+			alertScale.Annotations = map[string]string{constants.RequestedByAnnotation: "alertmanager"}
+			defaulter := &AlertScaleCustomDefaulter{Approval: ApprovalOptions{Delegates: []string{"api-server"}}}
+			Expect(defaulter.Default(admissionContext(admissionv1.Create, "api-server", nil), alertScale)).To(Succeed())
+			Expect(alertScale.Annotations).To(HaveKeyWithValue(constants.RequestedByAnnotation, "alertmanager"))
+		})
+
+		It("should keep the original requester on update", func() {
+			alertScale.Annotations = map[string]string{constants.RequestedByAnnotation: "alice"}
+			updated := alertScale.DeepCopy()
+			updated.Annotations[constants.RequestedByAnnotation] = "mallory"
+
+			defaulter := &AlertScaleCustomDefaulter{}
+			Expect(defaulter.Default(admissionContext(admissionv1.Update, "mallory", alertScale), updated)).To(Succeed())
+			Expect(updated.Annotations).To(HaveKeyWithValue(constants.RequestedByAnnotation, "alice"))
+		})
+
+		It("should not allow adding a requester on update", func() {
+			updated := alertScale.DeepCopy()
+			updated.Annotations = map[string]string{constants.RequestedByAnnotation: "mallory"}
+
+			defaulter := &AlertScaleCustomDefaulter{}
+			Expect(defaulter.Default(admissionContext(admissionv1.Update, "mallory", alertScale), updated)).To(Succeed())
+			Expect(updated.Annotations).NotTo(HaveKey(constants.RequestedByAnnotation))
+		})
+	})
+
+	Context("ValidateCreate", func() {
+		It("should accept a valid AlertScale with day and week durations", func() {
+			alertScale.Spec.ScaleUpPolicy = &opsv1beta1.StepPolicy{StepInterval: "1w"}
+			validator := newValidator(deployment, template, config)
+
+			_, err := validator.ValidateCreate(ctx, alertScale)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should reject a target that does not exist", func() {
+			validator := newValidator(template, config)
+
+			_, err := validator.ValidateCreate(ctx, alertScale)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Deployment default/web not found"))
+		})
+
+		It("should reject an unsupported target kind", func() {
+			alertScale.Spec.ScaleTarget.Kind = "CronJob"
+			validator := newValidator(deployment, template, config)
+
+			_, err := validator.ValidateCreate(ctx, alertScale)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.scaleTarget"))
+		})
+
+		It("should reject an unparsable duration", func() {
+			alertScale.Spec.ScaleTimeout = "10x"
+			validator := newValidator(deployment, template, config)
+
+			_, err := validator.ValidateCreate(ctx, alertScale)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.scaleTimeout"))
+		})
+
+		It("should reject a zero duration", func() {
+			alertScale.Spec.ScaleDuration = "0s"
+			validator := newValidator(deployment, template, config)
+
+			_, err := validator.ValidateCreate(ctx, alertScale)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.scaleDuration must be greater than 0"))
+		})
+
+		It("should reject a missing message template", func() {
+			validator := newValidator(deployment, config)
+
+			_, err := validator.ValidateCreate(ctx, alertScale)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("ScaleNotifyMsgTemplate default/scale-template not found"))
+		})
+
+		It("should reject an auto-approval rule that does not compile to bool", func() {
+			alertScale.Spec.AutoApprovalRules = []opsv1beta1.AutoApprovalRule{
+				{Name: "small-delta", Expression: `delta <= 2`},
+				{Name: "broken", Expression: `delta + 1`},
+			}
+			validator := newValidator(deployment, template, config)
+
+			_, err := validator.ValidateCreate(ctx, alertScale)
+			Expect(err).To(MatchError(ContainSubstring("spec.autoApprovalRules[1] broken: expression must return bool")))
+		})
+
+		It("should reject a notification type without a default config", func() {
+			config.Spec.Default = false
+			validator := newValidator(deployment, template, config)
+
+			_, err := validator.ValidateCreate(ctx, alertScale)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("no default ScaleNotifyConfig of type Lark"))
+		})
+	})
+
+	Context("Vertical scaling", func() {
+		BeforeEach(func() {
+			deployment.Spec.Template.Spec.Containers = []corev1.Container{{
+				Name: "app",
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+					Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("2Gi")},
+				},
+			}}
+			alertScale.Spec.ScaleType = types.ScaleTypeVertical
+			alertScale.Spec.VerticalScale = &opsv1beta1.VerticalScale{Containers: []opsv1beta1.ContainerResources{{
+				Name: "app",
+				Resources: corev1.ResourceRequirements{
+					Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("4Gi")},
+				},
+			}}}
+		})
+
+		It("should accept raising the resources of an existing container", func() {
+			validator := newValidator(deployment, template, config)
+
+			_, err := validator.ValidateCreate(ctx, alertScale)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should require the containers to change", func() {
+			alertScale.Spec.VerticalScale = nil
+			validator := newValidator(deployment, template, config)
+
+			_, err := validator.ValidateCreate(ctx, alertScale)
+			Expect(err).To(MatchError(ContainSubstring("spec.verticalScale.containers is required")))
+		})
+
+		It("should reject a container that is not in the pod template", func() {
+			alertScale.Spec.VerticalScale.Containers[0].Name = "missing"
+			validator := newValidator(deployment, template, config)
+
+			_, err := validator.ValidateCreate(ctx, alertScale)
+			Expect(err).To(MatchError(ContainSubstring("container missing not found in Deployment default/web")))
+		})
+
+		It("should reject requests above the resulting limits", func() {
+			alertScale.Spec.VerticalScale.Containers[0].Resources = corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("3Gi")},
+			}
+			validator := newValidator(deployment, template, config)
+
+			_, err := validator.ValidateCreate(ctx, alertScale)
+			Expect(err).To(MatchError(ContainSubstring("requests.memory 3Gi must not exceed limits.memory 2Gi")))
+		})
+
+		It("should reject changing the scale type", func() {
+			validator := newValidator(deployment, template, config)
+			updated := alertScale.DeepCopy()
+			updated.Spec.ScaleType = types.ScaleTypeHorizontal
+
+			_, err := validator.ValidateUpdate(ctx, alertScale, updated)
+			Expect(err).To(MatchError(ContainSubstring("spec.scaleType is immutable")))
+		})
+	})
+
+	Context("Multiple targets", func() {
+		var api *appsv1.Deployment
+
+		BeforeEach(func() {
+			deployment.Labels = map[string]string{"tier": "api"}
+			api = &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default", Labels: map[string]string{"tier": "api"}},
+				Spec:       appsv1.DeploymentSpec{Replicas: ptr.To(int32(3))},
+			}
+			alertScale.Spec.ScaleTarget = opsv1beta1.ScaleTarget{}
+			alertScale.Spec.ScaleTargets = []opsv1beta1.GroupTarget{
+				{ScaleTarget: opsv1beta1.ScaleTarget{Kind: "Deployment", Name: "web"}, Replicas: ptr.To(int32(6))},
+			}
+			alertScale.Spec.ScaleTargetSelector = &opsv1beta1.ScaleTargetSelector{
+				Kind:     "Deployment",
+				Selector: metav1.LabelSelector{MatchLabels: map[string]string{"tier": "api"}},
+			}
+		})
+
+		It("should default the namespaces of the members and the selector", func() {
+			defaulter := &AlertScaleCustomDefaulter{}
+			Expect(defaulter.Default(admissionContext(admissionv1.Create, "alice", nil), alertScale)).To(Succeed())
+			Expect(alertScale.Spec.ScaleTargets[0].Namespace).To(Equal("default"))
+			Expect(alertScale.Spec.ScaleTargetSelector.Namespace).To(Equal("default"))
+		})
+
+		It("should accept existing members and a matching selector", func() {
+			validator := newValidator(deployment, api, template, config)
+
+			_, err := validator.ValidateCreate(ctx, alertScale)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should reject a single target set together with the group", func() {
+			alertScale.Spec.ScaleTarget = opsv1beta1.ScaleTarget{Kind: "Deployment", Name: "web"}
+			validator := newValidator(deployment, api, template, config)
+
+			_, err := validator.ValidateCreate(ctx, alertScale)
+			Expect(err).To(MatchError(ContainSubstring("spec.scaleTarget must not be set together")))
+		})
+
+		It("should reject duplicate members", func() {
+			alertScale.Spec.ScaleTargets = append(alertScale.Spec.ScaleTargets, opsv1beta1.GroupTarget{
+				ScaleTarget: opsv1beta1.ScaleTarget{Kind: "Deployment", Name: "web", Namespace: "default"},
+			})
+			validator := newValidator(deployment, api, template, config)
+
+			_, err := validator.ValidateCreate(ctx, alertScale)
+			Expect(err).To(MatchError(ContainSubstring("spec.scaleTargets[1]: duplicate target Deployment default/web")))
+		})
+
+		It("should reject a member that does not exist", func() {
+			validator := newValidator(api, template, config)
+
+			_, err := validator.ValidateCreate(ctx, alertScale)
+			Expect(err).To(MatchError(ContainSubstring("spec.scaleTargets[0]: Deployment default/web not found")))
+		})
+
+		It("should reject a selector that matches nothing", func() {
+			alertScale.Spec.ScaleTargets = nil
+			alertScale.Spec.ScaleTargetSelector.Selector.MatchLabels["tier"] = "missing"
+			validator := newValidator(deployment, api, template, config)
+
+			_, err := validator.ValidateCreate(ctx, alertScale)
+			Expect(err).To(MatchError(ContainSubstring("no Deployment matches the selector")))
+		})
+
+		It("should reject step policies", func() {
+			alertScale.Spec.ScaleUpPolicy = &opsv1beta1.StepPolicy{StepInterval: "1m"}
+			validator := newValidator(deployment, api, template, config)
+
+			_, err := validator.ValidateCreate(ctx, alertScale)
+			Expect(err).To(MatchError(ContainSubstring("not supported with multiple targets")))
+		})
+
+		It("should reject turning a group into a single target", func() {
+			validator := newValidator(deployment, api, template, config)
+			updated := alertScale.DeepCopy()
+			updated.Spec.ScaleTargets = nil
+			updated.Spec.ScaleTargetSelector = nil
+			updated.Spec.ScaleTarget = opsv1beta1.ScaleTarget{Kind: "Deployment", Name: "web"}
+
+			_, err := validator.ValidateUpdate(ctx, alertScale, updated)
+			Expect(err).To(MatchError(ContainSubstring("cannot be added or removed after creation")))
+		})
+	})
+
+	Context("ScaleGuardrail", func() {
+		var guardrail *opsv1beta1.ScaleGuardrail
+
+		BeforeEach(func() {
+			guardrail = &opsv1beta1.ScaleGuardrail{
+				ObjectMeta: metav1.ObjectMeta{Name: "limits", Namespace: "default"},
+				Spec: opsv1beta1.ScaleGuardrailSpec{
+					MaxReplicas:       ptr.To(int32(8)),
+					MaxDuration:       "1w",
+					AllowedKinds:      []string{"Deployment"},
+					AllowAutoApproval: ptr.To(false),
+				},
+			}
+		})
+
+		It("should accept an AlertScale within the limits", func() {
+			validator := newValidator(deployment, template, config, guardrail)
+
+			_, err := validator.ValidateCreate(ctx, alertScale)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should reject target replicas above the maximum", func() {
+			alertScale.Spec.ScaleMode = types.ScaleModeDelta
+			alertScale.Spec.ScaleThreshold = 7
+			validator := newValidator(deployment, template, config, guardrail)
+
+			_, err := validator.ValidateCreate(ctx, alertScale)
+			Expect(err).To(MatchError(ContainSubstring(
+				"rejected by ScaleGuardrail limits: Deployment default/web: target replicas 9 exceed the maximum of 8")))
+		})
+
+		It("should treat auto-approval rules as auto-approval", func() {
+			alertScale.Spec.AutoApprovalRules = []opsv1beta1.AutoApprovalRule{{Name: "small-delta", Expression: `delta <= 2`}}
+			validator := newValidator(deployment, template, config, guardrail)
+
+			_, err := validator.ValidateCreate(ctx, alertScale)
+			Expect(err).To(MatchError(ContainSubstring("auto-approval is not permitted")))
+		})
+
+		It("should reject auto-approval when the guardrail forbids it", func() {
+			alertScale.Spec.ScaleAutoApproval = true
+			validator := newValidator(deployment, template, config, guardrail)
+
+			_, err := validator.ValidateCreate(ctx, alertScale)
+			Expect(err).To(MatchError(ContainSubstring("auto-approval is not permitted")))
+		})
+
+		It("should check guardrails when the spec changes on update", func() {
+			validator := newValidator(deployment, template, config, guardrail)
+			updated := alertScale.DeepCopy()
+			updated.Spec.ScaleDuration = "2w"
+
+			_, err := validator.ValidateUpdate(ctx, alertScale, updated)
+			Expect(err).To(MatchError(ContainSubstring("scaleDuration 2w exceeds the maximum of 1w")))
+
+			updated.Spec.ScaleDuration = "1d"
+			updated.Spec.ScaleAutoApproval = true
+			_, err = validator.ValidateUpdate(ctx, alertScale, updated)
+			Expect(err).To(MatchError(ContainSubstring("auto-approval is not permitted")))
+		})
+
+		It("should use the recorded replicas once the target replicas are fixed", func() {
+			// The workload has already been scaled, estimating from it would exceed the maximum
+			deployment.Spec.Replicas = ptr.To(int32(6))
+			alertScale.Spec.ScaleMode = types.ScaleModeDelta
+			alertScale.Spec.ScaleThreshold = 3
+			alertScale.Status.ScaleStatus = opsv1beta1.ScaleStatus{Status: types.ScaleStatusScaled, OriginReplicas: 2, TargetReplicas: ptr.To(int32(6))}
+			validator := newValidator(deployment, template, config, guardrail)
+			updated := alertScale.DeepCopy()
+			updated.Spec.ScaleDuration = "2d"
+
+			_, err := validator.ValidateUpdate(ctx, alertScale, updated)
+			Expect(err).NotTo(HaveOccurred())
+
+			alertScale.Status.ScaleStatus.TargetReplicas = nil
+			updated = alertScale.DeepCopy()
+			updated.Spec.ScaleDuration = "2d"
+			_, err = validator.ValidateUpdate(ctx, alertScale, updated)
+			Expect(err).To(MatchError(ContainSubstring("target replicas 9 exceed the maximum of 8")))
+		})
+	})
+
+	Context("ValidateUpdate", func() {
+		It("should skip validation when the spec is unchanged", func() {
+			// The target is gone, but annotation-only updates must still be admitted
+			validator := newValidator(template, config)
+			updated := alertScale.DeepCopy()
+			updated.Annotations = map[string]string{constants.ScaleActionAnnotation: constants.ScaleActionCancel}
+
+			_, err := validator.ValidateUpdate(ctx, alertScale, updated)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should reject toggling dry-run", func() {
+			validator := newValidator(deployment, template, config)
+			updated := alertScale.DeepCopy()
+			updated.Spec.DryRun = true
+
+			_, err := validator.ValidateUpdate(ctx, alertScale, updated)
+			Expect(err).To(MatchError(ContainSubstring("spec.dryRun is immutable")))
+		})
+
+		It("should validate a changed spec", func() {
+			validator := newValidator(deployment, template, config)
+			updated := alertScale.DeepCopy()
+			updated.Spec.ScaleTarget.Name = "missing"
+
+			_, err := validator.ValidateUpdate(ctx, alertScale, updated)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("not found"))
+		})
+	})
+
+	Context("Approval annotations", func() {
+		// newApprovalValidator answers SubjectAccessReviews by granting the approve verb to carol only
+		newApprovalValidator := func(opts ApprovalOptions, objects ...client.Object) *AlertScaleCustomValidator {
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).WithInterceptorFuncs(interceptor.Funcs{
+				Create: func(ctx context.Context, c client.WithWatch, obj client.Object, createOpts ...client.CreateOption) error {
+					if review, ok := obj.(*authorizationv1.SubjectAccessReview); ok {
+						attributes := review.Spec.ResourceAttributes
+						review.Status.Allowed = review.Spec.User == "carol" &&
+							attributes.Verb == constants.ApprovalVerb &&
+							attributes.Resource == "alertscales" &&
+							attributes.Name == "web-scale"
+						return nil
+					}
+					return c.Create(ctx, obj, createOpts...)
+				},
+			}).Build()
+			return &AlertScaleCustomValidator{Client: fakeClient, Approval: opts}
+		}
+
+		approve := func(obj *opsv1beta1.AlertScale, operator string) *opsv1beta1.AlertScale {
+			updated := obj.DeepCopy()
+			updated.Annotations[constants.ApprovalDecisionAnnotation] = constants.ApprovalDecisionApprove
+			updated.Annotations[constants.ApprovalOperatorAnnotation] = operator
+			return updated
+		}
+
+		BeforeEach(func() {
+			alertScale.Annotations = map[string]string{constants.RequestedByAnnotation: "alice"}
+		})
+
+		It("should overwrite a forged approval operator with the admission user", func() {
+			updated := approve(alertScale, "carol")
+
+			defaulter := &AlertScaleCustomDefaulter{}
+			Expect(defaulter.Default(admissionContext(admissionv1.Update, "mallory", alertScale), updated)).To(Succeed())
+			Expect(updated.Annotations).To(HaveKeyWithValue(constants.ApprovalOperatorAnnotation, "mallory"))
+		})
+
+		It("should keep the approver claimed by a delegate", func() {
+			updated := approve(alertScale, "carol")
+
+			defaulter := &AlertScaleCustomDefaulter{Approval: ApprovalOptions{Delegates: []string{"api-server"}}}
+			Expect(defaulter.Default(admissionContext(admissionv1.Update, "api-server", alertScale), updated)).To(Succeed())
+			Expect(updated.Annotations).To(HaveKeyWithValue(constants.ApprovalOperatorAnnotation, "carol"))
+		})
+
+		It("should reject an approval from a user without the approve verb", func() {
+			validator := newApprovalValidator(ApprovalOptions{})
+			updated := approve(alertScale, "mallory")
+
+			_, err := validator.ValidateUpdate(admissionContext(admissionv1.Update, "mallory", alertScale), alertScale, updated)
+			Expect(apierrors.IsForbidden(err)).To(BeTrue())
+		})
+
+		It("should reject an AlertScale created with an approval by a user without the approve verb", func() {
+			validator := newApprovalValidator(ApprovalOptions{})
+			created := approve(alertScale, "alice")
+
+			_, err := validator.ValidateCreate(admissionContext(admissionv1.Create, "alice", nil), created)
+			Expect(apierrors.IsForbidden(err)).To(BeTrue())
+		})
+
+		It("should accept an approval from a user with the approve verb", func() {
+			validator := newApprovalValidator(ApprovalOptions{})
+			updated := approve(alertScale, "carol")
+
+			_, err := validator.ValidateUpdate(admissionContext(admissionv1.Update, "carol", alertScale), alertScale, updated)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should reject self-approval when the policy forbids it", func() {
+			validator := newApprovalValidator(ApprovalOptions{ForbidSelfApproval: true})
+			alertScale.Annotations[constants.RequestedByAnnotation] = "carol"
+			updated := approve(alertScale, "carol")
+
+			_, err := validator.ValidateUpdate(admissionContext(admissionv1.Update, "carol", alertScale), alertScale, updated)
+			Expect(apierrors.IsForbidden(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("cannot approve their own request"))
+		})
+
+		It("should not overwrite a decision that is still being processed", func() {
+			validator := newApprovalValidator(ApprovalOptions{})
+			alertScale.Annotations[constants.ApprovalDecisionAnnotation] = constants.ApprovalDecisionApprove
+			alertScale.Annotations[constants.ApprovalOperatorAnnotation] = "dave"
+			alertScale.Annotations[constants.ApprovalProcessingAnnotation] = constants.ApprovalProcessingPending
+			updated := approve(alertScale, "carol")
+
+			_, err := validator.ValidateUpdate(admissionContext(admissionv1.Update, "carol", alertScale), alertScale, updated)
+			Expect(apierrors.IsConflict(err)).To(BeTrue())
+
+			alertScale.Annotations[constants.ApprovalProcessingAnnotation] = constants.ApprovalProcessingCompleted
+			updated = approve(alertScale, "carol")
+			_, err = validator.ValidateUpdate(admissionContext(admissionv1.Update, "carol", alertScale), alertScale, updated)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should allow a requester to reject their own request", func() {
+			validator := newApprovalValidator(ApprovalOptions{ForbidSelfApproval: true})
+			alertScale.Annotations[constants.RequestedByAnnotation] = "carol"
+			updated := approve(alertScale, "carol")
+			updated.Annotations[constants.ApprovalDecisionAnnotation] = constants.ApprovalDecisionReject
+
+			_, err := validator.ValidateUpdate(admissionContext(admissionv1.Update, "carol", alertScale), alertScale, updated)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should require the approve verb to set auto-approval rules", func() {
+			validator := newApprovalValidator(ApprovalOptions{}, deployment, template, config)
+			created := alertScale.DeepCopy()
+			created.Spec.AutoApprovalRules = []opsv1beta1.AutoApprovalRule{{Name: "always", Expression: "true"}}
+
+			_, err := validator.ValidateCreate(admissionContext(admissionv1.Create, "alice", nil), created)
+			Expect(apierrors.IsForbidden(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("setting spec.autoApprovalRules requires"))
+
+			_, err = validator.ValidateCreate(admissionContext(admissionv1.Create, "carol", nil), created)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should require the approve verb to change auto-approval rules but not to remove them", func() {
+			validator := newApprovalValidator(ApprovalOptions{}, deployment, template, config)
+			alertScale.Spec.AutoApprovalRules = []opsv1beta1.AutoApprovalRule{{Name: "small-delta", Expression: "delta <= 2"}}
+			updated := alertScale.DeepCopy()
+			updated.Spec.AutoApprovalRules[0].Expression = "true"
+
+			_, err := validator.ValidateUpdate(admissionContext(admissionv1.Update, "alice", alertScale), alertScale, updated)
+			Expect(apierrors.IsForbidden(err)).To(BeTrue())
+
+			_, err = validator.ValidateUpdate(admissionContext(admissionv1.Update, "carol", alertScale), alertScale, updated)
+			Expect(err).NotTo(HaveOccurred())
+
+			updated.Spec.AutoApprovalRules = nil
+			_, err = validator.ValidateUpdate(admissionContext(admissionv1.Update, "alice", alertScale), alertScale, updated)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should require the approve verb to exclude an AlertScale from an ApprovalPolicy by labels", func() {
+			approvalPolicy := &opsv1beta1.ApprovalPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "prod"},
+				Spec: opsv1beta1.ApprovalPolicySpec{
+					Namespaces:     []string{"default"},
+					Selector:       &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "critical"}},
+					ApproverGroups: []opsv1beta1.ApproverGroup{{Name: "sre", Users: []string{"carol"}}},
+				},
+			}
+			validator := newApprovalValidator(ApprovalOptions{}, deployment, template, config, approvalPolicy)
+
+			_, err := validator.ValidateCreate(admissionContext(admissionv1.Create, "alice", nil), alertScale)
+			Expect(apierrors.IsForbidden(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("excluding them from ApprovalPolicies prod by labels requires"))
+
+			_, err = validator.ValidateCreate(admissionContext(admissionv1.Create, "carol", nil), alertScale)
+			Expect(err).NotTo(HaveOccurred())
+
+			alertScale.Labels = map[string]string{"tier": "critical"}
+			_, err = validator.ValidateCreate(admissionContext(admissionv1.Create, "alice", nil), alertScale)
+			Expect(err).NotTo(HaveOccurred())
+
+			updated := alertScale.DeepCopy()
+			delete(updated.Labels, "tier")
+			_, err = validator.ValidateUpdate(admissionContext(admissionv1.Update, "alice", alertScale), alertScale, updated)
+			Expect(apierrors.IsForbidden(err)).To(BeTrue())
+
+			_, err = validator.ValidateUpdate(admissionContext(admissionv1.Update, "carol", alertScale), alertScale, updated)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	Context("Scale action annotations", func() {
+		cancel := func(obj *opsv1beta1.AlertScale, operator string) *opsv1beta1.AlertScale {
+			updated := obj.DeepCopy()
+			updated.Annotations[constants.ScaleActionAnnotation] = constants.ScaleActionCancel
+			updated.Annotations[constants.ScaleActionOperatorAnnotation] = operator
+			updated.Annotations[constants.ScaleActionProcessingAnnotation] = constants.ApprovalProcessingPending
+			return updated
+		}
+
+		BeforeEach(func() {
+			alertScale.Annotations = map[string]string{constants.RequestedByAnnotation: "alice"}
+		})
+
+		It("should overwrite a forged operator with the admission user", func() {
+			updated := cancel(alertScale, "carol")
+
+			defaulter := &AlertScaleCustomDefaulter{}
+			Expect(defaulter.Default(admissionContext(admissionv1.Update, "mallory", alertScale), updated)).To(Succeed())
+			Expect(updated.Annotations).To(HaveKeyWithValue(constants.ScaleActionOperatorAnnotation, "mallory"))
+		})
+
+		It("should keep the operator claimed by a delegate", func() {
+			updated := cancel(alertScale, "carol")
+
+			defaulter := &AlertScaleCustomDefaulter{Approval: ApprovalOptions{Delegates: []string{"api-server"}}}
+			Expect(defaulter.Default(admissionContext(admissionv1.Update, "api-server", alertScale), updated)).To(Succeed())
+			Expect(updated.Annotations).To(HaveKeyWithValue(constants.ScaleActionOperatorAnnotation, "carol"))
+		})
+
+		It("should keep the operator when the controller marks the action completed", func() {
+			alertScale = cancel(alertScale, "carol")
+			updated := alertScale.DeepCopy()
+			updated.Annotations[constants.ScaleActionProcessingAnnotation] = constants.ApprovalProcessingCompleted
+
+			defaulter := &AlertScaleCustomDefaulter{}
+			Expect(defaulter.Default(admissionContext(admissionv1.Update, "controller", alertScale), updated)).To(Succeed())
+			Expect(updated.Annotations).To(HaveKeyWithValue(constants.ScaleActionOperatorAnnotation, "carol"))
+		})
+
+		It("should reject an operator that does not match the admission user", func() {
+			validator := newValidator(deployment)
+			updated := cancel(alertScale, "carol")
+
+			_, err := validator.ValidateUpdate(admissionContext(admissionv1.Update, "mallory", alertScale), alertScale, updated)
+			Expect(apierrors.IsForbidden(err)).To(BeTrue())
+
+			updated = cancel(alertScale, "mallory")
+			_, err = validator.ValidateUpdate(admissionContext(admissionv1.Update, "mallory", alertScale), alertScale, updated)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+})
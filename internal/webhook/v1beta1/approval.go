@@ -0,0 +1,211 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	opsv1beta1 "udesk.cn/ops/api/v1beta1"
+	"udesk.cn/ops/constants"
+	"udesk.cn/ops/internal/policy"
+)
+
+// +kubebuilder:rbac:groups=authorization.k8s.io,resources=subjectaccessreviews,verbs=create
+
+// ApprovalOptions 审批注解的准入选项
+type ApprovalOptions struct {
+	// Delegates 可以代他人创建、审批或提交延长/取消操作的用户，如内置 API server 使用的 manager ServiceAccount，
+	// 这些用户提交的 requested-by、approval-operator 和 scale-action-operator 会被保留，其他用户提交时改写为用户本身
+	Delegates []string
+	// ForbidSelfApproval 禁止请求者批准自己创建的 AlertScale
+	ForbidSelfApproval bool
+}
+
+// approvalAnnotationKeys 受保护的审批注解，变更时需要审批权限
+var approvalAnnotationKeys = []string{
+	constants.ApprovalDecisionAnnotation,
+	constants.ApprovalOperatorAnnotation,
+}
+
+// approvalChanged 判断审批注解是否被新增或修改
+func approvalChanged(oldAnnotations, newAnnotations map[string]string) bool {
+	for _, key := range approvalAnnotationKeys {
+		if oldAnnotations[key] != newAnnotations[key] {
+			return true
+		}
+	}
+	return false
+}
+
+// stampApprovalOperator 审批注解变更时将 approval-operator 改写为提交审批的已认证用户，
+// 代理用户提交时保留其填写的审批人
+func stampApprovalOperator(ctx context.Context, obj metav1.Object, opts ApprovalOptions) error {
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get admission request: %w", err)
+	}
+	oldAnnotations, err := oldObjectAnnotations(req)
+	if err != nil {
+		return err
+	}
+
+	annotations := obj.GetAnnotations()
+	if !approvalChanged(oldAnnotations, annotations) {
+		return nil
+	}
+
+	username := req.UserInfo.Username
+	if slices.Contains(opts.Delegates, username) && annotations[constants.ApprovalOperatorAnnotation] != "" {
+		return nil
+	}
+	annotations[constants.ApprovalOperatorAnnotation] = username
+	obj.SetAnnotations(annotations)
+	return nil
+}
+
+// validateApproval 审批注解变更时通过 SubjectAccessReview 检查用户是否拥有 approve 权限，
+// 并按策略拒绝请求者批准自己的请求；上一个决策尚未被控制器处理时返回冲突，避免多方审批时覆盖他人的决策
+func validateApproval(ctx context.Context, c client.Client, opts ApprovalOptions, oldAnnotations map[string]string, alertscale *opsv1beta1.AlertScale) error {
+	annotations := alertscale.GetAnnotations()
+	if !approvalChanged(oldAnnotations, annotations) {
+		return nil
+	}
+
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get admission request: %w", err)
+	}
+
+	groupResource := schema.GroupResource{Group: opsv1beta1.GroupVersion.Group, Resource: "alertscales"}
+	allowed, err := canApprove(ctx, c, req.UserInfo, alertscale)
+	if err != nil {
+		return fmt.Errorf("failed to check approval permission: %w", err)
+	}
+	if !allowed {
+		return apierrors.NewForbidden(groupResource, alertscale.Name,
+			fmt.Errorf("user %q is not allowed to %s alertscales", req.UserInfo.Username, constants.ApprovalVerb))
+	}
+
+	if oldAnnotations[constants.ApprovalProcessingAnnotation] == constants.ApprovalProcessingPending {
+		return apierrors.NewConflict(groupResource, alertscale.Name,
+			fmt.Errorf("the approval decision of %q is still being processed, retry later", oldAnnotations[constants.ApprovalOperatorAnnotation]))
+	}
+
+	if opts.ForbidSelfApproval && annotations[constants.ApprovalDecisionAnnotation] == constants.ApprovalDecisionApprove {
+		requester := annotations[constants.RequestedByAnnotation]
+		if requester != "" && requester == annotations[constants.ApprovalOperatorAnnotation] {
+			return apierrors.NewForbidden(groupResource, alertscale.Name,
+				fmt.Errorf("user %q cannot approve their own request", requester))
+		}
+	}
+	return nil
+}
+
+// validateAutoApprovalRulesChange 设置或修改自动审批规则相当于预先批准匹配的请求，需要 approve 权限；
+// 删除规则只会收紧审批，不做检查
+func validateAutoApprovalRulesChange(ctx context.Context, c client.Client, oldRules []opsv1beta1.AutoApprovalRule, alertscale *opsv1beta1.AlertScale) error {
+	rules := alertscale.Spec.AutoApprovalRules
+	if len(rules) == 0 || equality.Semantic.DeepEqual(oldRules, rules) {
+		return nil
+	}
+
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get admission request: %w", err)
+	}
+	allowed, err := canApprove(ctx, c, req.UserInfo, alertscale)
+	if err != nil {
+		return fmt.Errorf("failed to check approval permission: %w", err)
+	}
+	if !allowed {
+		return apierrors.NewForbidden(schema.GroupResource{Group: opsv1beta1.GroupVersion.Group, Resource: "alertscales"}, alertscale.Name,
+			fmt.Errorf("user %q is not allowed to %s alertscales, which setting spec.autoApprovalRules requires", req.UserInfo.Username, constants.ApprovalVerb))
+	}
+	return nil
+}
+
+// validateApprovalPolicyLabels 标签使 AlertScale 脱离按命名空间本应适用的 ApprovalPolicy 相当于跳过多方审批，
+// 需要 approve 权限；更新时只检查本次标签变更脱离的策略
+func validateApprovalPolicyLabels(ctx context.Context, c client.Client, oldAlertScale, alertscale *opsv1beta1.AlertScale) error {
+	if oldAlertScale != nil && equality.Semantic.DeepEqual(oldAlertScale.Labels, alertscale.Labels) {
+		return nil
+	}
+
+	excluded, err := policy.ExcludedApprovalPolicies(ctx, c, oldAlertScale, alertscale)
+	if err != nil {
+		return err
+	}
+	if len(excluded) == 0 {
+		return nil
+	}
+
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get admission request: %w", err)
+	}
+	allowed, err := canApprove(ctx, c, req.UserInfo, alertscale)
+	if err != nil {
+		return fmt.Errorf("failed to check approval permission: %w", err)
+	}
+	if !allowed {
+		return apierrors.NewForbidden(schema.GroupResource{Group: opsv1beta1.GroupVersion.Group, Resource: "alertscales"}, alertscale.Name,
+			fmt.Errorf("user %q is not allowed to %s alertscales, which excluding them from ApprovalPolicies %s by labels requires",
+				req.UserInfo.Username, constants.ApprovalVerb, strings.Join(excluded, ", ")))
+	}
+	return nil
+}
+
+// canApprove 通过 SubjectAccessReview 检查用户对该 AlertScale 是否拥有 approve 动词权限
+func canApprove(ctx context.Context, c client.Client, userInfo authenticationv1.UserInfo, alertscale *opsv1beta1.AlertScale) (bool, error) {
+	extra := make(map[string]authorizationv1.ExtraValue, len(userInfo.Extra))
+	for key, value := range userInfo.Extra {
+		extra[key] = authorizationv1.ExtraValue(value)
+	}
+
+	review := &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			User:   userInfo.Username,
+			UID:    userInfo.UID,
+			Groups: userInfo.Groups,
+			Extra:  extra,
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace: alertscale.Namespace,
+				Verb:      constants.ApprovalVerb,
+				Group:     opsv1beta1.GroupVersion.Group,
+				Version:   opsv1beta1.GroupVersion.Version,
+				Resource:  "alertscales",
+				Name:      alertscale.Name,
+			},
+		},
+	}
+	if err := c.Create(ctx, review); err != nil {
+		return false, err
+	}
+	return review.Status.Allowed, nil
+}
@@ -0,0 +1,59 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+
+	opsv1beta1 "udesk.cn/ops/api/v1beta1"
+)
+
+// nolint:unused
+// log is for logging in this package.
+var podrebalancelog = logf.Log.WithName("podrebalance-resource")
+
+// SetupPodRebalanceWebhookWithManager registers the webhook for PodRebalance in the manager.
+func SetupPodRebalanceWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&opsv1beta1.PodRebalance{}).
+		WithDefaulter(&PodRebalanceCustomDefaulter{}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-ops-udesk-cn-v1beta1-podrebalance,mutating=true,failurePolicy=fail,sideEffects=None,groups=ops.udesk.cn,resources=podrebalances,verbs=create;update,versions=v1beta1,name=mpodrebalance-v1beta1.kb.io,admissionReviewVersions=v1
+
+// PodRebalanceCustomDefaulter struct is responsible for setting default values on the custom resource of the
+// Kind PodRebalance when those are created or updated.
+type PodRebalanceCustomDefaulter struct{}
+
+var _ webhook.CustomDefaulter = &PodRebalanceCustomDefaulter{}
+
+// Default implements webhook.CustomDefaulter so a webhook will be registered for the Kind PodRebalance.
+func (d *PodRebalanceCustomDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	podrebalance, ok := obj.(*opsv1beta1.PodRebalance)
+	if !ok {
+		return fmt.Errorf("expected a PodRebalance object but got %T", obj)
+	}
+	podrebalancelog.Info("Defaulting for PodRebalance", "name", podrebalance.GetName())
+
+	return stampRequestedBy(ctx, podrebalance, nil)
+}
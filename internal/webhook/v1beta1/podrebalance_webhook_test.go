@@ -0,0 +1,55 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	opsv1beta1 "udesk.cn/ops/api/v1beta1"
+	"udesk.cn/ops/constants"
+)
+
+var _ = Describe("PodRebalance Webhook", func() {
+	var podRebalance *opsv1beta1.PodRebalance
+
+	BeforeEach(func() {
+		podRebalance = &opsv1beta1.PodRebalance{
+			ObjectMeta: metav1.ObjectMeta{Name: "rebalance", Namespace: "default"},
+		}
+	})
+
+	Context("Default", func() {
+		It("should stamp the authenticated user on create", func() {
+			defaulter := &PodRebalanceCustomDefaulter{}
+			Expect(defaulter.Default(admissionContext(admissionv1.Create, "alice", nil), podRebalance)).To(Succeed())
+			Expect(podRebalance.Annotations).To(HaveKeyWithValue(constants.RequestedByAnnotation, "alice"))
+		})
+
+		It("should keep the original requester on update", func() {
+			podRebalance.Annotations = map[string]string{constants.RequestedByAnnotation: "alice"}
+			updated := podRebalance.DeepCopy()
+			delete(updated.Annotations, constants.RequestedByAnnotation)
+
+			defaulter := &PodRebalanceCustomDefaulter{}
+			Expect(defaulter.Default(admissionContext(admissionv1.Update, "bob", podRebalance), updated)).To(Succeed())
+			Expect(updated.Annotations).To(HaveKeyWithValue(constants.RequestedByAnnotation, "alice"))
+		})
+	})
+})
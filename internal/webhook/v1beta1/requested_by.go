@@ -0,0 +1,80 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"udesk.cn/ops/constants"
+)
+
+// stampRequestedBy 创建时将 AdmissionRequest 中已认证的用户写入 requested-by 注解，
+// 更新时恢复为旧对象上的值，防止请求者身份被伪造或篡改；
+// delegates 中的用户代他人创建时保留其填写的请求者，如 API server 记录的 Alertmanager 调用者
+func stampRequestedBy(ctx context.Context, obj metav1.Object, delegates []string) error {
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get admission request: %w", err)
+	}
+
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+
+	switch req.Operation {
+	case admissionv1.Create:
+		if slices.Contains(delegates, req.UserInfo.Username) && annotations[constants.RequestedByAnnotation] != "" {
+			return nil
+		}
+		annotations[constants.RequestedByAnnotation] = req.UserInfo.Username
+	case admissionv1.Update:
+		oldAnnotations, err := oldObjectAnnotations(req)
+		if err != nil {
+			return err
+		}
+		if requestedBy, exists := oldAnnotations[constants.RequestedByAnnotation]; exists {
+			annotations[constants.RequestedByAnnotation] = requestedBy
+		} else {
+			delete(annotations, constants.RequestedByAnnotation)
+		}
+	default:
+		return nil
+	}
+
+	obj.SetAnnotations(annotations)
+	return nil
+}
+
+// oldObjectAnnotations 返回更新请求中旧对象的注解，创建请求没有旧对象时返回 nil
+func oldObjectAnnotations(req admission.Request) (map[string]string, error) {
+	if len(req.OldObject.Raw) == 0 {
+		return nil, nil
+	}
+	oldObj := &metav1.PartialObjectMetadata{}
+	if err := json.Unmarshal(req.OldObject.Raw, oldObj); err != nil {
+		return nil, fmt.Errorf("failed to decode old object: %w", err)
+	}
+	return oldObj.Annotations, nil
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	opsv1beta1 "udesk.cn/ops/api/v1beta1"
+	"udesk.cn/ops/constants"
+)
+
+// scaleActionAnnotationKeys 描述延长/取消操作的注解，任一变更且处理状态为 pending 时视为提交了新的操作
+var scaleActionAnnotationKeys = []string{
+	constants.ScaleActionAnnotation,
+	constants.ScaleActionDurationAnnotation,
+	constants.ScaleActionOperatorAnnotation,
+	constants.ScaleActionReasonAnnotation,
+	constants.ScaleActionTimestampAnnotation,
+	constants.ScaleActionProcessingAnnotation,
+}
+
+// scaleActionSubmitted 判断是否提交了新的延长/取消操作，控制器将处理状态标记为 completed 不算提交
+func scaleActionSubmitted(oldAnnotations, newAnnotations map[string]string) bool {
+	if newAnnotations[constants.ScaleActionProcessingAnnotation] != constants.ApprovalProcessingPending {
+		return false
+	}
+	for _, key := range scaleActionAnnotationKeys {
+		if oldAnnotations[key] != newAnnotations[key] {
+			return true
+		}
+	}
+	return false
+}
+
+// stampScaleActionOperator 提交延长/取消操作时将 scale-action-operator 改写为已认证用户，
+// 代理用户提交时保留其填写的操作员
+func stampScaleActionOperator(ctx context.Context, obj metav1.Object, opts ApprovalOptions) error {
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get admission request: %w", err)
+	}
+	oldAnnotations, err := oldObjectAnnotations(req)
+	if err != nil {
+		return err
+	}
+
+	annotations := obj.GetAnnotations()
+	if !scaleActionSubmitted(oldAnnotations, annotations) {
+		return nil
+	}
+
+	username := req.UserInfo.Username
+	if slices.Contains(opts.Delegates, username) && annotations[constants.ScaleActionOperatorAnnotation] != "" {
+		return nil
+	}
+	annotations[constants.ScaleActionOperatorAnnotation] = username
+	obj.SetAnnotations(annotations)
+	return nil
+}
+
+// validateScaleActionOperator 拒绝操作员与提交用户不一致的延长/取消操作，防止 mutating webhook 之后的修改伪造操作员
+func validateScaleActionOperator(ctx context.Context, opts ApprovalOptions, oldAnnotations map[string]string, alertscale *opsv1beta1.AlertScale) error {
+	annotations := alertscale.GetAnnotations()
+	if !scaleActionSubmitted(oldAnnotations, annotations) {
+		return nil
+	}
+
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get admission request: %w", err)
+	}
+
+	username := req.UserInfo.Username
+	operator := annotations[constants.ScaleActionOperatorAnnotation]
+	if operator == username || operator != "" && slices.Contains(opts.Delegates, username) {
+		return nil
+	}
+	return apierrors.NewForbidden(schema.GroupResource{Group: opsv1beta1.GroupVersion.Group, Resource: "alertscales"}, alertscale.Name,
+		fmt.Errorf("%s %q does not match the requesting user %q", constants.ScaleActionOperatorAnnotation, operator, username))
+}
@@ -112,6 +112,12 @@ var _ = BeforeSuite(func() {
 	err = SetupScaleNotifyConfigWebhookWithManager(mgr)
 	Expect(err).NotTo(HaveOccurred())
 
+	err = SetupAlertScaleWebhookWithManager(mgr, ApprovalOptions{})
+	Expect(err).NotTo(HaveOccurred())
+
+	err = SetupPodRebalanceWebhookWithManager(mgr)
+	Expect(err).NotTo(HaveOccurred())
+
 	// +kubebuilder:scaffold:webhook
 
 	go func() {